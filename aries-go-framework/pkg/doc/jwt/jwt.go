@@ -257,7 +257,7 @@ func checkHeaders(headers map[string]interface{}) error {
 	}
 
 	typ, ok := headers[jose.HeaderType]
-	if ok && typ != TypeJWT {
+	if ok && !isJWTType(typ) {
 		return errors.New("typ is not JWT")
 	}
 
@@ -269,6 +269,27 @@ func checkHeaders(headers map[string]interface{}) error {
 	return nil
 }
 
+// isJWTType checks if typ header denotes JWT. Besides "JWT", explicit JWT types of Verifiable Credential
+// and Presentation (https://tools.ietf.org/html/rfc8725#section-3.11) "vc+jwt" and "vp+jwt" are accepted,
+// optionally with "application/" prefix. Other explicit types (e.g. "at+jwt") are rejected to prevent
+// the confusion of different kinds of JWTs.
+func isJWTType(typ interface{}) bool {
+	typStr, ok := typ.(string)
+	if !ok {
+		return false
+	}
+
+	typStr = strings.ToLower(typStr)
+	typStr = strings.TrimPrefix(typStr, "application/")
+
+	switch typStr {
+	case strings.ToLower(TypeJWT), "vc+jwt", "vp+jwt":
+		return true
+	default:
+		return false
+	}
+}
+
 func toMap(i interface{}) (map[string]interface{}, error) {
 	if reflect.ValueOf(i).Kind() == reflect.Map {
 		return i.(map[string]interface{}), nil
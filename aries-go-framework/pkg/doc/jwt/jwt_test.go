@@ -170,6 +170,23 @@ func TestParse(t *testing.T) {
 	r.Contains(err.Error(), "read JWT claims from JWS payload")
 	r.Nil(token)
 
+	// explicit JWT type
+	signer.headers = map[string]interface{}{"alg": "EdDSA", "typ": "application/vc+jwt", "cty": "vc"}
+	jws, err = buildJWS(signer, map[string]interface{}{"iss": "Albert"})
+	r.NoError(err)
+	token, err = Parse(jws, WithSignatureVerifier(verifier))
+	r.NoError(err)
+	r.NotNil(token)
+
+	// explicit type of other kind of JWT
+	signer.headers = map[string]interface{}{"alg": "EdDSA", "typ": "at+jwt"}
+	jws, err = buildJWS(signer, map[string]interface{}{"iss": "Albert"})
+	r.NoError(err)
+	token, err = Parse(jws, WithSignatureVerifier(verifier))
+	r.Error(err)
+	r.Contains(err.Error(), "typ is not JWT")
+	r.Nil(token)
+
 	// type is not JWT
 	signer.headers = map[string]interface{}{"alg": "EdDSA", "typ": "JWM"}
 	jws, err = buildJWS(signer, map[string]interface{}{"iss": "Albert"})
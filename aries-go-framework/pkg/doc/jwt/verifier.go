@@ -51,23 +51,37 @@ type BasicVerifier struct {
 	compositeVerifier *jose.CompositeAlgSigVerifier
 }
 
+// AlgVerifier defines signature verifier of the custom JWS algorithm.
+type AlgVerifier struct {
+	Alg    string
+	Verify func(pubKey *verifier.PublicKey, message, signature []byte) error
+}
+
 // NewVerifier creates a new basic Verifier.
-func NewVerifier(resolver KeyResolver) *BasicVerifier {
-	// TODO Support pluggable JWS verifiers
-	//  (https://github.com/hyperledger/aries-framework-go/issues/1267)
-	compositeVerifier := jose.NewCompositeAlgSigVerifier(
-		jose.AlgSignatureVerifier{
+// Besides built-in EdDSA and RS256 algorithms, verifiers of custom algorithms can be passed.
+func NewVerifier(resolver KeyResolver, algVerifiers ...AlgVerifier) *BasicVerifier {
+	joseVerifiers := []jose.AlgSignatureVerifier{
+		{
 			Alg:      signatureEdDSA,
 			Verifier: getVerifier(resolver, VerifyEdDSA),
 		},
-		jose.AlgSignatureVerifier{
+		{
 			Alg:      signatureRS256,
 			Verifier: getVerifier(resolver, VerifyRS256),
 		},
-	)
+	}
 	// TODO ECDSA to support NIST P256 curve
 	//  https://github.com/hyperledger/aries-framework-go/issues/1266
 
+	for _, v := range algVerifiers {
+		joseVerifiers = append(joseVerifiers, jose.AlgSignatureVerifier{
+			Alg:      v.Alg,
+			Verifier: getVerifier(resolver, v.Verify),
+		})
+	}
+
+	compositeVerifier := jose.NewCompositeAlgSigVerifier(joseVerifiers[0], joseVerifiers[1:]...)
+
 	return &BasicVerifier{resolver: resolver, compositeVerifier: compositeVerifier}
 }
 
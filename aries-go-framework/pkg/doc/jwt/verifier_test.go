@@ -75,6 +75,34 @@ func TestNewVerifier(t *testing.T) {
 	})
 }
 
+func TestNewVerifier_CustomAlgorithm(t *testing.T) {
+	r := require.New(t)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	r.NoError(err)
+
+	signer := &ed25519Signer{
+		privKey: privKey,
+		headers: prepareJWSHeaders(nil, "CustomEdDSA"),
+	}
+
+	token, err := NewSigned(&Claims{Issuer: "Mike"}, nil, signer)
+	r.NoError(err)
+	jws, err := token.Serialize(false)
+	r.NoError(err)
+
+	keyResolver := getTestKeyResolver(&verifier.PublicKey{Type: kms.ED25519, Value: pubKey}, nil)
+
+	// custom algorithm is not supported by default
+	_, err = jose.ParseJWS(jws, NewVerifier(keyResolver))
+	r.Error(err)
+	r.Contains(err.Error(), "no verifier found for CustomEdDSA algorithm")
+
+	v := NewVerifier(keyResolver, AlgVerifier{Alg: "CustomEdDSA", Verify: VerifyEdDSA})
+	_, err = jose.ParseJWS(jws, v)
+	r.NoError(err)
+}
+
 func TestBasicVerifier_Verify(t *testing.T) { // error corner cases
 	r := require.New(t)
 
@@ -21,6 +21,25 @@ import (
 // ErrContextNotFound is returned when JSON-LD context document is not found in the underlying storage.
 var ErrContextNotFound = errors.New("context not found")
 
+// ContextLoadError is returned when JSON-LD context document cannot be loaded.
+type ContextLoadError struct {
+	// URL of the context document.
+	URL string
+	// Err is the underlying cause, e.g. ErrContextNotFound, network failure, unexpected HTTP status
+	// or malformed JSON document.
+	Err error
+}
+
+// Error returns the error message including the context URL and the cause.
+func (e *ContextLoadError) Error() string {
+	return fmt.Sprintf("load context %s: %v", e.URL, e.Err)
+}
+
+// Unwrap returns the underlying cause.
+func (e *ContextLoadError) Unwrap() error {
+	return e.Err
+}
+
 // provider contains dependencies for the JSON-LD document loader.
 type provider interface {
 	JSONLDContextStore() ld.ContextStore
@@ -97,8 +116,18 @@ func prepareContexts(providerStore ld.RemoteProviderStore, opts *documentLoaderO
 }
 
 // LoadDocument resolves JSON-LD context document by document URL (u) either from storage or from remote URL.
-// If document is not found in the storage and remote DocumentLoader is not specified, ErrContextNotFound is returned.
+// The failure is returned as *ContextLoadError. If document is not found in the storage and remote DocumentLoader
+// is not specified, its cause is ErrContextNotFound.
 func (l *DocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	rd, err := l.loadDocument(u)
+	if err != nil {
+		return nil, &ContextLoadError{URL: u, Err: err}
+	}
+
+	return rd, nil
+}
+
+func (l *DocumentLoader) loadDocument(u string) (*jsonld.RemoteDocument, error) {
 	rd, err := l.store.Get(u)
 	if err != nil {
 		if !errors.Is(err, storage.ErrDataNotFound) {
@@ -193,7 +193,13 @@ func TestLoadDocument(t *testing.T) {
 		rd, err := loader.LoadDocument("https://example.com/context.jsonld")
 
 		require.Nil(t, rd)
-		require.EqualError(t, err, ld.ErrContextNotFound.Error())
+		require.ErrorIs(t, err, ld.ErrContextNotFound)
+
+		var loadErr *ld.ContextLoadError
+
+		require.ErrorAs(t, err, &loadErr)
+		require.Equal(t, "https://example.com/context.jsonld", loadErr.URL)
+		require.EqualError(t, err, "load context https://example.com/context.jsonld: context not found")
 	})
 
 	t.Run("Fail to get context from store", func(t *testing.T) {
@@ -225,6 +231,12 @@ func TestLoadDocument(t *testing.T) {
 		require.Nil(t, rd)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "load remote context document")
+
+		var loadErr *ld.ContextLoadError
+
+		require.ErrorAs(t, err, &loadErr)
+		require.Equal(t, "https://example.com/context.jsonld", loadErr.URL)
+		require.EqualError(t, errors.Unwrap(loadErr.Err), "load document error")
 	})
 
 	t.Run("Fail to save fetched remote document", func(t *testing.T) {
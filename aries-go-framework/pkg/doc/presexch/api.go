@@ -9,6 +9,7 @@ package presexch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/PaesslerAG/gval"
@@ -110,7 +111,8 @@ func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:g
 
 		vc, selectErr := selectByPath(builder, typelessVP, mapping.Path, opts)
 		if selectErr != nil {
-			return nil, fmt.Errorf("failed to select vc from submission: %w", selectErr)
+			return nil, fmt.Errorf("input descriptor id [%s]: failed to select vc from submission: %w",
+				mapping.ID, selectErr)
 		}
 
 		inputDescriptor := pd.inputDescriptor(mapping.ID)
@@ -122,7 +124,11 @@ func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:g
 				inputDescriptor.ID, inputDescriptor.Schema, vc.Context, vc.Types, mapping.Path)
 		}
 
-		// TODO add support for constraints: https://github.com/hyperledger/aries-framework-go/issues/2108
+		err = checkConstraints(inputDescriptor.Constraints, vc)
+		if err != nil {
+			return nil, fmt.Errorf("input descriptor id [%s]: vc selected by path [%s] does not match constraints: %w",
+				inputDescriptor.ID, mapping.Path, err)
+		}
 
 		result[mapping.ID] = vc
 	}
@@ -135,6 +141,69 @@ func (pd *PresentationDefinition) Match(vp *verifiable.Presentation, // nolint:g
 	return result, nil
 }
 
+// ValidatePresentationSubmission checks that the presentation_submission of the Verifiable Presentation
+// points to the credentials satisfying the Presentation Definition (see Match). The error names the first
+// failing input descriptor id.
+func ValidatePresentationSubmission(pd *PresentationDefinition, vp *verifiable.Presentation,
+	contextLoader ld.DocumentLoader, options ...MatchOption) error {
+	_, err := pd.Match(vp, contextLoader, options...)
+
+	return err
+}
+
+// MatchPresentationDefinition evaluates the input descriptors of the Presentation Definition against
+// the credentials and assembles the Verifiable Presentation of the matched ones with presentation_submission
+// (see PresentationDefinition.CreateVP). ErrNoCredentials is returned if the credentials do not satisfy
+// the Presentation Definition.
+func MatchPresentationDefinition(pd *PresentationDefinition, credentials []*verifiable.Credential,
+	contextLoader ld.DocumentLoader, options ...MatchOption) (*verifiable.Presentation, error) {
+	opts := &MatchOptions{}
+
+	for i := range options {
+		options[i](opts)
+	}
+
+	return pd.CreateVP(credentials, contextLoader, opts.CredentialOptions...)
+}
+
+// checkConstraints checks that the credential satisfies the constraints of the input descriptor.
+func checkConstraints(constraints *Constraints, vc *verifiable.Credential) error {
+	if constraints == nil {
+		return nil
+	}
+
+	if constraints.SubjectIsIssuer.isRequired() && !subjectIsIssuer(vc) {
+		return errors.New("subject is not issuer")
+	}
+
+	vcBits, err := json.Marshal(vc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal vc: %w", err)
+	}
+
+	var vcMap map[string]interface{}
+
+	err = json.Unmarshal(vcBits, &vcMap)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal vc: %w", err)
+	}
+
+	for i, field := range constraints.Fields {
+		if field.Predicate.isRequired() {
+			// the value of predicate field is replaced by the result of filter evaluation (see CreateVP),
+			// so only the presence of the field is checked
+			field = &Field{Path: field.Path}
+		}
+
+		err = filterField(field, vcMap)
+		if err != nil {
+			return fmt.Errorf("field.%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
 // Ensures the matched credentials meet the submission requirements.
 func (pd *PresentationDefinition) evalSubmissionRequirements(matched map[string]*verifiable.Credential) error {
 	// TODO support submission requirement rules: https://github.com/hyperledger/aries-framework-go/issues/2109
@@ -321,6 +321,248 @@ func TestPresentationDefinition_Match(t *testing.T) {
 	})
 }
 
+func TestValidatePresentationSubmission(t *testing.T) {
+	uri := randomURI()
+	customType := "CustomType"
+	docLoader := createTestDocumentLoader(t, uri, customType)
+
+	vc := newVC([]string{uri})
+	vc.Types = append(vc.Types, customType)
+	vc.Subject = map[string]interface{}{
+		"id":   uuid.New().String(),
+		"name": "Jayden Doe",
+	}
+
+	newDefinition := func() *PresentationDefinition {
+		return &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: "schema",
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", uri, customType),
+				}},
+			}, {
+				ID: "constraints",
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", uri, customType),
+				}},
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path:   []string{"$.credentialSubject.name"},
+						Filter: &Filter{Pattern: "^Jayden"},
+					}},
+				},
+			}},
+		}
+	}
+
+	newSubmissionVP := func(descriptorMap ...*InputDescriptorMapping) *verifiable.Presentation {
+		return newVP(t, &PresentationSubmission{DescriptorMap: descriptorMap}, vc)
+	}
+
+	options := []MatchOption{
+		WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)),
+	}
+
+	t.Run("valid submission", func(t *testing.T) {
+		vp := newSubmissionVP(
+			&InputDescriptorMapping{ID: "schema", Path: "$.verifiableCredential[0]"},
+			&InputDescriptorMapping{ID: "constraints", Path: "$.verifiableCredential[0]"},
+		)
+
+		require.NoError(t, ValidatePresentationSubmission(newDefinition(), vp, docLoader, options...))
+	})
+
+	t.Run("unknown input descriptor", func(t *testing.T) {
+		vp := newSubmissionVP(&InputDescriptorMapping{ID: "unknown", Path: "$.verifiableCredential[0]"})
+
+		err := ValidatePresentationSubmission(newDefinition(), vp, docLoader, options...)
+		require.EqualError(t, err, "an descriptor_map ID was found that did not match the `id` property "+
+			"of any input descriptor: unknown")
+	})
+
+	t.Run("credential does not match schemas", func(t *testing.T) {
+		pd := newDefinition()
+		pd.InputDescriptors[0].Schema[0].URI = randomURI()
+
+		vp := newSubmissionVP(&InputDescriptorMapping{ID: "schema", Path: "$.verifiableCredential[0]"})
+
+		err := ValidatePresentationSubmission(pd, vp, docLoader, options...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "input descriptor id [schema] requires schemas")
+	})
+
+	t.Run("credential does not match constraints", func(t *testing.T) {
+		pd := newDefinition()
+		pd.InputDescriptors[1].Constraints.Fields[0].Filter.Pattern = "^Morgan"
+
+		vp := newSubmissionVP(
+			&InputDescriptorMapping{ID: "schema", Path: "$.verifiableCredential[0]"},
+			&InputDescriptorMapping{ID: "constraints", Path: "$.verifiableCredential[0]"},
+		)
+
+		err := ValidatePresentationSubmission(pd, vp, docLoader, options...)
+		require.EqualError(t, err, "input descriptor id [constraints]: vc selected by path [$.verifiableCredential[0]] "+
+			"does not match constraints: field.0: path not applicable")
+	})
+
+	t.Run("subject is not issuer", func(t *testing.T) {
+		pd := newDefinition()
+		pd.InputDescriptors[1].Constraints.SubjectIsIssuer = &[]Preference{Required}[0]
+
+		vp := newSubmissionVP(&InputDescriptorMapping{ID: "constraints", Path: "$.verifiableCredential[0]"})
+
+		err := ValidatePresentationSubmission(pd, vp, docLoader, options...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "input descriptor id [constraints]")
+		require.Contains(t, err.Error(), "subject is not issuer")
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		vp := newSubmissionVP(&InputDescriptorMapping{ID: "schema", Path: "$.verifiableCredential[1]"})
+
+		err := ValidatePresentationSubmission(newDefinition(), vp, docLoader, options...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "input descriptor id [schema]: failed to select vc from submission")
+	})
+
+	t.Run("no credential for input descriptor", func(t *testing.T) {
+		vp := newSubmissionVP(&InputDescriptorMapping{ID: "schema", Path: "$.verifiableCredential[0]"})
+
+		err := ValidatePresentationSubmission(newDefinition(), vp, docLoader, options...)
+		require.EqualError(t, err, "failed submission requirements: no credential provided for input descriptor "+
+			"constraints")
+	})
+
+	t.Run("presentation submission context is missing", func(t *testing.T) {
+		vp := newSubmissionVP(
+			&InputDescriptorMapping{ID: "schema", Path: "$.verifiableCredential[0]"},
+			&InputDescriptorMapping{ID: "constraints", Path: "$.verifiableCredential[0]"},
+		)
+		vp.Context = vp.Context[:1]
+
+		err := ValidatePresentationSubmission(newDefinition(), vp, docLoader, options...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "input verifiable presentation must have json-ld context")
+	})
+
+	t.Run("submission with predicate created by CreateVP", func(t *testing.T) {
+		pd := newDefinition()
+		pd.InputDescriptors = pd.InputDescriptors[1:]
+		pd.InputDescriptors[0].Constraints.Fields[0].Predicate = &[]Preference{Required}[0]
+		pd.InputDescriptors[0].Constraints.Fields[0].Filter.Type = &strFilterType
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{vc}, docLoader,
+			verifiable.WithJSONLDDocumentLoader(docLoader))
+		require.NoError(t, err)
+
+		// the predicate field is replaced by the result of filter evaluation which does not match the filter
+		vc, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+		require.Equal(t, true, vc.Subject.([]verifiable.Subject)[0].CustomFields["name"])
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		receivedVP, err := verifiable.ParsePresentation(vpBytes,
+			verifiable.WithPresDisabledProofCheck(),
+			verifiable.WithPresJSONLDDocumentLoader(docLoader))
+		require.NoError(t, err)
+
+		require.NoError(t, ValidatePresentationSubmission(pd, receivedVP, docLoader, options...))
+	})
+
+	t.Run("missing presentation submission", func(t *testing.T) {
+		err := ValidatePresentationSubmission(newDefinition(), newVP(t, nil, vc), docLoader, options...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse descriptor map")
+	})
+}
+
+func TestMatchPresentationDefinition(t *testing.T) {
+	uri := randomURI()
+	customType := "CustomType"
+	docLoader := createTestDocumentLoader(t, uri, customType)
+
+	vc := newVC([]string{uri})
+	vc.Types = append(vc.Types, customType)
+	vc.Subject = map[string]interface{}{
+		"id":   uuid.New().String(),
+		"name": "Jayden Doe",
+	}
+
+	otherVC := newVC(nil)
+	otherVC.ID = "http://test.credential.com/456"
+
+	newDefinition := func(pattern string) *PresentationDefinition {
+		return &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: "name",
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", uri, customType),
+				}},
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path:   []string{"$.credentialSubject.name"},
+						Filter: &Filter{Pattern: pattern},
+					}},
+				},
+			}},
+		}
+	}
+
+	options := []MatchOption{
+		WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(docLoader)),
+	}
+
+	t.Run("presentation of matched credentials", func(t *testing.T) {
+		pd := newDefinition("^Jayden")
+
+		vp, err := MatchPresentationDefinition(pd, []*verifiable.Credential{otherVC, vc}, docLoader, options...)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+		require.Contains(t, vp.Context, PresentationSubmissionJSONLDContextIRI)
+		require.Contains(t, vp.Type, PresentationSubmissionJSONLDType)
+
+		submission, ok := vp.CustomFields["presentation_submission"].(*PresentationSubmission)
+		require.True(t, ok)
+		require.Equal(t, pd.ID, submission.DefinitionID)
+		require.Equal(t, []*InputDescriptorMapping{{
+			ID:     "name",
+			Format: "ldp_vp",
+			Path:   "$.verifiableCredential[0]",
+		}}, submission.DescriptorMap)
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		receivedVP, err := verifiable.ParsePresentation(vpBytes,
+			verifiable.WithPresDisabledProofCheck(),
+			verifiable.WithPresJSONLDDocumentLoader(docLoader))
+		require.NoError(t, err)
+
+		matched, err := pd.Match(receivedVP, docLoader, options...)
+		require.NoError(t, err)
+		require.Len(t, matched, 1)
+		require.Equal(t, vc.ID, matched["name"].ID)
+	})
+
+	t.Run("credentials do not satisfy the definition", func(t *testing.T) {
+		vp, err := MatchPresentationDefinition(newDefinition("^Morgan"), []*verifiable.Credential{otherVC, vc},
+			docLoader, options...)
+		require.ErrorIs(t, err, ErrNoCredentials)
+		require.Nil(t, vp)
+	})
+
+	t.Run("invalid definition", func(t *testing.T) {
+		vp, err := MatchPresentationDefinition(&PresentationDefinition{ID: uuid.New().String()},
+			[]*verifiable.Credential{vc}, docLoader)
+		require.EqualError(t, err, "presentation_definition: input_descriptors is required")
+		require.Nil(t, vp)
+	})
+}
+
 func TestE2E(t *testing.T) {
 	baseSchemaURI := randomURI()
 
@@ -299,6 +299,10 @@ func makeRequirement(requirements []*SubmissionRequirement, descriptors []*Input
 }
 
 // CreateVP creates verifiable presentation.
+// The credentials are matched against input descriptors of the presentation definition (schemas and constraints
+// with their JSONPath fields and filters) and submission requirements. The presentation is composed of the matched
+// credentials and includes presentation_submission which maps every input descriptor to its credential.
+// ErrNoCredentials is returned if the credentials do not satisfy the presentation definition.
 func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
 	if err := pd.ValidateSchema(); err != nil {
@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/piprate/json-gold/ld"
+)
+
+// Canonicalizer converts JSON-LD document into canonical RDF dataset serialized as N-Quads.
+// It's used by Processor (and so by the signature suites) to create and verify linked data proofs.
+type Canonicalizer interface {
+	// Canonicalize returns canonical N-Quads of the JSON-LD document built using the RDF dataset canonicalization
+	// algorithm (e.g. "URDNA2015"). The contexts referenced by the document are loaded by documentLoader
+	// (which is nil if the default one is used).
+	Canonicalize(doc map[string]interface{}, algorithm string, documentLoader ld.DocumentLoader) (string, error)
+}
+
+//nolint:gochecknoglobals
+var canonicalizer = struct {
+	sync.RWMutex
+	c Canonicalizer
+}{
+	c: jsonGoldCanonicalizer{},
+}
+
+// SetCanonicalizer replaces the canonicalizer used by all processors, e.g. by a faster native implementation
+// of URDNA2015. nil restores the default one (based on json-gold).
+// The documents without valid RDF are re-canonicalized by the default canonicalizer after removing invalid
+// data (see WithRemoveAllInvalidRDF).
+func SetCanonicalizer(c Canonicalizer) {
+	canonicalizer.Lock()
+	defer canonicalizer.Unlock()
+
+	if c == nil {
+		c = jsonGoldCanonicalizer{}
+	}
+
+	canonicalizer.c = c
+}
+
+func getCanonicalizer() Canonicalizer {
+	canonicalizer.RLock()
+	defer canonicalizer.RUnlock()
+
+	return canonicalizer.c
+}
+
+// jsonGoldCanonicalizer is the default Canonicalizer based on json-gold.
+type jsonGoldCanonicalizer struct{}
+
+func (jsonGoldCanonicalizer) Canonicalize(doc map[string]interface{}, algorithm string,
+	documentLoader ld.DocumentLoader) (string, error) {
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.ProcessingMode = ld.JsonLd_1_1
+	ldOptions.Algorithm = algorithm
+	ldOptions.Format = format
+	ldOptions.ProduceGeneralizedRdf = true
+	ldOptions.DocumentLoader = documentLoader
+
+	view, err := ld.NewJsonLdProcessor().Normalize(doc, ldOptions)
+	if err != nil {
+		return "", err
+	}
+
+	result, ok := view.(string)
+	if !ok {
+		return "", errors.New("invalid view")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonld_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+)
+
+type mockCanonicalizer struct {
+	result    string
+	err       error
+	algorithm string
+	loader    ld.DocumentLoader
+}
+
+func (c *mockCanonicalizer) Canonicalize(_ map[string]interface{}, algorithm string,
+	documentLoader ld.DocumentLoader) (string, error) {
+	c.algorithm = algorithm
+	c.loader = documentLoader
+
+	return c.result, c.err
+}
+
+func TestSetCanonicalizer(t *testing.T) {
+	loader := ldtestutil.WithDocumentLoader(t)
+
+	newDoc := func(t *testing.T) map[string]interface{} {
+		t.Helper()
+
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(jsonLDProofSample), &doc))
+
+		return doc
+	}
+
+	defaultResult, err := jsonld.Default().GetCanonicalDocument(newDoc(t), loader)
+	require.NoError(t, err)
+
+	t.Run("custom canonicalizer is used", func(t *testing.T) {
+		c := &mockCanonicalizer{result: "<urn:a> <urn:b> <urn:c> .\n"}
+
+		jsonld.SetCanonicalizer(c)
+		defer jsonld.SetCanonicalizer(nil)
+
+		result, err := jsonld.Default().GetCanonicalDocument(newDoc(t), loader)
+		require.NoError(t, err)
+		require.Equal(t, c.result, string(result))
+		require.Equal(t, defaultAlgorithm, c.algorithm)
+		require.NotNil(t, c.loader)
+	})
+
+	t.Run("error of custom canonicalizer", func(t *testing.T) {
+		jsonld.SetCanonicalizer(&mockCanonicalizer{err: errors.New("canonicalization error")})
+		defer jsonld.SetCanonicalizer(nil)
+
+		_, err := jsonld.Default().GetCanonicalDocument(newDoc(t), loader)
+		require.EqualError(t, err, "failed to normalize JSON-LD document: canonicalization error")
+	})
+
+	t.Run("default canonicalizer is restored", func(t *testing.T) {
+		jsonld.SetCanonicalizer(&mockCanonicalizer{})
+		jsonld.SetCanonicalizer(nil)
+
+		result, err := jsonld.Default().GetCanonicalDocument(newDoc(t), loader)
+		require.NoError(t, err)
+		require.Equal(t, defaultResult, result)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	jld "github.com/hyperledger/aries-framework-go/pkg/doc/ld"
 )
 
 const (
@@ -35,6 +36,43 @@ type processorOpts struct {
 	validateRDF      bool
 	documentLoader   ld.DocumentLoader
 	externalContexts []string
+	loadErrRecorder  *contextLoadErrorRecorder
+}
+
+// contextLoadError returns err of JSON-LD operation extended by the context load failure (if any),
+// so the failing context URL and the underlying cause can be retrieved as *ld.ContextLoadError.
+func (opts *processorOpts) contextLoadError(err error) error {
+	if err == nil || opts.loadErrRecorder == nil || opts.loadErrRecorder.err == nil {
+		return err
+	}
+
+	return fmt.Errorf("%v: %w", err, opts.loadErrRecorder.err)
+}
+
+// contextLoadErrorRecorder is a document loader which records the first failure of the underlying loader,
+// as json-gold reports the failure to load a context without its cause.
+type contextLoadErrorRecorder struct {
+	next ld.DocumentLoader
+	err  *jld.ContextLoadError
+}
+
+func (r *contextLoadErrorRecorder) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	rd, err := r.next.LoadDocument(u)
+	if err != nil {
+		var loadErr *jld.ContextLoadError
+
+		if !errors.As(err, &loadErr) {
+			loadErr = &jld.ContextLoadError{URL: u, Err: err}
+		}
+
+		if r.err == nil {
+			r.err = loadErr
+		}
+
+		return nil, loadErr
+	}
+
+	return rd, nil
 }
 
 // ProcessorOpts are the options for JSON LD operations on docs (like canonicalization or compacting).
@@ -98,30 +136,17 @@ func Default() *Processor {
 }
 
 // GetCanonicalDocument returns canonized document of given json ld.
+// The document is canonicalized by the Canonicalizer set by SetCanonicalizer (json-gold based by default).
 func (p *Processor) GetCanonicalDocument(doc map[string]interface{}, opts ...ProcessorOpts) ([]byte, error) {
 	procOptions := prepareOpts(opts)
 
-	ldOptions := ld.NewJsonLdOptions("")
-	ldOptions.ProcessingMode = ld.JsonLd_1_1
-	ldOptions.Algorithm = p.algorithm
-	ldOptions.Format = format
-	ldOptions.ProduceGeneralizedRdf = true
-	ldOptions.DocumentLoader = procOptions.documentLoader
-
 	if len(procOptions.externalContexts) > 0 {
 		doc["@context"] = AppendExternalContexts(doc["@context"], procOptions.externalContexts...)
 	}
 
-	proc := ld.NewJsonLdProcessor()
-
-	view, err := proc.Normalize(doc, ldOptions)
+	result, err := getCanonicalizer().Canonicalize(doc, p.algorithm, procOptions.documentLoader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to normalize JSON-LD document: %w", err)
-	}
-
-	result, ok := view.(string)
-	if !ok {
-		return nil, fmt.Errorf("failed to normalize JSON-LD document, invalid view")
+		return nil, fmt.Errorf("failed to normalize JSON-LD document: %w", procOptions.contextLoadError(err))
 	}
 
 	result, err = p.removeMatchingInvalidRDFs(result, procOptions)
@@ -173,7 +198,66 @@ func (p *Processor) Compact(input, context map[string]interface{},
 		context = map[string]interface{}{"@context": inputContext}
 	}
 
-	return ld.NewJsonLdProcessor().Compact(input, context, ldOptions)
+	compacted, err := ld.NewJsonLdProcessor().Compact(input, context, ldOptions)
+	if err != nil {
+		return nil, procOptions.contextLoadError(err)
+	}
+
+	return compacted, nil
+}
+
+// Expand expands given json ld object.
+func (p *Processor) Expand(input map[string]interface{}, opts ...ProcessorOpts) ([]interface{}, error) {
+	procOptions := prepareOpts(opts)
+
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.ProcessingMode = ld.JsonLd_1_1
+	ldOptions.DocumentLoader = procOptions.documentLoader
+
+	if len(procOptions.externalContexts) > 0 {
+		input["@context"] = AppendExternalContexts(input["@context"], procOptions.externalContexts...)
+	}
+
+	expanded, err := ld.NewJsonLdProcessor().Expand(input, ldOptions)
+	if err != nil {
+		return nil, procOptions.contextLoadError(err)
+	}
+
+	return expanded, nil
+}
+
+// Flatten flattens given json ld object and compacts the result using the context.
+// If context is nil, the context of the input is used.
+func (p *Processor) Flatten(input, context map[string]interface{},
+	opts ...ProcessorOpts) (map[string]interface{}, error) {
+	procOptions := prepareOpts(opts)
+
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.ProcessingMode = ld.JsonLd_1_1
+	ldOptions.DocumentLoader = procOptions.documentLoader
+
+	if context == nil {
+		inputContext := input["@context"]
+
+		if len(procOptions.externalContexts) > 0 {
+			inputContext = AppendExternalContexts(inputContext, procOptions.externalContexts...)
+			input["@context"] = inputContext
+		}
+
+		context = map[string]interface{}{"@context": inputContext}
+	}
+
+	flattened, err := ld.NewJsonLdProcessor().Flatten(input, context, ldOptions)
+	if err != nil {
+		return nil, procOptions.contextLoadError(err)
+	}
+
+	flattenedMap, ok := flattened.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid flattened document")
+	}
+
+	return flattenedMap, nil
 }
 
 // Frame makes a frame from the inputDoc using frameDoc.
@@ -200,7 +284,7 @@ func (p *Processor) Frame(inputDoc map[string]interface{}, frameDoc map[string]i
 	// TODO Drop replacing duplicated IDs as soon as https://github.com/piprate/json-gold/issues/44 will be fixed.
 	inputDocCopy, randomIds, err := removeDuplicateIDs(inputDoc, proc, ldOptions)
 	if err != nil {
-		return nil, fmt.Errorf("removing duplicate ids failed: %w", err)
+		return nil, fmt.Errorf("removing duplicate ids failed: %w", procOptions.contextLoadError(err))
 	}
 
 	inputDocCopy, err = p.transformBlankNodes(inputDocCopy, opts...)
@@ -210,7 +294,7 @@ func (p *Processor) Frame(inputDoc map[string]interface{}, frameDoc map[string]i
 
 	framedInputDoc, err := proc.Frame(inputDocCopy, frameDoc, ldOptions)
 	if err != nil {
-		return nil, fmt.Errorf("framing failed: %w", err)
+		return nil, fmt.Errorf("framing failed: %w", procOptions.contextLoadError(err))
 	}
 
 	framedInputDoc["@context"] = frameDoc["@context"]
@@ -428,12 +512,12 @@ func fromRDF(docStatements []string, context interface{},
 
 	transformedDoc, err := proc.FromRDF(doc, ldOptions)
 	if err != nil {
-		return nil, fmt.Errorf("rdf processing failed: %w", err)
+		return nil, fmt.Errorf("rdf processing failed: %w", procOptions.contextLoadError(err))
 	}
 
 	transformedDocMap, err := proc.Compact(transformedDoc, context, ldOptions)
 	if err != nil {
-		return nil, fmt.Errorf("compacting failed: %w", err)
+		return nil, fmt.Errorf("compacting failed: %w", procOptions.contextLoadError(err))
 	}
 
 	return transformedDocMap, nil
@@ -447,6 +531,11 @@ func prepareOpts(opts []ProcessorOpts) *processorOpts {
 		opt(procOpts)
 	}
 
+	if procOpts.documentLoader != nil {
+		procOpts.loadErrRecorder = &contextLoadErrorRecorder{next: procOpts.documentLoader}
+		procOpts.documentLoader = procOpts.loadErrRecorder
+	}
+
 	return procOpts
 }
 
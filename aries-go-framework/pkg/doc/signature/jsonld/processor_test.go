@@ -9,11 +9,14 @@ package jsonld_test
 import (
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"log"
 	"testing"
 
+	ld "github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
+	jld "github.com/hyperledger/aries-framework-go/pkg/doc/ld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
@@ -229,6 +232,101 @@ func TestCompact(t *testing.T) {
 	})
 }
 
+type failingDocumentLoader struct {
+	err error
+}
+
+func (l *failingDocumentLoader) LoadDocument(string) (*ld.RemoteDocument, error) {
+	return nil, l.err
+}
+
+func TestContextLoadError(t *testing.T) {
+	const contextURL = "https://example.com/unknown-context.jsonld"
+
+	newDoc := func() map[string]interface{} {
+		return map[string]interface{}{
+			"@context": []interface{}{"https://www.w3.org/2018/credentials/v1", contextURL},
+			"id":       "http://example.edu/credentials/1872",
+			"type":     "VerifiableCredential",
+		}
+	}
+
+	t.Run("context is not found by the document loader", func(t *testing.T) {
+		_, err := jsonld.Default().Compact(newDoc(), nil, ldtestutil.WithDocumentLoader(t))
+		require.Error(t, err)
+		require.ErrorIs(t, err, jld.ErrContextNotFound)
+
+		var loadErr *jld.ContextLoadError
+
+		require.ErrorAs(t, err, &loadErr)
+		require.Equal(t, contextURL, loadErr.URL)
+		require.Contains(t, err.Error(), "load context "+contextURL+": context not found")
+	})
+
+	t.Run("failure of custom document loader", func(t *testing.T) {
+		loaderErr := errors.New("Bad response status code: 404")
+		loader := jsonld.WithDocumentLoader(&failingDocumentLoader{err: loaderErr})
+
+		_, err := jsonld.Default().Expand(newDoc(), loader)
+		require.ErrorIs(t, err, loaderErr)
+
+		var loadErr *jld.ContextLoadError
+
+		require.ErrorAs(t, err, &loadErr)
+		require.Equal(t, "https://www.w3.org/2018/credentials/v1", loadErr.URL)
+
+		_, err = jsonld.Default().GetCanonicalDocument(newDoc(), loader)
+		require.ErrorAs(t, err, &loadErr)
+		require.Contains(t, err.Error(), "failed to normalize JSON-LD document")
+	})
+}
+
+func TestExpandAndFlatten(t *testing.T) {
+	newDoc := func() map[string]interface{} {
+		return map[string]interface{}{
+			"@context": map[string]interface{}{
+				"dc":       "http://purl.org/dc/elements/1.1/",
+				"ex":       "http://example.org/vocab#",
+				"contains": map[string]interface{}{"@id": "ex:contains", "@type": "@id"},
+			},
+			"@id":      "http://example.org/test#book",
+			"contains": "http://example.org/test#chapter",
+			"dc:title": "Title",
+		}
+	}
+
+	t.Run("expand", func(t *testing.T) {
+		expandedDoc, err := jsonld.Default().Expand(newDoc())
+		require.NoError(t, err)
+		require.Len(t, expandedDoc, 1)
+
+		node, ok := expandedDoc[0].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "http://example.org/test#book", node["@id"])
+		require.Contains(t, node, "http://purl.org/dc/elements/1.1/title")
+		require.NotContains(t, node, "@context")
+	})
+
+	t.Run("flatten", func(t *testing.T) {
+		flattenedDoc, err := jsonld.Default().Flatten(newDoc(), nil)
+		require.NoError(t, err)
+		require.Contains(t, flattenedDoc, "@context")
+
+		graph, ok := flattenedDoc["@graph"].([]interface{})
+		require.True(t, ok)
+		require.Contains(t, graph, map[string]interface{}{
+			"@id":      "http://example.org/test#book",
+			"contains": "http://example.org/test#chapter",
+			"dc:title": "Title",
+		})
+	})
+
+	t.Run("flatten with invalid context", func(t *testing.T) {
+		_, err := jsonld.Default().Flatten(newDoc(), map[string]interface{}{"@context": 1})
+		require.Error(t, err)
+	})
+}
+
 func TestProcessor_Frame(t *testing.T) {
 	processor := jsonld.Default()
 
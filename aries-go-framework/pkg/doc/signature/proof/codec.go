@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package proof
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/multiformats/go-multibase"
+)
+
+// ValueCodec encodes a signature into the "proofValue" field of a proof and decodes it back.
+type ValueCodec interface {
+	// Encode returns the string representation of the signature.
+	Encode(value []byte) string
+
+	// Decode returns the signature of the string representation.
+	Decode(value string) ([]byte, error)
+}
+
+// ValueCodecProvider is implemented by a signature suite which declares the encoding of its "proofValue".
+// The signature suites which do not implement it use Base64URLCodec.
+type ValueCodecProvider interface {
+	// ProofValueCodec returns the codec of "proofValue" or nil to use the default one.
+	ProofValueCodec() ValueCodec
+}
+
+// CodecOf returns the "proofValue" codec declared by the signature suite or Base64URLCodec
+// if the suite does not declare one.
+func CodecOf(suite interface{}) ValueCodec {
+	if p, ok := suite.(ValueCodecProvider); ok {
+		if codec := p.ProofValueCodec(); codec != nil {
+			return codec
+		}
+	}
+
+	return Base64URLCodec{}
+}
+
+// Base64URLCodec encodes "proofValue" as base64url without padding. Standard and padded base64
+// values are accepted when decoding.
+type Base64URLCodec struct{}
+
+// Encode returns the base64url representation of the value.
+func (Base64URLCodec) Encode(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// Decode decodes base64 value.
+func (Base64URLCodec) Decode(value string) ([]byte, error) {
+	return decodeBase64(value)
+}
+
+// Base58BTCMultibaseCodec encodes "proofValue" as multibase base58btc (i.e. base58btc prefixed with "z").
+type Base58BTCMultibaseCodec struct{}
+
+// Encode returns the multibase base58btc representation of the value.
+func (Base58BTCMultibaseCodec) Encode(value []byte) string {
+	// the error is returned for the unknown encodings only
+	s, _ := multibase.Encode(multibase.Base58BTC, value) //nolint:errcheck
+
+	return s
+}
+
+// Decode decodes multibase base58btc value.
+func (Base58BTCMultibaseCodec) Decode(value string) ([]byte, error) {
+	encoding, decoded, err := multibase.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode multibase value: %w", err)
+	}
+
+	if encoding != multibase.Base58BTC {
+		return nil, fmt.Errorf("unexpected multibase encoding %q, base58btc is expected", value[:1])
+	}
+
+	return decoded, nil
+}
+
+// HexCodec encodes "proofValue" as a hex string.
+type HexCodec struct{}
+
+// Encode returns the hex representation of the value.
+func (HexCodec) Encode(value []byte) string {
+	return hex.EncodeToString(value)
+}
+
+// Decode decodes hex value.
+func (HexCodec) Decode(value string) ([]byte, error) {
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode hex value: %w", err)
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package proof
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCodecProvider struct {
+	codec ValueCodec
+}
+
+func (p *testCodecProvider) ProofValueCodec() ValueCodec {
+	return p.codec
+}
+
+func TestValueCodecs(t *testing.T) {
+	value := []byte("proof value")
+
+	tests := []struct {
+		name    string
+		codec   ValueCodec
+		encoded string
+	}{
+		{name: "base64url", codec: Base64URLCodec{}, encoded: "cHJvb2YgdmFsdWU"},
+		{name: "multibase base58btc", codec: Base58BTCMultibaseCodec{}, encoded: "zUtGuRhnq4AUk9x8"},
+		{name: "hex", codec: HexCodec{}, encoded: "70726f6f662076616c7565"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.encoded, tc.codec.Encode(value))
+
+			decoded, err := tc.codec.Decode(tc.encoded)
+			require.NoError(t, err)
+			require.Equal(t, value, decoded)
+		})
+	}
+
+	t.Run("base64 with padding is decoded", func(t *testing.T) {
+		decoded, err := Base64URLCodec{}.Decode("cHJvb2YgdmFsdWU=")
+		require.NoError(t, err)
+		require.Equal(t, value, decoded)
+	})
+
+	t.Run("decode errors", func(t *testing.T) {
+		_, err := Base58BTCMultibaseCodec{}.Decode("not multibase")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode multibase value")
+
+		_, err = Base58BTCMultibaseCodec{}.Decode("fcafe")
+		require.EqualError(t, err, `unexpected multibase encoding "f", base58btc is expected`)
+
+		_, err = HexCodec{}.Decode("xyz")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode hex value")
+	})
+}
+
+func TestCodecOf(t *testing.T) {
+	require.Equal(t, Base64URLCodec{}, CodecOf(nil))
+	require.Equal(t, Base64URLCodec{}, CodecOf("suite without codec"))
+	require.Equal(t, Base64URLCodec{}, CodecOf(&testCodecProvider{}))
+	require.Equal(t, HexCodec{}, CodecOf(&testCodecProvider{codec: HexCodec{}}))
+}
+
+func TestNewProofWithCodec(t *testing.T) {
+	emap := map[string]interface{}{
+		"type":       "Ed25519Signature2018",
+		"created":    "2011-09-23T20:21:34Z",
+		"proofValue": "zUtGuRhnq4AUk9x8",
+	}
+
+	p, err := NewProofWithCodec(emap, Base58BTCMultibaseCodec{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("proof value"), p.ProofValue)
+
+	// the proof value is encoded back with the same codec
+	require.Equal(t, "zUtGuRhnq4AUk9x8", p.JSONLdObject()["proofValue"])
+
+	// the value which is not decoded by the codec is rejected
+	p, err = NewProofWithCodec(emap, HexCodec{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode hex value")
+	require.Nil(t, p)
+}
+
+func TestGetProofsWithCodec(t *testing.T) {
+	doc := map[string]interface{}{
+		"proof": []interface{}{
+			map[string]interface{}{
+				"type":       "HexSignature",
+				"created":    "2011-09-23T20:21:34Z",
+				"proofValue": "70726f6f662076616c7565",
+			},
+			map[string]interface{}{
+				"type":       "Ed25519Signature2018",
+				"created":    "2011-09-23T20:21:34Z",
+				"proofValue": "cHJvb2YgdmFsdWU",
+			},
+		},
+	}
+
+	proofs, err := GetProofsWithCodec(doc, func(proofType string) ValueCodec {
+		if proofType == "HexSignature" {
+			return HexCodec{}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, proofs, 2)
+	require.Equal(t, []byte("proof value"), proofs[0].ProofValue)
+	require.Equal(t, []byte("proof value"), proofs[1].ProofValue)
+
+	proofs, err = GetProofsWithCodec(doc, func(string) ValueCodec { return Base58BTCMultibaseCodec{} })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode multibase value")
+	require.Nil(t, proofs)
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
@@ -22,6 +23,7 @@ const (
 const (
 	jwtPartsNumber   = 3
 	jwtHeaderPart    = 0
+	jwtPayloadPart   = 1
 	jwtSignaturePart = 2
 )
 
@@ -73,8 +75,59 @@ func getJWTHeader(jwt string) (string, error) {
 	return jwtParts[jwtHeaderPart], nil
 }
 
+// getJWSPayload returns payload part of JWS, it's empty if the payload is detached.
+func getJWSPayload(jws string) (string, error) {
+	jwsParts := strings.Split(jws, ".")
+	if len(jwsParts) != jwtPartsNumber {
+		return "", errors.New("invalid JWT")
+	}
+
+	return jwsParts[jwtPayloadPart], nil
+}
+
+// isJWSPayloadEncoded checks "b64" header parameter (https://tools.ietf.org/html/rfc7797#section-3),
+// the payload is base64url encoded if the parameter is not defined.
+func isJWSPayloadEncoded(jwtHeader string) (bool, error) {
+	headerBytes, err := base64.RawURLEncoding.DecodeString(jwtHeader)
+	if err != nil {
+		return false, fmt.Errorf("decode JWT header: %w", err)
+	}
+
+	var header struct {
+		B64 *bool `json:"b64,omitempty"`
+	}
+
+	if err = json.Unmarshal(headerBytes, &header); err != nil {
+		return false, fmt.Errorf("unmarshal JWT header: %w", err)
+	}
+
+	return header.B64 == nil || *header.B64, nil
+}
+
+// attachedJWSSigningInput builds the JWS signing input for the verify data if the JWS has attached payload
+// (i.e. it is not detached as the JWS created by this package). The attached payload must match the verify
+// data reconstructed from the canonical document and proof options.
+func attachedJWSSigningInput(jwtHeader, payload string, verifyData []byte) ([]byte, error) {
+	encoded, err := isJWSPayloadEncoded(jwtHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedPayload := string(verifyData)
+	if encoded {
+		expectedPayload = base64.RawURLEncoding.EncodeToString(verifyData)
+	}
+
+	if payload != expectedPayload {
+		return nil, errors.New("attached JWS payload does not match the document")
+	}
+
+	return []byte(jwtHeader + "." + expectedPayload), nil
+}
+
 // createVerifyJWS creates a data to be used to create/verify a digital signature in the
 // form of JSON Web Signature (JWS) with detached content (https://tools.ietf.org/html/rfc7797).
+// The JWS with attached payload is accepted as well: its payload is checked against the reconstructed one.
 // The algorithm of building the payload is similar to conventional  Create Verify Hash algorithm.
 // It differs by using https://w3id.org/security/v2 as context for JSON-LD canonization of both
 // JSON and Signature documents and by preliminary JSON-LD compacting of JSON document.
@@ -104,6 +157,15 @@ func createVerifyJWS(suite signatureSuite, jsonldDoc map[string]interface{}, p *
 		return nil, err
 	}
 
+	payload, err := getJWSPayload(p.JWS)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload != "" {
+		return attachedJWSSigningInput(jwtHeader, payload, verifyData)
+	}
+
 	return append([]byte(jwtHeader+"."), verifyData...), nil
 }
 
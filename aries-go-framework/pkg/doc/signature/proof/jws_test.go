@@ -70,6 +70,33 @@ func Test_createVerifyJWS(t *testing.T) {
 	require.Empty(t, proofVerifyData)
 }
 
+func Test_attachedJWSSigningInput(t *testing.T) {
+	verifyData := []byte("verify data")
+
+	encodedHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA"}`))
+	unencodedHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA","b64":false,"crit":["b64"]}`))
+	encodedPayload := base64.RawURLEncoding.EncodeToString(verifyData)
+
+	signingInput, err := attachedJWSSigningInput(encodedHeader, encodedPayload, verifyData)
+	require.NoError(t, err)
+	require.Equal(t, encodedHeader+"."+encodedPayload, string(signingInput))
+
+	signingInput, err = attachedJWSSigningInput(unencodedHeader, string(verifyData), verifyData)
+	require.NoError(t, err)
+	require.Equal(t, unencodedHeader+".verify data", string(signingInput))
+
+	_, err = attachedJWSSigningInput(unencodedHeader, encodedPayload, verifyData)
+	require.EqualError(t, err, "attached JWS payload does not match the document")
+
+	_, err = attachedJWSSigningInput("not base64!", encodedPayload, verifyData)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode JWT header")
+
+	_, err = attachedJWSSigningInput(base64.RawURLEncoding.EncodeToString([]byte("[]")), encodedPayload, verifyData)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unmarshal JWT header")
+}
+
 func TestCreateDetachedJWTHeader(t *testing.T) {
 	getJwtHeaderMap := func(jwtHeaderB64 string) map[string]interface{} {
 		jwtHeaderBytes, err := base64.RawURLEncoding.DecodeString(jwtHeaderB64)
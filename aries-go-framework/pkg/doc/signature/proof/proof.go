@@ -36,12 +36,25 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldExpires is a key for time proof expires.
+	jsonldExpires = "expires"
+	// jsonldID is a key for proof id.
+	jsonldID = "id"
 )
 
+// knownProofFields are the proof fields which are not considered additional (see Proof.AdditionalFields).
+// "id" and "@context" are never a part of the proof options.
+var knownProofFields = map[string]bool{ //nolint:gochecknoglobals
+	jsonldType: true, jsonldCreator: true, jsonldCreated: true, jsonldDomain: true, jsonldNonce: true,
+	jsonldProofValue: true, jsonldProofPurpose: true, jsonldJWS: true, jsonldVerificationMethod: true,
+	jsonldChallenge: true, jsonldCapabilityChain: true, jsonldExpires: true, jsonldID: true, jsonldContext: true,
+}
+
 // Proof is cryptographic proof of the integrity of the DID Document.
 type Proof struct {
 	Type                    string
 	Created                 *util.TimeWrapper
+	Expires                 *util.TimeWrapper
 	Creator                 string
 	VerificationMethod      string
 	ProofValue              []byte
@@ -53,10 +66,22 @@ type Proof struct {
 	SignatureRepresentation SignatureRepresentation
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// ProofValueCodec encodes ProofValue into "proofValue" field, Base64URLCodec is used if it is not set.
+	ProofValueCodec ValueCodec
+	// AdditionalFields are the proof fields which are not defined above, e.g. "capability" and
+	// "capabilityAction" of ZCAP-LD proof with "capabilityInvocation" purpose. They are preserved as is
+	// and included into the proof options which are canonicalized for signing and verification.
+	AdditionalFields map[string]interface{}
 }
 
-// NewProof creates new proof.
+// NewProof creates new proof, "proofValue" is decoded as base64.
 func NewProof(emap map[string]interface{}) (*Proof, error) {
+	return NewProofWithCodec(emap, nil)
+}
+
+// NewProofWithCodec creates new proof decoding "proofValue" with the codec of the signature suite
+// (Base64URLCodec if codec is nil).
+func NewProofWithCodec(emap map[string]interface{}, codec ValueCodec) (*Proof, error) {
 	created := stringEntry(emap[jsonldCreated])
 
 	timeValue, err := util.ParseTimeWrapper(created)
@@ -71,7 +96,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 	)
 
 	if generalProof, ok := emap[jsonldProofValue]; ok {
-		proofValue, err = decodeBase64(stringEntry(generalProof))
+		proofValue, err = decodeProofValue(stringEntry(generalProof), codec)
 		if err != nil {
 			return nil, err
 		}
@@ -96,12 +121,23 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		return nil, fmt.Errorf("failed to decode capabilityChain: %w", err)
 	}
 
+	var expires *util.TimeWrapper
+
+	if expiresStr := stringEntry(emap[jsonldExpires]); expiresStr != "" {
+		expires, err = util.ParseTimeWrapper(expiresStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode expires: %w", err)
+		}
+	}
+
 	return &Proof{
 		Type:                    stringEntry(emap[jsonldType]),
 		Created:                 timeValue,
+		Expires:                 expires,
 		Creator:                 stringEntry(emap[jsonldCreator]),
 		VerificationMethod:      stringEntry(emap[jsonldVerificationMethod]),
 		ProofValue:              proofValue,
+		ProofValueCodec:         codec,
 		SignatureRepresentation: proofHolder,
 		JWS:                     jws,
 		ProofPurpose:            stringEntry(emap[jsonldProofPurpose]),
@@ -109,9 +145,28 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
 		CapabilityChain:         capabilityChain,
+		AdditionalFields:        decodeAdditionalFields(emap),
 	}, nil
 }
 
+func decodeAdditionalFields(proof map[string]interface{}) map[string]interface{} {
+	var additionalFields map[string]interface{}
+
+	for k, v := range proof {
+		if knownProofFields[k] {
+			continue
+		}
+
+		if additionalFields == nil {
+			additionalFields = make(map[string]interface{})
+		}
+
+		additionalFields[k] = v
+	}
+
+	return additionalFields
+}
+
 func decodeCapabilityChain(proof map[string]interface{}) ([]interface{}, error) {
 	var capabilityChain []interface{}
 
@@ -128,6 +183,14 @@ func decodeCapabilityChain(proof map[string]interface{}) ([]interface{}, error)
 	return capabilityChain, nil
 }
 
+func decodeProofValue(s string, codec ValueCodec) ([]byte, error) {
+	if codec == nil {
+		return decodeBase64(s)
+	}
+
+	return codec.Decode(s)
+}
+
 func decodeBase64(s string) ([]byte, error) {
 	allEncodings := []*base64.Encoding{
 		base64.RawURLEncoding, base64.StdEncoding, base64.RawStdEncoding,
@@ -154,7 +217,14 @@ func stringEntry(entry interface{}) string {
 
 // JSONLdObject returns map that represents JSON LD Object.
 func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
-	emap := make(map[string]interface{})
+	emap := make(map[string]interface{}, len(p.AdditionalFields))
+
+	for k, v := range p.AdditionalFields {
+		if !knownProofFields[k] {
+			emap[k] = v
+		}
+	}
+
 	emap[jsonldType] = p.Type
 
 	if p.Creator != "" {
@@ -169,8 +239,12 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldCreated] = p.Created.FormatToString()
 	}
 
+	if p.Expires != nil {
+		emap[jsonldExpires] = p.Expires.FormatToString()
+	}
+
 	if len(p.ProofValue) > 0 {
-		emap[jsonldProofValue] = base64.RawURLEncoding.EncodeToString(p.ProofValue)
+		emap[jsonldProofValue] = p.proofValueCodec().Encode(p.ProofValue)
 	}
 
 	if len(p.JWS) > 0 {
@@ -200,6 +274,14 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	return emap
 }
 
+func (p *Proof) proofValueCodec() ValueCodec {
+	if p.ProofValueCodec != nil {
+		return p.ProofValueCodec
+	}
+
+	return Base64URLCodec{}
+}
+
 // PublicKeyID provides ID of public key to be used to independently verify the proof.
 // "verificationMethod" field is checked first. If not empty, its value is returned.
 // Otherwise, "creator" field is returned if not empty. Otherwise, error is returned.
@@ -122,6 +122,30 @@ func TestProof(t *testing.T) {
 			require.Contains(t, err.Error(), "invalid format for capabilityChain")
 		})
 	})
+
+	t.Run("expires", func(t *testing.T) {
+		p, err := NewProof(map[string]interface{}{
+			"type":       "type",
+			"created":    "2018-03-15T00:00:00Z",
+			"expires":    "2018-03-15T00:05:00Z",
+			"proofValue": proofValueBase64,
+		})
+		require.NoError(t, err)
+
+		expires, err := time.Parse(time.RFC3339, "2018-03-15T00:05:00Z")
+		require.NoError(t, err)
+		require.Equal(t, expires, p.Expires.Time)
+		require.Equal(t, "2018-03-15T00:05:00Z", p.JSONLdObject()["expires"])
+
+		_, err = NewProof(map[string]interface{}{
+			"type":       "type",
+			"created":    "2018-03-15T00:00:00Z",
+			"expires":    "not a time",
+			"proofValue": proofValueBase64,
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decode expires")
+	})
 }
 
 func TestInvalidProofValue(t *testing.T) {
@@ -253,6 +277,44 @@ func TestProof_JSONLdObject(t *testing.T) {
 			r.NotContains(result, "capabilityChain")
 		})
 	})
+
+	t.Run("additional fields", func(t *testing.T) {
+		proofMap := map[string]interface{}{
+			"id":                 "urn:uuid:8a58b31a-7ab5-4d3c-9e3a-6ca0a8ac7f55",
+			"@context":           "https://w3id.org/security/v2",
+			"type":               "Ed25519Signature2018",
+			"created":            "2018-03-15T00:00:00Z",
+			"verificationMethod": "did:example:123456#key1",
+			"proofPurpose":       "capabilityInvocation",
+			"capability":         "https://example.com/zcaps/1",
+			"capabilityAction":   "read",
+			"jws":                "test..jws",
+		}
+
+		p, err := NewProof(proofMap)
+		r.NoError(err)
+		r.Equal(map[string]interface{}{
+			"capability":       "https://example.com/zcaps/1",
+			"capabilityAction": "read",
+		}, p.AdditionalFields)
+
+		result := p.JSONLdObject()
+		delete(proofMap, "id")
+		delete(proofMap, "@context")
+		r.Equal(proofMap, result)
+
+		// additional fields do not override the defined ones
+		p.AdditionalFields["type"] = "OtherType"
+		r.Equal("Ed25519Signature2018", p.JSONLdObject()["type"])
+
+		p, err = NewProof(map[string]interface{}{
+			"type":       "Ed25519Signature2018",
+			"created":    "2018-03-15T00:00:00Z",
+			"proofValue": proofValueBase64,
+		})
+		r.NoError(err)
+		r.Nil(p.AdditionalFields)
+	})
 }
 
 func TestProof_PublicKeyID(t *testing.T) {
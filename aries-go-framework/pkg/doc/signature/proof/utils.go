@@ -15,6 +15,13 @@ const (
 
 // GetProofs gets proof(s) from LD Object.
 func GetProofs(jsonLdObject map[string]interface{}) ([]*Proof, error) {
+	return GetProofsWithCodec(jsonLdObject, nil)
+}
+
+// GetProofsWithCodec gets proof(s) from LD Object decoding "proofValue" of each proof with the codec
+// returned by codecOf for the proof type (base64 is used if codecOf is nil or returns nil).
+func GetProofsWithCodec(jsonLdObject map[string]interface{},
+	codecOf func(proofType string) ValueCodec) ([]*Proof, error) {
 	entry, ok := jsonLdObject[jsonldProof]
 	if !ok {
 		return nil, ErrProofNotFound
@@ -38,7 +45,13 @@ func GetProofs(jsonLdObject map[string]interface{}) ([]*Proof, error) {
 			return nil, errors.New("wrong interface, expecting []interface{}")
 		}
 
-		proof, err := NewProof(emap)
+		var codec ValueCodec
+
+		if codecOf != nil {
+			codec = codecOf(stringEntry(emap[jsonldType]))
+		}
+
+		proof, err := NewProofWithCodec(emap, codec)
 		if err != nil {
 			return nil, err
 		}
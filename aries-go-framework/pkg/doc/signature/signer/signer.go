@@ -19,6 +19,7 @@ import (
 const defaultProofPurpose = "assertionMethod"
 
 // SignatureSuite encapsulates signature suite methods required for signing documents.
+// The suite may declare the encoding of "proofValue" by implementing proof.ValueCodecProvider.
 type SignatureSuite interface {
 	// GetCanonicalDocument will return normalized/canonical version of the document
 	GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error)
@@ -47,12 +48,14 @@ type Context struct {
 	Creator                 string                        // required
 	SignatureRepresentation proof.SignatureRepresentation // optional
 	Created                 *time.Time                    // optional
+	Expires                 *time.Time                    // optional
 	Domain                  string                        // optional
 	Nonce                   []byte                        // optional
 	VerificationMethod      string                        // optional
 	Challenge               string                        // optional
 	Purpose                 string                        // optional
 	CapabilityChain         []interface{}                 // optional
+	AdditionalProofFields   map[string]interface{}        // optional, see proof.Proof AdditionalFields
 }
 
 // New returns new instance of document verifier.
@@ -82,6 +85,14 @@ func (signer *DocumentSigner) Sign(context *Context, jsonLdDoc []byte, opts ...j
 	return signedDoc, nil
 }
 
+// SignObject signs JSON LD document defined as an object (e.g. unmarshalled from JSON) and adds the proof to it.
+// Unlike Sign, the document is neither unmarshalled nor marshalled, so it can be used to avoid JSON round trips
+// when the document is available in the parsed form.
+func (signer *DocumentSigner) SignObject(context *Context, jsonLdObject map[string]interface{},
+	opts ...jsonld.ProcessorOpts) error {
+	return signer.signObject(context, jsonLdObject, opts)
+}
+
 // signObject is a helper method that operates on JSON LD objects.
 func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[string]interface{},
 	opts []jsonld.ProcessorOpts) error {
@@ -111,6 +122,11 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		Challenge:               context.Challenge,
 		ProofPurpose:            context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		AdditionalFields:        context.AdditionalProofFields,
+	}
+
+	if context.Expires != nil {
+		p.Expires = wrapTime(*context.Expires)
 	}
 
 	// TODO support custom proof purpose
@@ -133,15 +149,17 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		return err
 	}
 
-	signer.applySignatureValue(context, p, s)
+	signer.applySignatureValue(context, p, s, proof.CodecOf(suite))
 
 	return proof.AddProof(jsonLdObject, p)
 }
 
-func (signer *DocumentSigner) applySignatureValue(context *Context, p *proof.Proof, s []byte) {
+func (signer *DocumentSigner) applySignatureValue(context *Context, p *proof.Proof, s []byte,
+	codec proof.ValueCodec) {
 	switch context.SignatureRepresentation {
 	case proof.SignatureProofValue:
 		p.ProofValue = s
+		p.ProofValueCodec = codec
 	case proof.SignatureJWS:
 		p.JWS += base64.RawURLEncoding.EncodeToString(s)
 	}
@@ -7,8 +7,11 @@ package signer
 
 import (
 	_ "embed"
+	"encoding/base64"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -67,6 +71,153 @@ func TestDocumentSigner_Sign(t *testing.T) {
 	require.Contains(t, proofMap, "jws")
 }
 
+func TestDocumentSigner_SignObject(t *testing.T) {
+	context := getSignatureContext()
+	context.Created = &time.Time{}
+
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	s := New(ed25519signature2018.New(suite.WithSigner(signer)))
+
+	var doc map[string]interface{}
+
+	require.NoError(t, json.Unmarshal([]byte(validDoc), &doc))
+
+	err = s.SignObject(context, doc, ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	proofs, ok := doc["proof"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, proofs, 1)
+
+	proofMap, ok := proofs[0].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "Ed25519Signature2018", proofMap["type"])
+	require.Contains(t, proofMap, "proofValue")
+
+	// the same proof is created by Sign (ed25519 signature is deterministic)
+	signedDoc, err := s.Sign(context, []byte(validDoc), ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	signedDocFromObject, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.JSONEq(t, string(signedDoc), string(signedDocFromObject))
+}
+
+func TestDocumentSigner_SignWithProofValueCodec(t *testing.T) {
+	context := getSignatureContext()
+
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()),
+		suite.WithProofValueCodec(proof.Base58BTCMultibaseCodec{}))
+
+	signedDoc, err := New(ss).Sign(context, []byte(validDoc), ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var signedMap map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &signedMap))
+
+	proofs, ok := signedMap["proof"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, proofs, 1)
+
+	proofMap, ok := proofs[0].(map[string]interface{})
+	require.True(t, ok)
+
+	proofValue, ok := proofMap["proofValue"].(string)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(proofValue, "z"))
+
+	v, err := verifier.New(&testKeyResolver{
+		publicKey: &verifier.PublicKey{Type: kmsapi.ED25519, Value: signer.PublicKeyBytes()},
+	}, ss)
+	require.NoError(t, err)
+	require.NoError(t, v.Verify(signedDoc, ldtestutil.WithDocumentLoader(t)))
+
+	// the suite with default codec cannot decode base58btc value
+	v, err = verifier.New(&testKeyResolver{
+		publicKey: &verifier.PublicKey{Type: kmsapi.ED25519, Value: signer.PublicKeyBytes()},
+	}, ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())))
+	require.NoError(t, err)
+	require.Error(t, v.Verify(signedDoc, ldtestutil.WithDocumentLoader(t)))
+}
+
+func TestDocumentSigner_VerifyAttachedJWS(t *testing.T) {
+	context := getSignatureContext()
+	context.SignatureRepresentation = proof.SignatureJWS
+
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	signedDoc, err := New(ss).Sign(context, []byte(validDoc), ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var signedMap map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &signedMap))
+
+	proofMap := signedMap["proof"].([]interface{})[0].(map[string]interface{})
+
+	p, err := proof.NewProof(proofMap)
+	require.NoError(t, err)
+
+	// reconstruct the payload of detached JWS created by the signer
+	detachedSigningInput, err := proof.CreateVerifyData(ss, signedMap, p, ldtestutil.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	detachedHeader := strings.Split(p.JWS, ".")[0]
+	verifyData := detachedSigningInput[len(detachedHeader)+1:]
+
+	// JWS with attached base64url encoded payload (b64 header is not defined)
+	attachedJWS := func(payload []byte) string {
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"EdDSA"}`))
+		signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+		sig, err := signer.Sign([]byte(signingInput))
+		require.NoError(t, err)
+
+		return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+	}
+
+	v, err := verifier.New(&testKeyResolver{
+		publicKey: &verifier.PublicKey{Type: kmsapi.ED25519, Value: signer.PublicKeyBytes()},
+	}, ss)
+	require.NoError(t, err)
+
+	// detached JWS
+	require.NoError(t, v.Verify(signedDoc, ldtestutil.WithDocumentLoader(t)))
+
+	proofMap["jws"] = attachedJWS(verifyData)
+
+	docWithAttachedJWS, err := json.Marshal(signedMap)
+	require.NoError(t, err)
+	require.NoError(t, v.Verify(docWithAttachedJWS, ldtestutil.WithDocumentLoader(t)))
+
+	// attached payload is not the one of the document
+	proofMap["jws"] = attachedJWS([]byte("other payload"))
+
+	docWithAttachedJWS, err = json.Marshal(signedMap)
+	require.NoError(t, err)
+
+	err = v.Verify(docWithAttachedJWS, ldtestutil.WithDocumentLoader(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "attached JWS payload does not match the document")
+}
+
+type testKeyResolver struct {
+	publicKey *verifier.PublicKey
+}
+
+func (r *testKeyResolver) Resolve(string) (*verifier.PublicKey, error) {
+	return r.publicKey, nil
+}
+
 func TestDocumentSigner_SignErrors(t *testing.T) {
 	context := getSignatureContext()
 	signer, err := newCryptoSigner(kmsapi.ED25519Type)
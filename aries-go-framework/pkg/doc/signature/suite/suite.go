@@ -9,6 +9,7 @@ package suite
 import (
 	"errors"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
@@ -17,6 +18,7 @@ type SignatureSuite struct {
 	Signer         signer
 	Verifier       verifier
 	CompactedProof bool
+	ValueCodec     proof.ValueCodec
 }
 
 type signer interface {
@@ -53,6 +55,14 @@ func WithCompactProof() Opt {
 	}
 }
 
+// WithProofValueCodec defines the encoding of "proofValue" of the proofs created and verified by the Signature Suite,
+// by default base64url is used.
+func WithProofValueCodec(codec proof.ValueCodec) Opt {
+	return func(opts *SignatureSuite) {
+		opts.ValueCodec = codec
+	}
+}
+
 // InitSuiteOptions initializes signature suite with options.
 func InitSuiteOptions(suite *SignatureSuite, opts ...Opt) *SignatureSuite {
 	for _, opt := range opts {
@@ -85,6 +95,11 @@ func (s *SignatureSuite) CompactProof() bool {
 	return s.CompactedProof
 }
 
+// ProofValueCodec returns the codec of "proofValue" (nil if the default one is used).
+func (s *SignatureSuite) ProofValueCodec() proof.ValueCodec {
+	return s.ValueCodec
+}
+
 // ErrSignerNotDefined is returned when Sign() is called but signer option is not defined.
 var ErrSignerNotDefined = errors.New("signer is not defined")
 
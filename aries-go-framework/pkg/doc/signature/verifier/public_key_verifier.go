@@ -27,6 +27,8 @@ import (
 
 // PublicKeyVerifier makes signature verification using the public key
 // based on one or several signature algorithms.
+// The signatures are checked by the verification functions of the algorithms (e.g. ed25519.Verify),
+// they are never compared byte by byte with the expected values.
 type PublicKeyVerifier struct {
 	exactType      string
 	singleVerifier SignatureVerifier
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifier
 
 import (
+	"crypto/rsa"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,9 +14,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
 // SignatureSuite encapsulates signature suite methods required for signature verification.
+// The suite may declare the encoding of "proofValue" by implementing proof.ValueCodecProvider.
 type SignatureSuite interface {
 
 	// GetCanonicalDocument will return normalized/canonical version of the document
@@ -41,6 +44,50 @@ type PublicKey struct {
 	JWK   *jwk.JWK
 }
 
+// PublicKeyFromJWK creates PublicKey from JWK (e.g. publicKeyJwk of DID document verification method).
+// Type of the key is a kms key type determined by the key type and curve of JWK (e.g. "ED25519" or
+// "ECDSAP256IEEEP1363"), Value is the raw public key bytes (see jwk.JWK.PublicKeyBytes()).
+func PublicKeyFromJWK(jwkBytes json.RawMessage) (*PublicKey, error) {
+	var j jwk.JWK
+
+	if err := j.UnmarshalJSON(jwkBytes); err != nil {
+		return nil, fmt.Errorf("parse JWK: %w", err)
+	}
+
+	keyType, err := jwkKeyType(&j)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := j.PublicKeyBytes()
+	if err != nil {
+		return nil, fmt.Errorf("get public key bytes of JWK: %w", err)
+	}
+
+	return &PublicKey{
+		Type:  string(keyType),
+		Value: value,
+		JWK:   &j,
+	}, nil
+}
+
+func jwkKeyType(j *jwk.JWK) (kms.KeyType, error) {
+	if _, ok := j.Key.(*rsa.PublicKey); ok {
+		if j.Algorithm == "PS256" {
+			return kms.RSAPS256Type, nil
+		}
+
+		return kms.RSARS256Type, nil
+	}
+
+	keyType, err := j.KeyType()
+	if err != nil {
+		return "", fmt.Errorf("determine key type of JWK: %w", err)
+	}
+
+	return keyType, nil
+}
+
 // keyResolver encapsulates key resolution.
 type keyResolver interface {
 
@@ -80,7 +127,7 @@ func (dv *DocumentVerifier) Verify(jsonLdDoc []byte, opts ...jsonld.ProcessorOpt
 
 // verifyObject will verify document proofs for JSON LD object.
 func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, opts ...jsonld.ProcessorOpts) error {
-	proofs, err := proof.GetProofs(jsonLdObject)
+	proofs, err := proof.GetProofsWithCodec(jsonLdObject, dv.proofValueCodec)
 	if err != nil {
 		return err
 	}
@@ -131,6 +178,16 @@ func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (SignatureSu
 	return nil, fmt.Errorf("signature type %s not supported", signatureType)
 }
 
+// proofValueCodec returns the "proofValue" codec of the signature suite which accepts the proof type.
+func (dv *DocumentVerifier) proofValueCodec(proofType string) proof.ValueCodec {
+	s, err := dv.getSignatureSuite(proofType)
+	if err != nil {
+		return nil
+	}
+
+	return proof.CodecOf(s)
+}
+
 func getProofVerifyValue(p *proof.Proof) ([]byte, error) {
 	switch p.SignatureRepresentation {
 	case proof.SignatureProofValue:
@@ -7,6 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package verifier
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	_ "embed"
 	"encoding/base64"
 	"encoding/json"
@@ -15,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -177,3 +183,62 @@ func (s *testSignatureSuite) Accept(string) bool {
 func (s *testSignatureSuite) CompactProof() bool {
 	return s.compactProof
 }
+
+func TestPublicKeyFromJWK(t *testing.T) {
+	marshalJWK := func(key interface{}) json.RawMessage {
+		j, err := jwksupport.JWKFromKey(key)
+		require.NoError(t, err)
+
+		jwkBytes, err := j.MarshalJSON()
+		require.NoError(t, err)
+
+		return jwkBytes
+	}
+
+	t.Run("Ed25519 key", func(t *testing.T) {
+		pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		pk, err := PublicKeyFromJWK(marshalJWK(pubKey))
+		require.NoError(t, err)
+		require.Equal(t, kms.ED25519, pk.Type)
+		require.Equal(t, []byte(pubKey), pk.Value)
+		require.NotNil(t, pk.JWK)
+
+		msg := []byte("test message")
+		require.NoError(t, NewEd25519SignatureVerifier().Verify(pk, msg, ed25519.Sign(privKey, msg)))
+	})
+
+	t.Run("P-256 key", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		pk, err := PublicKeyFromJWK(marshalJWK(&privKey.PublicKey))
+		require.NoError(t, err)
+		require.Equal(t, kms.ECDSAP256IEEEP1363, pk.Type)
+		require.Equal(t, elliptic.Marshal(elliptic.P256(), privKey.X, privKey.Y), pk.Value)
+		require.Equal(t, "P-256", pk.JWK.Crv)
+	})
+
+	t.Run("RSA key", func(t *testing.T) {
+		privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		pk, err := PublicKeyFromJWK(marshalJWK(&privKey.PublicKey))
+		require.NoError(t, err)
+		require.Equal(t, kms.RSARS256, pk.Type)
+		require.NotEmpty(t, pk.Value)
+	})
+
+	t.Run("invalid JWK", func(t *testing.T) {
+		pk, err := PublicKeyFromJWK(json.RawMessage(`{"kty":"OKP"`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse JWK")
+		require.Nil(t, pk)
+
+		pk, err = PublicKeyFromJWK(json.RawMessage(`{"kty":"oct","k":"c2VjcmV0"}`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "determine key type of JWK")
+		require.Nil(t, pk)
+	})
+}
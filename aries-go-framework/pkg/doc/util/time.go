@@ -8,9 +8,18 @@ package util
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
+//nolint:gochecknoglobals
+var timeLayouts = []string{
+	time.RFC3339,               // fractional seconds are accepted by all the layouts
+	"2006-01-02T15:04:05Z0700", // numeric offset without colon, e.g. +0000
+	"2006-01-02T15:04:05Z07",   // hour-only numeric offset, e.g. +01
+	"2006-01-02T15:04:05",      // no offset, UTC is assumed
+}
+
 // TimeWrapper overrides marshalling of time.Time. If a TimeWrapper is created from a time string, or
 // unmarshalled from JSON, it saves the string literal, which it uses when marshalling.
 // If a TimeWrapper is created using NewTime or a struct literal, it marshals with the default
@@ -62,7 +71,9 @@ func (tm TimeWrapper) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
-// The time is expected to be a quoted string in RFC 3339 format.
+// The time is expected to be a quoted string in RFC 3339 format. Other ISO 8601 variants are accepted too:
+// fractional seconds of any precision, numeric offsets with or without colon (e.g. +00:00, +0000, +00),
+// lowercase "t" and "z" and no offset at all (UTC is assumed).
 // The source string value is saved, and used if this is marshalled back to JSON.
 func (tm *TimeWrapper) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
@@ -85,14 +96,28 @@ func (tm *TimeWrapper) UnmarshalJSON(data []byte) error {
 }
 
 func (tm *TimeWrapper) parse(timeStr string) error {
-	t, err := time.Parse(time.RFC3339, timeStr)
-	if err != nil {
-		t, err = time.Parse(time.RFC3339, timeStr+"Z")
-		if err != nil {
-			return err
+	// RFC 3339 allows "T" and "Z" to be lowercase.
+	normalized := strings.ToUpper(timeStr)
+
+	var (
+		t   time.Time
+		err error
+	)
+
+	for _, layout := range timeLayouts {
+		t, err = time.Parse(layout, normalized)
+		if err == nil {
+			break
 		}
 	}
 
+	if err != nil {
+		// report an error of parsing in the main format
+		_, err = time.Parse(time.RFC3339, normalized)
+
+		return err
+	}
+
 	tm.Time = t
 	tm.timeStr = timeStr
 
@@ -75,6 +75,49 @@ func TestTimeWithTrailingZeroMsec(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestTimeWrapper_ISO8601Variants(t *testing.T) {
+	expected := time.Date(2010, time.January, 1, 19, 23, 24, 0, time.UTC)
+
+	timeTests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2010-01-01T19:23:24Z", expected},
+		{"2010-01-01T19:23:24.000Z", expected},
+		{"2010-01-01T19:23:24.123456789Z", expected.Add(123456789 * time.Nanosecond)},
+		{"2010-01-01T19:23:24+00:00", expected},
+		{"2010-01-01T19:23:24.000+00:00", expected},
+		{"2010-01-01T21:23:24+02:00", expected},
+		{"2010-01-01T19:23:24+0000", expected},
+		{"2010-01-01T14:23:24.000-0500", expected},
+		{"2010-01-01T19:23:24+00", expected},
+		{"2010-01-01T20:23:24.5+01", expected.Add(500 * time.Millisecond)},
+		{"2010-01-01t19:23:24z", expected},
+		{"2010-01-01T19:23:24", expected},
+		{"2010-01-01T19:23:24.000", expected},
+	}
+
+	for _, tt := range timeTests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			var tw TimeWrapper
+			err := json.Unmarshal([]byte(quote(tt.in)), &tw)
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(tw.Time), "expected %s, got %s", tt.want, tw.Time)
+
+			// the original string is preserved
+			twBytes, err := json.Marshal(tw)
+			require.NoError(t, err)
+			require.Equal(t, quote(tt.in), string(twBytes))
+		})
+	}
+
+	for _, in := range []string{"2010-01-01", "2010-01-01T19:23", "2010-01-01 19:23:24Z", "2010-01-01T19:23:24+5"} {
+		_, err := ParseTimeWrapper(in)
+		require.Error(t, err, in)
+	}
+}
+
 func TestParse(t *testing.T) {
 	timeMsec, err := ParseTimeWrapper("2018-03-15T00:00:00.000Z")
 	require.NoError(t, err)
@@ -0,0 +1,55 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time. It's consulted by the package wherever "now" is needed, e.g. as default
+// "created" of linked data proof, issuance date of credential created from SubjectTemplate or
+// to check the expiration of cached schemas and status lists.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is a function adapter of Clock.
+type ClockFunc func() time.Time
+
+// Now returns the current time.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+//nolint:gochecknoglobals
+var packageClock = struct {
+	sync.RWMutex
+	clock Clock
+}{
+	clock: ClockFunc(time.Now),
+}
+
+// SetClock replaces the clock used by the package (e.g. by a fixed one to make tests deterministic).
+// nil restores the system clock.
+func SetClock(c Clock) {
+	packageClock.Lock()
+	defer packageClock.Unlock()
+
+	if c == nil {
+		c = ClockFunc(time.Now)
+	}
+
+	packageClock.clock = c
+}
+
+// now returns the current time according to the package clock.
+func now() time.Time {
+	packageClock.RLock()
+	defer packageClock.RUnlock()
+
+	return packageClock.clock.Now()
+}
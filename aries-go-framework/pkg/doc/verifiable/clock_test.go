@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestSetClock(t *testing.T) {
+	fixed := time.Date(2021, time.March, 10, 4, 24, 12, 0, time.UTC)
+
+	SetClock(ClockFunc(func() time.Time { return fixed }))
+	defer SetClock(nil)
+
+	require.Equal(t, fixed, now())
+
+	t.Run("default created of linked data proof", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:xyz#key-1",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, "2021-03-10T04:24:12Z", vc.Proofs[0]["created"])
+	})
+
+	t.Run("issuance date and JWT iat of credential created from template", func(t *testing.T) {
+		vc, err := (&SubjectTemplate{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			Types:   []string{"VerifiableCredential"},
+			Issuer:  Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Subject: map[string]interface{}{"id": "{{id}}"},
+		}).Issue(map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"})
+		require.NoError(t, err)
+		require.Equal(t, fixed, vc.Issued.Time)
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+		require.Equal(t, fixed, jwtClaims.IssuedAt.Time().UTC())
+	})
+
+	SetClock(nil)
+	require.NotEqual(t, fixed, now())
+}
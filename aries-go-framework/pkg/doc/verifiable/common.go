@@ -17,11 +17,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"strings"
+	"sync"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
@@ -47,14 +51,90 @@ func (ja JWSAlgorithm) name() (string, error) {
 	case EdDSA:
 		return "EdDSA", nil
 	default:
+		jwsAlgorithms.RLock()
+		defer jwsAlgorithms.RUnlock()
+
+		if alg, ok := jwsAlgorithms.byID[ja]; ok {
+			return alg.Alg, nil
+		}
+
 		return "", fmt.Errorf("unsupported algorithm: %v", ja)
 	}
 }
 
+// JWSVerifier verifies signature of JWS made using the custom algorithm.
+type JWSVerifier func(pubKey *verifier.PublicKey, message, signature []byte) error
+
+//nolint:gochecknoglobals
+var jwsAlgorithms = struct {
+	sync.RWMutex
+	byID   map[JWSAlgorithm]jwt.AlgVerifier
+	byName map[string]JWSAlgorithm
+	lastID JWSAlgorithm
+}{
+	byID:   make(map[JWSAlgorithm]jwt.AlgVerifier),
+	byName: make(map[string]JWSAlgorithm),
+	lastID: EdDSA,
+}
+
+// RegisterJWSAlgorithm registers the custom JWS algorithm (e.g. ES256K or PS256) defined by its name
+// put into "alg" JOSE header and a verifier of the signature.
+// It returns JWSAlgorithm which can be passed to JWTCredClaims.MarshalJWS() and JWTPresClaims.MarshalJWS()
+// together with the Signer of the algorithm. The registered verifier is used when decoding VC or VP from JWS.
+// An error is returned if the algorithm collides with the built-in or already registered one.
+func RegisterJWSAlgorithm(name string, jwsVerifier JWSVerifier) (JWSAlgorithm, error) {
+	if name == "" {
+		return 0, errors.New("JWS algorithm name is not defined")
+	}
+
+	if jwsVerifier == nil {
+		return 0, fmt.Errorf("verifier of %s JWS algorithm is not defined", name)
+	}
+
+	for _, builtIn := range []JWSAlgorithm{RS256, EdDSA} {
+		if builtInName, _ := builtIn.name(); builtInName == name { //nolint:errcheck
+			return 0, fmt.Errorf("JWS algorithm %s collides with the built-in one", name)
+		}
+	}
+
+	jwsAlgorithms.Lock()
+	defer jwsAlgorithms.Unlock()
+
+	if _, ok := jwsAlgorithms.byName[name]; ok {
+		return 0, fmt.Errorf("JWS algorithm %s is already registered", name)
+	}
+
+	jwsAlgorithms.lastID++
+
+	jwsAlgorithms.byID[jwsAlgorithms.lastID] = jwt.AlgVerifier{Alg: name, Verify: jwsVerifier}
+	jwsAlgorithms.byName[name] = jwsAlgorithms.lastID
+
+	return jwsAlgorithms.lastID, nil
+}
+
+// registeredJWSVerifiers returns verifiers of the registered custom JWS algorithms.
+func registeredJWSVerifiers() []jwt.AlgVerifier {
+	jwsAlgorithms.RLock()
+	defer jwsAlgorithms.RUnlock()
+
+	algVerifiers := make([]jwt.AlgVerifier, 0, len(jwsAlgorithms.byID))
+
+	for _, v := range jwsAlgorithms.byID {
+		algVerifiers = append(algVerifiers, v)
+	}
+
+	return algVerifiers
+}
+
 type jsonldCredentialOpts struct {
 	jsonldDocumentLoader ld.DocumentLoader
 	externalContext      []string
 	jsonldOnlyValidRDF   bool
+	validationReport     *ValidationReport
+
+	// blankNodeTermsCheck, if true, rejects the documents whose terms are expanded to blank nodes
+	// when their linked data proofs are checked.
+	blankNodeTermsCheck bool
 }
 
 // PublicKeyFetcher fetches public key for JWT signing verification based on Issuer ID (possibly DID)
@@ -75,6 +155,7 @@ func SingleKey(pubKey []byte, pubKeyType string) PublicKeyFetcher {
 // VDRKeyResolver resolves DID in order to find public keys for VC verification using vdr.Registry.
 // A source of DID could be issuer of VC or holder of VP. It can be also obtained from
 // JWS "issuer" claim or "verificationMethod" of Linked Data Proof.
+// The public key of did:jwk DID is decoded from the DID itself without resolution.
 type VDRKeyResolver struct {
 	vdr vdrapi.Registry
 }
@@ -85,6 +166,10 @@ func NewVDRKeyResolver(vdr vdrapi.Registry) *VDRKeyResolver {
 }
 
 func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.PublicKey, error) {
+	if strings.HasPrefix(issuerDID, didJWKPrefix) {
+		return resolveDIDJWK(issuerDID, keyID)
+	}
+
 	docResolution, err := r.vdr.Resolve(issuerDID)
 	if err != nil {
 		return nil, fmt.Errorf("resolve DID %s: %w", issuerDID, err)
@@ -124,6 +209,29 @@ type TypedID struct {
 	Type string `json:"type,omitempty"`
 
 	CustomFields `json:"-"`
+
+	// types keeps "type" defined as an array of strings (e.g. in evidence), Type is its first element.
+	types []string
+}
+
+// typesArrayTypedID is TypedID with "type" defined as an array of strings.
+type typesArrayTypedID struct {
+	ID    string   `json:"id,omitempty"`
+	Types []string `json:"type"`
+}
+
+// Types returns all the types of TypedID. It differs from Type if "type" is defined as an array of strings
+// in the parsed JSON and Type is not changed since then.
+func (tid *TypedID) Types() []string {
+	if len(tid.types) > 0 && tid.types[0] == tid.Type {
+		return tid.types
+	}
+
+	if tid.Type == "" {
+		return nil
+	}
+
+	return []string{tid.Type}
 }
 
 // MarshalJSON defines custom marshalling of TypedID to JSON.
@@ -131,9 +239,14 @@ func (tid TypedID) MarshalJSON() ([]byte, error) {
 	// TODO hide this exported method
 	type Alias TypedID
 
-	alias := Alias(tid)
+	var v interface{} = Alias(tid)
+
+	if len(tid.types) > 0 && tid.types[0] == tid.Type {
+		// keep "type" in its original array form
+		v = typesArrayTypedID{ID: tid.ID, Types: tid.types}
+	}
 
-	data, err := marshalWithCustomFields(alias, tid.CustomFields)
+	data, err := marshalWithCustomFields(v, tid.CustomFields)
 	if err != nil {
 		return nil, fmt.Errorf("marshal TypedID: %w", err)
 	}
@@ -151,6 +264,17 @@ func (tid *TypedID) UnmarshalJSON(data []byte) error {
 	tid.CustomFields = make(CustomFields)
 
 	err := unmarshalWithCustomFields(data, alias, tid.CustomFields)
+	if err != nil && hasTypesArray(data) {
+		var typed typesArrayTypedID
+
+		tid.CustomFields = make(CustomFields)
+
+		err = unmarshalWithCustomFields(data, &typed, tid.CustomFields)
+		if err == nil {
+			tid.ID, tid.Type, tid.types = typed.ID, typed.Types[0], typed.Types
+		}
+	}
+
 	if err != nil {
 		return fmt.Errorf("unmarshal TypedID: %w", err)
 	}
@@ -158,6 +282,14 @@ func (tid *TypedID) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func hasTypesArray(data []byte) bool {
+	var typed struct {
+		Type []string `json:"type"`
+	}
+
+	return json.Unmarshal(data, &typed) == nil && len(typed.Type) > 0
+}
+
 func newTypedID(v interface{}) (TypedID, error) {
 	bytes, err := json.Marshal(v)
 	if err != nil {
@@ -242,6 +374,29 @@ func decodeContext(c interface{}) ([]string, []interface{}, error) {
 	}
 }
 
+// checkDocumentSize returns an error if the size of the document exceeds the limit (if it's defined).
+func checkDocumentSize(size, maxSize int) error {
+	if maxSize > 0 && size > maxSize {
+		return fmt.Errorf("document size %d exceeds the limit of %d bytes", size, maxSize)
+	}
+
+	return nil
+}
+
+// readLimited reads the reader till EOF, but not more than maxSize bytes. An error is returned if there is more data.
+func readLimited(r io.Reader, maxSize int) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("data exceeds the limit of %d bytes", maxSize)
+	}
+
+	return data, nil
+}
+
 func safeStringValue(v interface{}) string {
 	if v == nil {
 		return ""
@@ -14,6 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
 )
@@ -34,6 +37,77 @@ func TestJwtAlgorithm_Name(t *testing.T) {
 	require.Empty(t, sa)
 }
 
+func TestRegisterJWSAlgorithm(t *testing.T) {
+	const customAlg = "CustomEdDSA"
+
+	alg, err := RegisterJWSAlgorithm(customAlg, jwt.VerifyEdDSA)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		jwsAlgorithms.Lock()
+		defer jwsAlgorithms.Unlock()
+
+		delete(jwsAlgorithms.byID, alg)
+		delete(jwsAlgorithms.byName, customAlg)
+	})
+
+	algName, err := alg.name()
+	require.NoError(t, err)
+	require.Equal(t, customAlg, algName)
+
+	t.Run("marshal and parse credential JWS signed using custom algorithm", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, []byte(jwtTestCredential))
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWS, err := jwtClaims.MarshalJWS(alg, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#keys-1")
+		require.NoError(t, err)
+
+		jws, err := jose.ParseJWS(vcJWS, &noVerifier{})
+		require.NoError(t, err)
+
+		headerAlg, ok := jws.ProtectedHeaders.Algorithm()
+		require.True(t, ok)
+		require.Equal(t, customAlg, headerAlg)
+
+		vcFromJWS, err := parseTestCredential(t, []byte(vcJWS),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+		require.Equal(t, vc, withoutJWT(vcFromJWS))
+
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vcFromJWS, err = parseTestCredential(t, []byte(vcJWS),
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature doesn't match")
+		require.Nil(t, vcFromJWS)
+	})
+
+	t.Run("registration errors", func(t *testing.T) {
+		_, err := RegisterJWSAlgorithm(customAlg, jwt.VerifyEdDSA)
+		require.EqualError(t, err, "JWS algorithm CustomEdDSA is already registered")
+
+		_, err = RegisterJWSAlgorithm("EdDSA", jwt.VerifyEdDSA)
+		require.EqualError(t, err, "JWS algorithm EdDSA collides with the built-in one")
+
+		_, err = RegisterJWSAlgorithm("RS256", jwt.VerifyRS256)
+		require.EqualError(t, err, "JWS algorithm RS256 collides with the built-in one")
+
+		_, err = RegisterJWSAlgorithm("", jwt.VerifyEdDSA)
+		require.EqualError(t, err, "JWS algorithm name is not defined")
+
+		_, err = RegisterJWSAlgorithm("ES256K", nil)
+		require.EqualError(t, err, "verifier of ES256K JWS algorithm is not defined")
+	})
+}
+
 func TestStringSlice(t *testing.T) {
 	strings, err := stringSlice([]interface{}{"str1", "str2"})
 	require.NoError(t, err)
@@ -109,6 +183,48 @@ func TestTypedID_UnmarshalJSON(t *testing.T) {
 		}, tid.CustomFields)
 	})
 
+	t.Run("Successful unmarshalling of type defined as array", func(t *testing.T) {
+		tidJSON := `{
+  "id": "https://example.edu/evidence/f2aeec97-fc0d-42bf-8ca7-0548192d4231",
+  "type": ["DocumentVerification"],
+  "verifier": "https://example.edu/issuers/14",
+  "evidenceDocument": "DriversLicense"
+}`
+
+		var tid TypedID
+		err := json.Unmarshal([]byte(tidJSON), &tid)
+		require.NoError(t, err)
+
+		require.Equal(t, "https://example.edu/evidence/f2aeec97-fc0d-42bf-8ca7-0548192d4231", tid.ID)
+		require.Equal(t, "DocumentVerification", tid.Type)
+		require.Equal(t, []string{"DocumentVerification"}, tid.Types())
+		require.Equal(t, CustomFields{
+			"verifier":         "https://example.edu/issuers/14",
+			"evidenceDocument": "DriversLicense",
+		}, tid.CustomFields)
+
+		tidBytes, err := json.Marshal(tid)
+		require.NoError(t, err)
+		require.JSONEq(t, tidJSON, string(tidBytes))
+
+		// several types
+		require.NoError(t, json.Unmarshal([]byte(`{"type": ["DocumentVerification", "Other"]}`), &tid))
+		require.Equal(t, "DocumentVerification", tid.Type)
+		require.Equal(t, []string{"DocumentVerification", "Other"}, tid.Types())
+
+		tidBytes, err = json.Marshal(tid)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type": ["DocumentVerification", "Other"]}`, string(tidBytes))
+
+		// the changed type replaces the array
+		tid.Type = "Changed"
+		require.Equal(t, []string{"Changed"}, tid.Types())
+
+		tidBytes, err = json.Marshal(tid)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type": "Changed"}`, string(tidBytes))
+	})
+
 	t.Run("Invalid unmarshalling", func(t *testing.T) {
 		tidJSONWithInvalidType := `{
   "type": 77
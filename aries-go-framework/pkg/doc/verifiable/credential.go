@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
@@ -13,6 +14,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"reflect"
+	"strings"
 	"time"
 
 	jsonld "github.com/piprate/json-gold/ld"
@@ -22,6 +24,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 var logger = log.New("aries-framework/doc/verifiable")
@@ -225,6 +228,9 @@ const DefaultSchema = `{
 // https://www.w3.org/TR/vc-data-model/#data-schemas
 const jsonSchema2018Type = "JsonSchemaValidator2018"
 
+// https://www.w3.org/TR/vc-data-model/#zero-knowledge-proofs
+const zkpExampleSchema2018Type = "ZkpExampleSchema2018"
+
 const (
 	// https://www.w3.org/TR/vc-data-model/#base-context
 	baseContext = "https://www.w3.org/2018/credentials/v1"
@@ -266,6 +272,11 @@ const (
 	// baseContextExtendedValidation when set it's validated that fields that are specified in base context are
 	// as specified. Additional fields are allowed.
 	baseContextExtendedValidation
+
+	// jsonSchemaValidation when set it uses JSON Schema only for validation.
+	// It's applied instead of combinedValidation to VC decoded from JWS as its proof does not depend on JSON-LD,
+	// unless WithStrictValidation() is set.
+	jsonSchemaValidation
 )
 
 // SchemaCache defines a cache of credential schemas.
@@ -348,7 +359,7 @@ func (b *CredentialSchemaLoaderBuilder) Build() *CredentialSchemaLoader {
 
 // Put element to the cache. It also adds a mark of when the element will expire.
 func (sc *ExpirableSchemaCache) Put(k string, v []byte) {
-	expires := time.Now().Add(sc.expiration).Unix()
+	expires := now().Add(sc.expiration).Unix()
 
 	const numBytesTime = 8
 
@@ -373,7 +384,7 @@ func (sc *ExpirableSchemaCache) Get(k string) ([]byte, bool) {
 	const numBytesTime = 8
 
 	expires := int64(binary.LittleEndian.Uint64(b[:numBytesTime]))
-	if expires < time.Now().Unix() {
+	if expires < now().Unix() {
 		// cache expires
 		sc.cache.Del([]byte(k))
 		return nil, false
@@ -383,7 +394,7 @@ func (sc *ExpirableSchemaCache) Get(k string) ([]byte, bool) {
 }
 
 // Evidence defines evidence of Verifiable Credential.
-type Evidence interface{}
+type Evidence = []TypedID
 
 // Issuer of the Verifiable Credential.
 type Issuer struct {
@@ -392,8 +403,9 @@ type Issuer struct {
 	CustomFields CustomFields `json:"-"`
 }
 
-// MarshalJSON marshals Issuer to JSON.
-func (i *Issuer) MarshalJSON() ([]byte, error) {
+// MarshalJSON marshals Issuer to JSON. Issuer is marshalled as a string (issuer ID) if there are no custom fields,
+// otherwise it's marshalled as an object.
+func (i Issuer) MarshalJSON() ([]byte, error) {
 	if len(i.CustomFields) == 0 {
 		// as string
 		return json.Marshal(i.ID)
@@ -402,7 +414,7 @@ func (i *Issuer) MarshalJSON() ([]byte, error) {
 	// as object
 	type Alias Issuer
 
-	alias := Alias(*i)
+	alias := Alias(i)
 
 	data, err := marshalWithCustomFields(alias, i.CustomFields)
 	if err != nil {
@@ -441,6 +453,12 @@ func (i *Issuer) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// IssuerID returns ID of the credential issuer regardless of whether the issuer is defined as a string
+// or as an object.
+func (vc *Credential) IssuerID() string {
+	return vc.Issuer.ID
+}
+
 // Subject of the Verifiable Credential.
 type Subject struct {
 	ID string `json:"id,omitempty"`
@@ -492,11 +510,17 @@ func (s *Subject) UnmarshalJSON(bytes []byte) error {
 }
 
 // Credential Verifiable Credential definition.
+//
+// Empty Schemas are omitted from JSON (Schemas is nil if credentialSchema is not defined). The only exception
+// is the credential parsed from JSON defining credentialSchema as an empty array: the array is kept as is
+// (unless Schemas is set to nil), so the credential is marshalled back without changes.
 type Credential struct {
 	Context       []string
 	CustomContext []interface{}
 	ID            string
-	Types         []string
+	// Types are parsed from "type" defined either as a single string or as an array, they are marshalled
+	// as a single string if there is only one type and as an array otherwise (the same as Presentation Type).
+	Types []string
 	// Subject can be a string, map, slice of maps, struct (Subject or any custom), slice of structs.
 	Subject        interface{}
 	Issuer         Issuer
@@ -510,6 +534,28 @@ type Credential struct {
 	RefreshService []TypedID
 
 	CustomFields CustomFields
+
+	// Confirmation is the "cnf" claim of the credential parsed from JWT (see HolderConfirmation). It's not a part
+	// of the credential data model, so it's put into JWT claims (see JWTClaims) but not into JSON form.
+	Confirmation *HolderConfirmation
+
+	// fieldOrder is the original order of fields (see WithPreserveFieldOrder).
+	fieldOrder *fieldOrder
+	// issuerAsObject indicates the issuer is marshalled as an object by MarshalDisplayJSON (see WithIssuerAsObject).
+	issuerAsObject bool
+	// emptySchemas indicates credentialSchema is defined as an empty array in the parsed credential.
+	emptySchemas bool
+	// evidenceArray indicates evidence is defined as an array in the parsed credential.
+	evidenceArray bool
+	// compactMarshaling indicates empty fields are omitted by MarshalDisplayJSON (see WithCompactMarshaling).
+	compactMarshaling bool
+	// typedSubject is the subject unmarshalled into the Go type registered by RegisterSubjectType.
+	typedSubject interface{}
+	// jwt is the original JWT the credential is parsed from (see JWTString).
+	jwt string
+	// decodedJSON is the JSON the credential is parsed from; its numbers are checked by Fingerprint
+	// as float64 the credential numbers are decoded into can't represent some of them exactly.
+	decodedJSON []byte
 }
 
 // rawCredential is a basic verifiable credential.
@@ -524,7 +570,7 @@ type rawCredential struct {
 	Status         *TypedID          `json:"credentialStatus,omitempty"`
 	Issuer         json.RawMessage   `json:"issuer,omitempty"`
 	Schema         interface{}       `json:"credentialSchema,omitempty"`
-	Evidence       Evidence          `json:"evidence,omitempty"`
+	Evidence       json.RawMessage   `json:"evidence,omitempty"`
 	TermsOfUse     json.RawMessage   `json:"termsOfUse,omitempty"`
 	RefreshService json.RawMessage   `json:"refreshService,omitempty"`
 
@@ -573,12 +619,35 @@ type credentialOpts struct {
 	allowedCustomTypes    map[string]bool
 	disabledProofCheck    bool
 	strictValidation      bool
+	noValidation          bool
+	schemaValidation      bool
+	maxDocumentSize       int
+	preserveFieldOrder    bool
+	issuerAsObject        bool
+	compactMarshaling     bool
 	ldpSuites             []verifier.SignatureSuite
+	autoSuites            bool
+	verificationMethod    string
+	httpClient            *http.Client
+	allowUnsecuredJWT     bool
+	expectedTypes         []string
+	vmAuthorizationVDR    vdrapi.Registry
+	unwrapGraph           bool
+	requireProof          bool
+	inlineContexts        map[string]json.RawMessage
+	maxProofs             int
+	evidenceVerifier      EvidenceVerifier
+	trustedIssuers        map[string]bool
+	subjectIDPolicy       subjectIDPolicy
+	jwtClaimsPolicy       JWTClaimsConflictPolicy
 
 	jsonldCredentialOpts
 }
 
 // CredentialOpt is the Verifiable Credential decoding option.
+// Options are applied to the options of every ParseCredential call separately, so the same options can be
+// reused by concurrent calls as long as the values they hold (e.g. document loader, public key fetcher,
+// signature suites or schema cache) are safe for concurrent use.
 type CredentialOpt func(opts *credentialOpts)
 
 // WithDisabledProofCheck option for disabling of proof check.
@@ -588,6 +657,117 @@ func WithDisabledProofCheck() CredentialOpt {
 	}
 }
 
+// WithRequireProof option rejects the credential which has neither embedded proof nor JWS signature
+// with ErrProofMissing, so unsigned credential is not accepted by mistake. Unlike the proof check,
+// it only requires the proof to be present, so it can be combined with WithDisabledProofCheck.
+func WithRequireProof() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.requireProof = true
+	}
+}
+
+// WithAllowUnsecuredJWT option allows the credential defined as unsecured JWT ("alg": "none"), e.g. for test
+// fixtures. Without this option ErrUnsecuredJWT is returned for such a credential unless the proof check
+// is disabled: missing JWT signature must not be silently accepted.
+func WithAllowUnsecuredJWT() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.allowUnsecuredJWT = true
+	}
+}
+
+// WithCredentialNoValidation option is for decoding of already trusted credentials (e.g. validated earlier)
+// as fast as possible. Only JSON unmarshalling into the Credential is made: neither proof nor
+// JSON Schema or JSON-LD checks are done and no network requests are made.
+// Unlike WithDisabledProofCheck, it disables the credential model validation too.
+func WithCredentialNoValidation() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.noValidation = true
+		opts.disabledProofCheck = true
+	}
+}
+
+// WithSchemaValidation option enables validation of the credential subject in JSON-LD expanded form against
+// the credential schemas (credentialSchema) using the validators registered by RegisterSchemaValidator.
+// An error is returned if there is no validator registered for the schema type. JSON Schema
+// (JsonSchemaValidator2018) describes the whole credential, so the built-in validator checks the credential
+// against every JSON Schema entry and reports the violations as SchemaValidationErrors.
+// If the JSON Schema entry of credentialSchema defines "digestSRI", the downloaded schema must match it
+// (ErrSchemaDigestMismatch is returned otherwise).
+func WithSchemaValidation() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.schemaValidation = true
+	}
+}
+
+// WithMaxDocumentSize option limits the size of the credential (in bytes) to be parsed. The credential which
+// exceeds the limit is rejected before it's decoded. For the credential in JWT form, the limit is applied
+// to the decoded JWT payload too.
+func WithMaxDocumentSize(n int) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.maxDocumentSize = n
+	}
+}
+
+// WithMaxProofs option limits the number of embedded proofs (proof set or chain) of the credential to be checked.
+// The credential with more proofs is rejected before any of them is verified. The default limit is 10,
+// a non-positive value disables the limit.
+func WithMaxProofs(n int) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.maxProofs = n
+	}
+}
+
+// EvidenceVerifier checks the evidence of the credential, e.g. by confirming with an external document
+// verification service that the evidence is still attested.
+type EvidenceVerifier func(evidence []TypedID) error
+
+// WithEvidenceVerifier option defines the custom check of the credential evidence. The verifier is called
+// by ParseCredential if the credential has evidence, after the credential is decoded and its proof is checked.
+// The credential is rejected if the verifier returns an error.
+func WithEvidenceVerifier(verifier EvidenceVerifier) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.evidenceVerifier = verifier
+	}
+}
+
+// WithJWTClaimsConflictPolicy option defines how the conflicts of the registered JWT claims and the fields
+// of "vc" claim are resolved when the credential in JWT form is parsed, e.g. if "exp" claim and expirationDate
+// differ. The registered JWT claims take precedence by default (JWTClaimsPreferJWT).
+func WithJWTClaimsConflictPolicy(policy JWTClaimsConflictPolicy) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.jwtClaimsPolicy = policy
+	}
+}
+
+// WithPreserveFieldOrder option records the original order of top-level and subject fields of the credential,
+// so the credential is marshalled (see Credential.MarshalJSON) with the fields in the same order
+// (e.g. for byte-exact round-trips of golden files). The fields which are not present in the original
+// credential follow in the sorted order. The nested values of the fields are marshalled in the sorted order.
+func WithPreserveFieldOrder() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.preserveFieldOrder = true
+	}
+}
+
+// WithIssuerAsObject option makes Credential.MarshalDisplayJSON output the issuer as an object ({"id": ...})
+// even if it is defined as a string in the original credential. It affects the display marshalling only:
+// Credential.MarshalJSON keeps the original form of the issuer, as changing it breaks the proofs.
+func WithIssuerAsObject() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.issuerAsObject = true
+	}
+}
+
+// WithCompactMarshaling option makes Credential.MarshalDisplayJSON omit the fields having empty arrays
+// or objects as values (e.g. "credentialSchema": []), including the nested ones. It affects the display
+// marshalling only: Credential.MarshalJSON keeps the empty fields, as removing them changes the signed data
+// and breaks the proofs.
+func WithCompactMarshaling() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.compactMarshaling = true
+	}
+}
+
 // WithNoCustomSchemaCheck option is for disabling of Credential Schemas download if defined
 // in Verifiable Credential. Instead, the Verifiable Credential is checked against default Schema.
 func WithNoCustomSchemaCheck() CredentialOpt {
@@ -613,6 +793,8 @@ func WithCredentialSchemaLoader(loader *CredentialSchemaLoader) CredentialOpt {
 }
 
 // WithJSONLDValidation uses the JSON LD parser for validation.
+// For VC in JWS form, JSON-LD validation is made only if this option is defined;
+// otherwise, only JSON Schema validation is made and JSON-LD document loader is not required.
 func WithJSONLDValidation() CredentialOpt {
 	return func(opts *credentialOpts) {
 		opts.modelValidationMode = jsonldValidation
@@ -649,6 +831,35 @@ func WithBaseContextExtendedValidation(customContexts, customTypes []string) Cre
 	}
 }
 
+// WithExpectedCredentialTypes option requires the credential "type" to include all the given types.
+// The credential which lacks any of them is rejected before the validation of its content.
+func WithExpectedCredentialTypes(types ...string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.expectedTypes = append(opts.expectedTypes, types...)
+	}
+}
+
+// WithVerifyMethodAuthorization option enables the check that the verification method of every linked data proof
+// belongs to the issuer of the credential and is authorized for the proof purpose: it must be listed
+// in the corresponding verification relationship (e.g. "assertionMethod") of the issuer's DID document resolved
+// by vdr. The proofs made by the keys of other DIDs or by the keys which are not authorized for their purpose
+// are rejected.
+func WithVerifyMethodAuthorization(vdr vdrapi.Registry) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.vmAuthorizationVDR = vdr
+	}
+}
+
+// WithUnwrapGraph option enables parsing of the credential wrapped into "@graph" of JSON-LD document:
+// the node of type "VerifiableCredential" is extracted from "@graph" (inheriting @context of the document).
+// The linked data proof of the wrapping document is checked against the whole document (so its graph
+// structure is canonicalized as it was signed), but it is not kept in the Proofs of the credential.
+func WithUnwrapGraph() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.unwrapGraph = true
+	}
+}
+
 // WithJSONLDDocumentLoader defines a JSON-LD document loader.
 func WithJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -662,6 +873,11 @@ func WithJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) CredentialOp
 //
 // In case of JSON-LD validation, the comparison of JSON-LD VC document after compaction with original VC one is made.
 // In case of mismatch a validation exception is raised.
+//
+// Terms expanded by "@vocab" of the context are defined, so credentials relying on "@vocab" instead of
+// explicit term definitions pass the strict validation.
+//
+// The credential id and the ids of the credential subjects must be valid URIs (e.g. DIDs, URNs or HTTP(S) URLs).
 func WithStrictValidation() CredentialOpt {
 	return func(opts *credentialOpts) {
 		opts.strictValidation = true
@@ -676,6 +892,16 @@ func WithExternalJSONLDContext(context ...string) CredentialOpt {
 	}
 }
 
+// WithSafeCanonicalizationCheck option rejects the credential having the terms expanded to blank node identifiers
+// (e.g. by "@vocab": "_:") with ErrUnsafeCanonicalization when its linked data proofs are checked.
+// Such terms are dropped by canonicalization, so they can be changed without breaking the proofs.
+// The check expands the credential once more, so it's not made by default.
+func WithSafeCanonicalizationCheck() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.blankNodeTermsCheck = true
+	}
+}
+
 // WithJSONLDOnlyValidRDF indicates the need to remove all invalid RDF dataset from normalize document
 // when verifying linked data signatures of verifiable credential.
 func WithJSONLDOnlyValidRDF() CredentialOpt {
@@ -691,6 +917,38 @@ func WithEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) CredentialOp
 	}
 }
 
+// WithAutoSignatureSuites option selects a signature suite for every embedded linked data proof by the proof
+// type, so credentials with proofs of different types can be checked without knowing the types in advance.
+// The suites defined by WithEmbeddedSignatureSuites take precedence; the default suites
+// (Ed25519Signature2018, Ed25519Signature2020, JsonWebSignature2020, EcdsaSecp256k1Signature2019,
+// BbsBlsSignature2020 and BbsBlsSignatureProof2020) are used for other proof types. The proof of unknown type
+// is reported as "no suite registered for type X" error.
+func WithAutoSignatureSuites() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.autoSuites = true
+	}
+}
+
+// WithVerifyProofByVerificationMethod option restricts the check of embedded linked data proofs to the proofs
+// with the given verification method, the other proofs of the credential are ignored (e.g. the proofs
+// of untrusted co-signers). An error is returned if the credential has no proof with this verification method.
+func WithVerifyProofByVerificationMethod(vm string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.verificationMethod = vm
+	}
+}
+
+// WithHTTPClient option defines HTTP client used for all the network requests made when parsing the credential:
+// downloading of credential schemas (unless WithCredentialSchemaLoader is used) and loading of remote JSON-LD
+// contexts (unless WithJSONLDDocumentLoader is used). The client is also used by Credential.Refresh and
+// StatusChecker if these options are passed to them (see WithRefreshCredentialOpts and WithStatusCredentialOpts)
+// and no dedicated HTTP client is defined.
+func WithHTTPClient(client *http.Client) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.httpClient = client
+	}
+}
+
 // parseIssuer parses raw issuer.
 //
 // Issuer can be defined by:
@@ -785,7 +1043,44 @@ func decodeCredentialSchemas(data *rawCredential) ([]TypedID, error) {
 // It returns decoded Credential.
 func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error) {
 	// Apply options.
+	return parseCredential(vcData, getCredentialOpts(opts))
+}
+
+// ValidateCredentialJSONLD parses Verifiable Credential like ParseCredential with JSON-LD validation
+// (see WithJSONLDValidation) and returns the non-fatal issues of JSON-LD validation instead of failing,
+// e.g. the terms which are not defined by the JSON-LD context and are dropped by compaction.
+// The strict validation (see WithStrictValidation) does not reject the credential with such issues.
+// The errors of JSON-LD processing (e.g. failure to load a context) and the other parsing errors are still returned.
+// The report is created by every call, so the same options can be reused by concurrent calls.
+func ValidateCredentialJSONLD(vcData []byte, opts ...CredentialOpt) (*ValidationReport, error) {
 	vcOpts := getCredentialOpts(opts)
+	vcOpts.modelValidationMode = jsonldValidation
+
+	report := &ValidationReport{}
+	vcOpts.validationReport = report
+
+	if _, err := parseCredential(vcData, vcOpts); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func parseCredential(vcData []byte, vcOpts *credentialOpts) (*Credential, error) {
+	if jwt.IsJWS(string(vcData)) && vcOpts.modelValidationMode == combinedValidation && !vcOpts.strictValidation {
+		// JWS proof does not depend on JSON-LD, so JSON-LD validation is made only when explicitly requested
+		// (including the strict validation which declines the terms not defined by JSON-LD context).
+		vcOpts.modelValidationMode = jsonSchemaValidation
+	}
+
+	if err := checkDocumentSize(len(vcData), vcOpts.maxDocumentSize); err != nil {
+		return nil, fmt.Errorf("decode new credential: %w", err)
+	}
+
+	// The credential content is checked prior to the proof verification which may fetch the keys and contexts.
+	if err := precheckCredential(vcData, vcOpts); err != nil {
+		return nil, err
+	}
 
 	// Decode credential (e.g. from JWT).
 	vcDataDecoded, err := decodeRaw(vcData, vcOpts)
@@ -807,14 +1102,122 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		return nil, fmt.Errorf("build new credential: %w", err)
 	}
 
+	vc.issuerAsObject = vcOpts.issuerAsObject
+	vc.compactMarshaling = vcOpts.compactMarshaling
+	// the copy is kept as the decoded JSON may share the buffer of vcData owned by the caller
+	vc.decodedJSON = append([]byte(nil), vcDataDecoded...)
+
+	vc.typedSubject, err = decodeTypedSubject(vc.Types, raw.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("build new credential: %w", err)
+	}
+
+	if vcStr := string(vcData); jwt.IsJWS(vcStr) || jwt.IsJWTUnsecured(vcStr) {
+		vc.Confirmation, err = peekCredConfirmation(vcStr)
+		if err != nil {
+			return nil, fmt.Errorf("build new credential: %w", err)
+		}
+
+		vc.jwt = vcStr
+	}
+
+	if vcOpts.preserveFieldOrder {
+		vc.fieldOrder, err = recordFieldOrder(vcDataDecoded)
+		if err != nil {
+			return nil, fmt.Errorf("build new credential: %w", err)
+		}
+	}
+
+	if vcOpts.evidenceVerifier != nil && len(vc.Evidence) > 0 {
+		if err = vcOpts.evidenceVerifier(vc.Evidence); err != nil {
+			return nil, fmt.Errorf("verify credential evidence: %w", err)
+		}
+	}
+
+	if vcOpts.noValidation {
+		return vc, nil
+	}
+
 	err = validateCredential(vc, vcDataDecoded, vcOpts)
 	if err != nil {
 		return nil, err
 	}
 
+	if vcOpts.strictValidation {
+		if err = vc.validateIDs(); err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.schemaValidation {
+		if err = vc.validateSubjectSchemas(vcDataDecoded, vcOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = vc.checkSubjectIDs(vcOpts.subjectIDPolicy); err != nil {
+		return nil, err
+	}
+
 	return vc, nil
 }
 
+// checkExpectedTypes checks that the credential types include all the expected ones.
+func checkExpectedTypes(types, expectedTypes []string) error {
+	var missing []string
+
+	for _, expected := range expectedTypes {
+		if !containsString(types, expected) && !containsString(missing, expected) {
+			missing = append(missing, expected)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("credential does not have the expected types: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// precheckCredential checks the types and the issuer of the credential against the expected types
+// and the trusted issuers (if they are defined). The credential is decoded without checking its proof.
+func precheckCredential(vcData []byte, vcOpts *credentialOpts) error {
+	if vcOpts.expectedTypes == nil && vcOpts.trustedIssuers == nil {
+		return nil
+	}
+
+	vcBytes, err := peekRaw(vcData, vcOpts)
+	if err != nil {
+		return fmt.Errorf("decode new credential: %w", err)
+	}
+
+	var raw rawCredential
+
+	if err = json.Unmarshal(vcBytes, &raw); err != nil {
+		return fmt.Errorf("unmarshal new credential: %w", err)
+	}
+
+	if vcOpts.expectedTypes != nil {
+		var types []string
+
+		types, err = decodeType(raw.Type)
+		if err != nil {
+			return fmt.Errorf("fill credential types from raw: %w", err)
+		}
+
+		if err = checkExpectedTypes(types, vcOpts.expectedTypes); err != nil {
+			return err
+		}
+	}
+
+	issuer, err := parseIssuer(raw.Issuer)
+	if err != nil {
+		return fmt.Errorf("fill credential issuer from raw: %w", err)
+	}
+
+	return checkTrustedIssuer(issuer.ID, vcOpts.trustedIssuers)
+}
+
 func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts) error {
 	// Credential and type constraint.
 	switch vcOpts.modelValidationMode {
@@ -839,6 +1242,9 @@ func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts)
 	case baseContextExtendedValidation:
 		return vc.validateBaseContextWithExtendedValidation(vcOpts, vcBytes)
 
+	case jsonSchemaValidation:
+		return vc.validateJSONSchema(vcBytes, vcOpts)
+
 	default:
 		return fmt.Errorf("unsupported vcModelValidationMode: %v", vcOpts.modelValidationMode)
 	}
@@ -924,8 +1330,6 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		if err != nil {
 			return nil, fmt.Errorf("fill credential schemas from raw: %w", err)
 		}
-	} else {
-		schemas = make([]TypedID, 0)
 	}
 
 	types, err := decodeType(raw.Type)
@@ -953,6 +1357,11 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		return nil, fmt.Errorf("fill credential refresh service from raw: %w", err)
 	}
 
+	evidence, err := parseTypedID(raw.Evidence)
+	if err != nil {
+		return nil, fmt.Errorf("fill credential evidence from raw: %w", err)
+	}
+
 	proofs, err := parseProof(raw.Proof)
 	if err != nil {
 		return nil, fmt.Errorf("fill credential proof from raw: %w", err)
@@ -975,10 +1384,12 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		Proofs:         proofs,
 		Status:         raw.Status,
 		Schemas:        schemas,
-		Evidence:       raw.Evidence,
+		Evidence:       evidence,
 		TermsOfUse:     termsOfUse,
 		RefreshService: refreshService,
 		CustomFields:   raw.CustomFields,
+		emptySchemas:   schemas != nil && len(schemas) == 0,
+		evidenceArray:  len(raw.Evidence) > 0 && raw.Evidence[0] == '[',
 	}, nil
 }
 
@@ -1004,6 +1415,27 @@ func parseTypedID(bytes json.RawMessage) ([]TypedID, error) {
 	return nil, err
 }
 
+// peekRaw decodes the credential like decodeRaw but does not check its proof.
+func peekRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, error) {
+	vcStr := string(vcData)
+
+	if jwt.IsJWS(vcStr) {
+		return decodeCredJWS(vcStr, false, nil, vcOpts.jwtClaimsPolicy)
+	}
+
+	if jwt.IsJWTUnsecured(vcStr) {
+		return decodeCredJWTUnsecured(vcStr, vcOpts.jwtClaimsPolicy)
+	}
+
+	if vcOpts.unwrapGraph {
+		vcNode, _, err := unwrapGraph(vcData)
+
+		return vcNode, err
+	}
+
+	return vcData, nil
+}
+
 func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, error) {
 	vcStr := string(vcData)
 
@@ -1012,25 +1444,69 @@ func decodeRaw(vcData []byte, vcOpts *credentialOpts) ([]byte, error) {
 			return nil, errors.New("public key fetcher is not defined")
 		}
 
-		vcDecodedBytes, err := decodeCredJWS(vcStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher)
+		vcDecodedBytes, err := decodeCredJWS(vcStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher,
+			vcOpts.jwtClaimsPolicy)
 		if err != nil {
 			return nil, fmt.Errorf("JWS decoding: %w", err)
 		}
 
+		if err = checkDocumentSize(len(vcDecodedBytes), vcOpts.maxDocumentSize); err != nil {
+			return nil, fmt.Errorf("JWS decoding: %w", err)
+		}
+
 		return vcDecodedBytes, nil
 	}
 
 	if jwt.IsJWTUnsecured(vcStr) { // Embedded proof.
-		vcDecodedBytes, err := decodeCredJWTUnsecured(vcStr)
+		if !vcOpts.allowUnsecuredJWT && !vcOpts.disabledProofCheck {
+			return nil, fmt.Errorf("unsecured JWT decoding: %w", ErrUnsecuredJWT)
+		}
+
+		vcDecodedBytes, err := decodeCredJWTUnsecured(vcStr, vcOpts.jwtClaimsPolicy)
 		if err != nil {
 			return nil, fmt.Errorf("unsecured JWT decoding: %w", err)
 		}
 
+		if err = checkDocumentSize(len(vcDecodedBytes), vcOpts.maxDocumentSize); err != nil {
+			return nil, fmt.Errorf("unsecured JWT decoding: %w", err)
+		}
+
 		return checkEmbeddedProof(vcDecodedBytes, getEmbeddedProofCheckOpts(vcOpts))
 	}
 
 	// Embedded proof.
-	return checkEmbeddedProof(vcData, getEmbeddedProofCheckOpts(vcOpts))
+	if !vcOpts.unwrapGraph {
+		return checkEmbeddedProof(vcData, getEmbeddedProofCheckOpts(vcOpts))
+	}
+
+	// The proof of the document wrapping the credential into "@graph" is checked first against the whole document,
+	// the proof of the credential node itself (if any) is checked after unwrapping.
+	// If the proof is required, it's enough to have either of them.
+	wrapperProofOpts := getEmbeddedProofCheckOpts(vcOpts)
+	wrapperProofOpts.requireProof = false
+
+	vcDataChecked, err := checkEmbeddedProof(vcData, wrapperProofOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	vcNode, unwrapped, err := unwrapGraph(vcDataChecked)
+	if err != nil {
+		return nil, err
+	}
+
+	if !unwrapped {
+		if vcOpts.requireProof && !hasEmbeddedProof(vcNode) {
+			return nil, fmt.Errorf("check embedded proof: %w", ErrProofMissing)
+		}
+
+		return vcNode, nil
+	}
+
+	nodeProofOpts := getEmbeddedProofCheckOpts(vcOpts)
+	nodeProofOpts.requireProof = vcOpts.requireProof && !hasEmbeddedProof(vcDataChecked)
+
+	return checkEmbeddedProof(vcNode, nodeProofOpts)
 }
 
 func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
@@ -1038,6 +1514,12 @@ func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
 		publicKeyFetcher:     vcOpts.publicKeyFetcher,
 		disabledProofCheck:   vcOpts.disabledProofCheck,
 		ldpSuites:            vcOpts.ldpSuites,
+		autoSuites:           vcOpts.autoSuites,
+		verificationMethod:   vcOpts.verificationMethod,
+		vmAuthorizationVDR:   vcOpts.vmAuthorizationVDR,
+		controllerProofs:     vcOpts.trustedIssuers != nil,
+		requireProof:         vcOpts.requireProof,
+		maxProofs:            vcOpts.maxProofs,
 		jsonldCredentialOpts: vcOpts.jsonldCredentialOpts,
 	}
 }
@@ -1045,6 +1527,7 @@ func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
 func getCredentialOpts(opts []CredentialOpt) *credentialOpts {
 	crOpts := &credentialOpts{
 		modelValidationMode: combinedValidation,
+		maxProofs:           defaultMaxProofs,
 	}
 
 	for _, opt := range opts {
@@ -1052,15 +1535,25 @@ func getCredentialOpts(opts []CredentialOpt) *credentialOpts {
 	}
 
 	if crOpts.schemaLoader == nil {
-		crOpts.schemaLoader = newDefaultSchemaLoader()
+		crOpts.schemaLoader = newDefaultSchemaLoader(crOpts.httpClient)
 	}
 
+	if crOpts.jsonldDocumentLoader == nil && crOpts.httpClient != nil {
+		crOpts.jsonldDocumentLoader = jsonld.NewDefaultDocumentLoader(crOpts.httpClient)
+	}
+
+	crOpts.jsonldDocumentLoader = withInlineContexts(crOpts.jsonldDocumentLoader, crOpts.inlineContexts)
+
 	return crOpts
 }
 
-func newDefaultSchemaLoader() *CredentialSchemaLoader {
+func newDefaultSchemaLoader(client *http.Client) *CredentialSchemaLoader {
+	if client == nil {
+		client = &http.Client{}
+	}
+
 	return &CredentialSchemaLoader{
-		schemaDownloadClient: &http.Client{},
+		schemaDownloadClient: client,
 		jsonLoader:           defaultSchemaLoader(),
 	}
 }
@@ -1069,6 +1562,66 @@ func issuerToRaw(issuer Issuer) (json.RawMessage, error) {
 	return issuer.MarshalJSON()
 }
 
+// issuerObjectToRaw marshals the issuer as an object regardless of custom fields.
+func issuerObjectToRaw(issuer Issuer) (json.RawMessage, error) {
+	type Alias Issuer
+
+	data, err := marshalWithCustomFields(Alias(issuer), issuer.CustomFields)
+	if err != nil {
+		return nil, fmt.Errorf("marshal Issuer: %w", err)
+	}
+
+	return data, nil
+}
+
+// omitEmptyFields removes the fields having empty arrays or objects as values from JSON object
+// (see WithCompactMarshaling). The fields which become empty after the removal are removed as well.
+func omitEmptyFields(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var obj map[string]interface{}
+
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(withoutEmptyValues(obj))
+}
+
+func withoutEmptyValues(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, fieldValue := range value {
+			fieldValue = withoutEmptyValues(fieldValue)
+			if isEmptyValue(fieldValue) {
+				delete(value, k)
+
+				continue
+			}
+
+			value[k] = fieldValue
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = withoutEmptyValues(value[i])
+		}
+	}
+
+	return v
+}
+
+func isEmptyValue(v interface{}) bool {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
+
 // subjectToBytes converts subject(s) to bytes.
 // A subject can be of a different kind:
 // - string (represents subject id)
@@ -1178,14 +1731,15 @@ func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *cre
 }
 
 func getSchemaLoader(schemas []TypedID, opts *credentialOpts) (gojsonschema.JSONLoader, error) {
-	if opts.disabledCustomSchema {
+	if opts.disabledCustomSchema || opts.schemaValidation {
+		// custom schemas are checked by the registered schema validators if schema validation is enabled
 		return defaultSchemaLoader(), nil
 	}
 
 	for _, schema := range schemas {
 		switch schema.Type {
 		case jsonSchema2018Type:
-			customSchemaData, err := getJSONSchema(schema.ID, opts)
+			customSchemaData, err := getVerifiedJSONSchema(schema, opts)
 			if err != nil {
 				return nil, fmt.Errorf("load of custom credential schema from %s: %w", schema.ID, err)
 			}
@@ -1333,13 +1887,26 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		return nil, err
 	}
 
+	rawEvidence, err := typedIDsToRaw(vc.Evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vc.Evidence) == 1 && vc.evidenceArray {
+		// keep a single evidence in its original array form
+		rawEvidence, err = json.Marshal(vc.Evidence)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	proof, err := proofsToRaw(vc.Proofs)
 	if err != nil {
 		return nil, err
 	}
 
 	var schema interface{}
-	if len(vc.Schemas) > 0 {
+	if len(vc.Schemas) > 0 || vc.Schemas != nil && vc.emptySchemas {
 		schema = vc.Schemas
 	}
 
@@ -1362,7 +1929,7 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		Status:         vc.Status,
 		Issuer:         issuer,
 		Schema:         schema,
-		Evidence:       vc.Evidence,
+		Evidence:       rawEvidence,
 		RefreshService: rawRefreshService,
 		TermsOfUse:     rawTermsOfUse,
 		Issued:         vc.Issued,
@@ -1373,6 +1940,9 @@ func (vc *Credential) raw() (*rawCredential, error) {
 	return r, nil
 }
 
+// typesToRaw defines the form of "type" of both credential and presentation: a single type is marshalled
+// as a string. Either form yields the same JSON-LD canonical document, so the linked data proofs created
+// for the original form remain valid.
 func typesToRaw(types []string) interface{} {
 	if len(types) == 1 {
 		// as string
@@ -1410,16 +1980,49 @@ func typedIDsToRaw(typedIDs []TypedID) ([]byte, error) {
 }
 
 // MarshalJSON converts Verifiable Credential to JSON bytes.
+// The fields are in the original order if the credential is parsed using WithPreserveFieldOrder option.
 func (vc *Credential) MarshalJSON() ([]byte, error) {
+	return vc.marshalJSON(false)
+}
+
+// MarshalDisplayJSON converts Verifiable Credential to JSON bytes for display or storage purposes, applying
+// the normalizations requested when parsing (see WithIssuerAsObject and WithCompactMarshaling). The result can
+// differ from the signed credential, so it must not be used for proof verification; use MarshalJSON for that.
+func (vc *Credential) MarshalDisplayJSON() ([]byte, error) {
+	return vc.marshalJSON(true)
+}
+
+func (vc *Credential) marshalJSON(display bool) ([]byte, error) {
 	raw, err := vc.raw()
 	if err != nil {
 		return nil, fmt.Errorf("JSON marshalling of verifiable credential: %w", err)
 	}
 
+	if display && vc.issuerAsObject {
+		raw.Issuer, err = issuerObjectToRaw(vc.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("JSON marshalling of verifiable credential: %w", err)
+		}
+	}
+
 	byteCred, err := json.Marshal(raw)
 	if err != nil {
 		return nil, fmt.Errorf("JSON marshalling of verifiable credential: %w", err)
 	}
 
+	if display && vc.compactMarshaling {
+		byteCred, err = omitEmptyFields(byteCred)
+		if err != nil {
+			return nil, fmt.Errorf("JSON marshalling of verifiable credential: %w", err)
+		}
+	}
+
+	if vc.fieldOrder != nil {
+		byteCred, err = vc.fieldOrder.apply(byteCred)
+		if err != nil {
+			return nil, fmt.Errorf("JSON marshalling of verifiable credential: %w", err)
+		}
+	}
+
 	return byteCred, nil
 }
@@ -0,0 +1,288 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+)
+
+const (
+	// AnonCredsCredentialType is a type of the Credential mapped from the AnonCreds credential.
+	AnonCredsCredentialType = "AnonCredsCredential"
+
+	// AnonCredsSchemaType is a type of the credential schema (credentialSchema) of AnonCreds credential.
+	AnonCredsSchemaType = "AnonCredsDefinition"
+
+	// CLSignatureType is a type of the proof which keeps the CL signature of AnonCreds credential.
+	CLSignatureType = "CLSignature2022"
+
+	anonCredsCredDefIDField   = "credentialDefinitionId"
+	anonCredsRevRegIDField    = "revocationRegistryId"
+	clSignatureEncodedField   = "encodedValues"
+	anonCredsLegacyCredDefTag = ":3:CL:"
+	anonCredsIssuerIDSuffix   = "/anoncreds/"
+	indyLegacyDIDMethodPrefix = "did:sov:"
+)
+
+// AnonCredsCredential is an AnonCreds (Hyperledger Indy) credential.
+type AnonCredsCredential struct {
+	SchemaID                  string                             `json:"schema_id"`
+	CredDefID                 string                             `json:"cred_def_id"`
+	RevRegID                  string                             `json:"rev_reg_id,omitempty"`
+	Values                    map[string]AnonCredsAttributeValue `json:"values"`
+	Signature                 json.RawMessage                    `json:"signature,omitempty"`
+	SignatureCorrectnessProof json.RawMessage                    `json:"signature_correctness_proof,omitempty"`
+	RevReg                    json.RawMessage                    `json:"rev_reg,omitempty"`
+	Witness                   json.RawMessage                    `json:"witness,omitempty"`
+}
+
+// AnonCredsAttributeValue is a value of AnonCreds credential attribute in raw and encoded forms.
+type AnonCredsAttributeValue struct {
+	Raw     string `json:"raw"`
+	Encoded string `json:"encoded"`
+}
+
+// ParseAnonCredsCredential maps AnonCreds credential into the Credential. The attributes (raw values) become
+// the fields of the credential subject, the schema is referenced by credentialSchema of AnonCredsDefinition
+// type and the issuer is derived from the credential definition ID. The CL signature and the encoded attribute
+// values it signs are kept as a proof of CLSignature2022 type, so the credential can be converted back
+// by Credential.AnonCreds().
+// AnonCreds credential has no issuance date, so the time of mapping is set as issuanceDate.
+//
+// The CL signature is NOT verified. Only WithMaxDocumentSize option is applied.
+func ParseAnonCredsCredential(data []byte, opts ...CredentialOpt) (*Credential, error) {
+	vcOpts := getCredentialOpts(opts)
+
+	if err := checkDocumentSize(len(data), vcOpts.maxDocumentSize); err != nil {
+		return nil, fmt.Errorf("parse AnonCreds credential: %w", err)
+	}
+
+	var ac AnonCredsCredential
+
+	if err := json.Unmarshal(data, &ac); err != nil {
+		return nil, fmt.Errorf("parse AnonCreds credential: %w", err)
+	}
+
+	if ac.SchemaID == "" {
+		return nil, errors.New("parse AnonCreds credential: schema ID is missing")
+	}
+
+	issuerID, err := anonCredsIssuerID(ac.CredDefID)
+	if err != nil {
+		return nil, fmt.Errorf("parse AnonCreds credential: %w", err)
+	}
+
+	attributes := make(CustomFields, len(ac.Values))
+
+	for name, value := range ac.Values {
+		attributes[name] = value.Raw
+	}
+
+	proof, err := clSignatureProof(&ac)
+	if err != nil {
+		return nil, fmt.Errorf("parse AnonCreds credential: %w", err)
+	}
+
+	customFields := CustomFields{anonCredsCredDefIDField: ac.CredDefID}
+
+	if ac.RevRegID != "" {
+		customFields[anonCredsRevRegIDField] = ac.RevRegID
+	}
+
+	return &Credential{
+		Context:      []string{baseContext},
+		Types:        []string{vcType, AnonCredsCredentialType},
+		Subject:      []Subject{{CustomFields: attributes}},
+		Issuer:       Issuer{ID: issuerID},
+		Issued:       util.NewTime(now()),
+		Proofs:       []Proof{proof},
+		Schemas:      []TypedID{{ID: ac.SchemaID, Type: AnonCredsSchemaType}},
+		CustomFields: customFields,
+	}, nil
+}
+
+// AnonCreds converts the Credential created by ParseAnonCredsCredential back into AnonCreds credential.
+// The encoded attribute values are taken from the CL signature proof; the values which are not found there
+// are computed from the raw ones.
+func (vc *Credential) AnonCreds() (*AnonCredsCredential, error) {
+	ac := &AnonCredsCredential{
+		CredDefID: safeStringValue(vc.CustomFields[anonCredsCredDefIDField]),
+		RevRegID:  safeStringValue(vc.CustomFields[anonCredsRevRegIDField]),
+	}
+
+	for _, schema := range vc.Schemas {
+		if schema.Type == AnonCredsSchemaType {
+			ac.SchemaID = schema.ID
+
+			break
+		}
+	}
+
+	if ac.SchemaID == "" || ac.CredDefID == "" {
+		return nil, errors.New("convert to AnonCreds credential: not an AnonCreds credential")
+	}
+
+	attributes, err := anonCredsAttributes(vc.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("convert to AnonCreds credential: %w", err)
+	}
+
+	var encodedValues map[string]interface{}
+
+	for _, proof := range vc.Proofs {
+		if proof["type"] != CLSignatureType {
+			continue
+		}
+
+		if err = setCLSignature(ac, proof); err != nil {
+			return nil, fmt.Errorf("convert to AnonCreds credential: %w", err)
+		}
+
+		encodedValues, _ = proof[clSignatureEncodedField].(map[string]interface{})
+
+		break
+	}
+
+	ac.Values = make(map[string]AnonCredsAttributeValue, len(attributes))
+
+	for name, value := range attributes {
+		raw, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("convert to AnonCreds credential: attribute %s is not a string", name)
+		}
+
+		encoded, ok := encodedValues[name].(string)
+		if !ok {
+			encoded = encodeAnonCredsValue(raw)
+		}
+
+		ac.Values[name] = AnonCredsAttributeValue{Raw: raw, Encoded: encoded}
+	}
+
+	return ac, nil
+}
+
+func anonCredsAttributes(subject interface{}) (map[string]interface{}, error) {
+	switch s := subject.(type) {
+	case map[string]interface{}:
+		return s, nil
+	case Subject:
+		return s.CustomFields, nil
+	case []Subject:
+		if len(s) == 1 {
+			return s[0].CustomFields, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported subject %T", subject)
+}
+
+// anonCredsIssuerID derives the issuer DID from the credential definition ID, either of legacy Indy form
+// "<DID>:3:CL:<schema seq no>:<tag>" or of "<issuer DID>/anoncreds/v0/CLAIM_DEF/..." form.
+func anonCredsIssuerID(credDefID string) (string, error) {
+	var issuerID string
+
+	switch {
+	case strings.Contains(credDefID, anonCredsLegacyCredDefTag):
+		issuerID = credDefID[:strings.Index(credDefID, anonCredsLegacyCredDefTag)]
+	case strings.Contains(credDefID, anonCredsIssuerIDSuffix):
+		issuerID = credDefID[:strings.Index(credDefID, anonCredsIssuerIDSuffix)]
+	}
+
+	if issuerID == "" {
+		return "", fmt.Errorf("issuer cannot be derived from credential definition ID %q", credDefID)
+	}
+
+	if !strings.HasPrefix(issuerID, "did:") {
+		// unqualified Indy DID
+		issuerID = indyLegacyDIDMethodPrefix + issuerID
+	}
+
+	return issuerID, nil
+}
+
+func clSignatureProof(ac *AnonCredsCredential) (Proof, error) {
+	proof := Proof{"type": CLSignatureType}
+
+	for field, value := range map[string]json.RawMessage{
+		"signature":                 ac.Signature,
+		"signatureCorrectnessProof": ac.SignatureCorrectnessProof,
+		"revocationRegistry":        ac.RevReg,
+		"witness":                   ac.Witness,
+	} {
+		if len(value) == 0 || string(value) == "null" {
+			continue
+		}
+
+		var v interface{}
+
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", field, err)
+		}
+
+		proof[field] = v
+	}
+
+	// The encoded values are signed, so they are kept as is rather than computed from the raw ones again.
+	encodedValues := make(map[string]interface{}, len(ac.Values))
+
+	for name, value := range ac.Values {
+		if value.Encoded != "" {
+			encodedValues[name] = value.Encoded
+		}
+	}
+
+	if len(encodedValues) > 0 {
+		proof[clSignatureEncodedField] = encodedValues
+	}
+
+	return proof, nil
+}
+
+func setCLSignature(ac *AnonCredsCredential, proof Proof) error {
+	fields := map[string]*json.RawMessage{
+		"signature":                 &ac.Signature,
+		"signatureCorrectnessProof": &ac.SignatureCorrectnessProof,
+		"revocationRegistry":        &ac.RevReg,
+		"witness":                   &ac.Witness,
+	}
+
+	for name, field := range fields {
+		value, ok := proof[name]
+		if !ok {
+			continue
+		}
+
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", name, err)
+		}
+
+		*field = valueBytes
+	}
+
+	return nil
+}
+
+// encodeAnonCredsValue encodes the raw attribute value as AnonCreds does: 32-bit integers are kept as is,
+// other values are encoded as decimal representation of SHA-256 hash of the raw value.
+func encodeAnonCredsValue(raw string) string {
+	if _, err := strconv.ParseInt(raw, 10, 32); err == nil {
+		return raw
+	}
+
+	hash := sha256.Sum256([]byte(raw))
+
+	return new(big.Int).SetBytes(hash[:]).String()
+}
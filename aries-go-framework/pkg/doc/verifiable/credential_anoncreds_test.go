@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:lll
+const anonCredsCredential = `
+{
+  "schema_id": "Th7MpTaRZVRYnPiabds81Y:2:degree schema:46.58.87",
+  "cred_def_id": "Th7MpTaRZVRYnPiabds81Y:3:CL:17:default",
+  "rev_reg_id": null,
+  "values": {
+    "name": {
+      "raw": "Alice Garcia",
+      "encoded": "42269428060847300013074105341288624461740820166347597208920185513943254001053"
+    },
+    "age": {
+      "raw": "28",
+      "encoded": "28"
+    }
+  },
+  "signature": {
+    "p_credential": {
+      "m_2": "57832835556928742723946725004638238236382427793876617639158517726445069815397",
+      "a": "20335594316731334597758816443885619716281946894071547670112874227353349613733",
+      "e": "259344723055062059907025491480697571938277889515152306249728583105665800713306759149981690559193987143012367913206299323899696942213235956742929837794489002147266183999965799605813",
+      "v": "8070312275110314663750247899433202850238560575163878956819342967827136399370879736823043902982634515009588016797203155246614708232573921376646871743359587732590693401587607271972304303322060390310307460889523961550612965021232979808509508502354241838342542729225461467834597352210800168107201638861601487760961526713355932504366874557170337152964069325172574449356691055377568302458374147949937789910094307449082152173580675507028369533914480926873196435808261915052547630680304620062203647948590064800546491641963412948122135194369131128319694594446518925913583118382698018169919523769679141724867515604189334120099773703979769794325694804992635522127820413717601811493634024617930397944903746555691677663850240187799372670069559074549528342288602574968520156320273386872799429362106185458798531573424651644586691950218"
+    },
+    "r_credential": null
+  },
+  "signature_correctness_proof": {
+    "se": "16380378819766384687299800964395104347426132415600670073499502988403571039552426989440730562439872799389359320216622430122149635890650280073919616970308875713611769602805907315796100888051513191790990723115153015179238215201014858697020476301190889292739142646098613335687696678474499610035829049097552703970387216872374849734708764603376911608392816067509505173513379900549958002287975424637744258982508227210821445545063280589183914569333870632968595659796744088289167771635644102920825749994200219186110532662348311959247565066406030309945998501282244986323336410628720691577720308242032279888024250179409222261839",
+    "c": "54687071895183924055442269144489786903186459631877792294627879136747836413523"
+  },
+  "rev_reg": null,
+  "witness": null
+}`
+
+func TestParseAnonCredsCredential(t *testing.T) {
+	t.Run("maps AnonCreds credential", func(t *testing.T) {
+		vc, err := ParseAnonCredsCredential([]byte(anonCredsCredential))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{baseContext}, vc.Context)
+		require.Equal(t, []string{vcType, AnonCredsCredentialType}, vc.Types)
+		require.Equal(t, "did:sov:Th7MpTaRZVRYnPiabds81Y", vc.Issuer.ID)
+		require.NotNil(t, vc.Issued)
+		require.Equal(t, []Subject{{CustomFields: CustomFields{"name": "Alice Garcia", "age": "28"}}}, vc.Subject)
+		require.Equal(t, []TypedID{{
+			ID:   "Th7MpTaRZVRYnPiabds81Y:2:degree schema:46.58.87",
+			Type: AnonCredsSchemaType,
+		}}, vc.Schemas)
+		require.Equal(t, "Th7MpTaRZVRYnPiabds81Y:3:CL:17:default", vc.CustomFields["credentialDefinitionId"])
+		require.NotContains(t, vc.CustomFields, "revocationRegistryId")
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, CLSignatureType, vc.Proofs[0]["type"])
+		require.Contains(t, vc.Proofs[0], "signature")
+		require.Contains(t, vc.Proofs[0], "signatureCorrectnessProof")
+		require.NotContains(t, vc.Proofs[0], "witness")
+		require.Equal(t, map[string]interface{}{
+			"name": "42269428060847300013074105341288624461740820166347597208920185513943254001053",
+			"age":  "28",
+		}, vc.Proofs[0]["encodedValues"])
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		vcParsed, err := parseTestCredential(t, vcBytes, WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, vc.Issuer, vcParsed.Issuer)
+		require.Equal(t, vc.Subject, vcParsed.Subject)
+
+		ac, err := vc.AnonCreds()
+		require.NoError(t, err)
+
+		acParsed, err := vcParsed.AnonCreds()
+		require.NoError(t, err)
+		require.Equal(t, ac, acParsed)
+	})
+
+	t.Run("qualified issuer DID", func(t *testing.T) {
+		var ac AnonCredsCredential
+
+		require.NoError(t, json.Unmarshal([]byte(anonCredsCredential), &ac))
+
+		ac.CredDefID = "did:indy:sovrin:Th7MpTaRZVRYnPiabds81Y/anoncreds/v0/CLAIM_DEF/17/default"
+
+		acBytes, err := json.Marshal(ac)
+		require.NoError(t, err)
+
+		vc, err := ParseAnonCredsCredential(acBytes)
+		require.NoError(t, err)
+		require.Equal(t, "did:indy:sovrin:Th7MpTaRZVRYnPiabds81Y", vc.Issuer.ID)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		vc, err := ParseAnonCredsCredential([]byte(anonCredsCredential))
+		require.NoError(t, err)
+
+		ac, err := vc.AnonCreds()
+		require.NoError(t, err)
+
+		acBytes, err := json.Marshal(ac)
+		require.NoError(t, err)
+
+		var expected, actual map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(anonCredsCredential), &expected))
+		require.NoError(t, json.Unmarshal(acBytes, &actual))
+
+		// null values are omitted
+		for _, field := range []string{"rev_reg_id", "rev_reg", "witness"} {
+			delete(expected, field)
+		}
+
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("encoded values are kept", func(t *testing.T) {
+		var ac AnonCredsCredential
+
+		require.NoError(t, json.Unmarshal([]byte(anonCredsCredential), &ac))
+
+		// the issuer can use other encoding of the raw values
+		ac.Values["name"] = AnonCredsAttributeValue{Raw: "Alice Garcia", Encoded: "1139481716457488690172217916278103335"}
+
+		acBytes, err := json.Marshal(ac)
+		require.NoError(t, err)
+
+		vc, err := ParseAnonCredsCredential(acBytes)
+		require.NoError(t, err)
+
+		converted, err := vc.AnonCreds()
+		require.NoError(t, err)
+		require.Equal(t, ac.Values, converted.Values)
+
+		// the values which are not kept in the proof are computed
+		delete(vc.Proofs[0], "encodedValues")
+
+		converted, err = vc.AnonCreds()
+		require.NoError(t, err)
+		require.Equal(t, "42269428060847300013074105341288624461740820166347597208920185513943254001053",
+			converted.Values["name"].Encoded)
+	})
+
+	t.Run("invalid AnonCreds credential", func(t *testing.T) {
+		_, err := ParseAnonCredsCredential([]byte("not JSON"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse AnonCreds credential")
+
+		_, err = ParseAnonCredsCredential([]byte(`{"cred_def_id": "Th7MpTaRZVRYnPiabds81Y:3:CL:17:default"}`))
+		require.EqualError(t, err, "parse AnonCreds credential: schema ID is missing")
+
+		_, err = ParseAnonCredsCredential([]byte(`{"schema_id": "schema", "cred_def_id": "unknown"}`))
+		require.EqualError(t, err,
+			`parse AnonCreds credential: issuer cannot be derived from credential definition ID "unknown"`)
+
+		_, err = ParseAnonCredsCredential([]byte(anonCredsCredential), WithMaxDocumentSize(100))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exceeds the limit of 100 bytes")
+	})
+
+	t.Run("not AnonCreds credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		_, err = vc.AnonCreds()
+		require.EqualError(t, err, "convert to AnonCreds credential: not an AnonCreds credential")
+	})
+}
@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+)
+
+// CredentialBuilder defines a builder of Credential. The base @context
+// ("https://www.w3.org/2018/credentials/v1") and type ("VerifiableCredential") are always included, so only
+// the additional ones have to be defined.
+type CredentialBuilder struct {
+	vc Credential
+}
+
+// NewCredentialBuilder creates a new instance of CredentialBuilder.
+func NewCredentialBuilder() *CredentialBuilder {
+	return &CredentialBuilder{
+		vc: Credential{
+			Context: []string{baseContext},
+			Types:   []string{vcType},
+		},
+	}
+}
+
+// ID sets the credential id.
+func (b *CredentialBuilder) ID(id string) *CredentialBuilder {
+	b.vc.ID = id
+	return b
+}
+
+// Context adds @context of the credential.
+func (b *CredentialBuilder) Context(contexts ...string) *CredentialBuilder {
+	for _, c := range contexts {
+		if !containsString(b.vc.Context, c) {
+			b.vc.Context = append(b.vc.Context, c)
+		}
+	}
+
+	return b
+}
+
+// Type adds types of the credential.
+func (b *CredentialBuilder) Type(types ...string) *CredentialBuilder {
+	for _, t := range types {
+		if !containsString(b.vc.Types, t) {
+			b.vc.Types = append(b.vc.Types, t)
+		}
+	}
+
+	return b
+}
+
+// Issuer sets the issuer of the credential.
+func (b *CredentialBuilder) Issuer(id string) *CredentialBuilder {
+	b.vc.Issuer.ID = id
+	return b
+}
+
+// IssuerCustomFields sets the extra fields of the issuer (e.g. "name"). The issuer is serialized as an object then.
+func (b *CredentialBuilder) IssuerCustomFields(fields CustomFields) *CredentialBuilder {
+	b.vc.Issuer.CustomFields = fields
+	return b
+}
+
+// Subject sets credentialSubject. It can be a string, map, slice of maps, struct (Subject or any custom)
+// or slice of structs.
+func (b *CredentialBuilder) Subject(subject interface{}) *CredentialBuilder {
+	b.vc.Subject = subject
+	return b
+}
+
+// Issued sets issuanceDate of the credential.
+func (b *CredentialBuilder) Issued(t time.Time) *CredentialBuilder {
+	b.vc.Issued = util.NewTime(t)
+	return b
+}
+
+// Expired sets expirationDate of the credential.
+func (b *CredentialBuilder) Expired(t time.Time) *CredentialBuilder {
+	b.vc.Expired = util.NewTime(t)
+	return b
+}
+
+// Status sets credentialStatus.
+func (b *CredentialBuilder) Status(status *TypedID) *CredentialBuilder {
+	b.vc.Status = status
+	return b
+}
+
+// Schema adds credentialSchema.
+func (b *CredentialBuilder) Schema(schemas ...TypedID) *CredentialBuilder {
+	b.vc.Schemas = append(b.vc.Schemas, schemas...)
+	return b
+}
+
+// CustomField sets the extra field of the credential.
+func (b *CredentialBuilder) CustomField(name string, value interface{}) *CredentialBuilder {
+	if b.vc.CustomFields == nil {
+		b.vc.CustomFields = make(CustomFields)
+	}
+
+	b.vc.CustomFields[name] = value
+
+	return b
+}
+
+// Build validates the required fields (issuer, credentialSubject and issuanceDate) and returns a new Credential.
+// The builder can be reused: the further changes of the builder do not affect the returned credential.
+func (b *CredentialBuilder) Build() (*Credential, error) {
+	if err := b.vc.checkRequiredFields(); err != nil {
+		return nil, fmt.Errorf("build credential: %w", err)
+	}
+
+	vc := b.vc
+
+	vc.Context = append([]string(nil), b.vc.Context...)
+	vc.Types = append([]string(nil), b.vc.Types...)
+	vc.Schemas = append([]TypedID(nil), b.vc.Schemas...)
+	vc.Issuer.CustomFields = copyCustomFields(b.vc.Issuer.CustomFields)
+	vc.CustomFields = copyCustomFields(b.vc.CustomFields)
+
+	return &vc, nil
+}
+
+// checkRequiredFields checks that the fields required by VC data model are defined.
+func (vc *Credential) checkRequiredFields() error {
+	if vc.Issuer.ID == "" {
+		return errors.New("issuer is required")
+	}
+
+	if vc.Subject == nil {
+		return errors.New("credentialSubject is required")
+	}
+
+	if vc.Issued == nil {
+		return errors.New("issuanceDate is required")
+	}
+
+	if vc.Expired != nil && vc.Expired.Time.Before(vc.Issued.Time) {
+		return errors.New("expirationDate is before issuanceDate")
+	}
+
+	return nil
+}
+
+func copyCustomFields(fields CustomFields) CustomFields {
+	if fields == nil {
+		return nil
+	}
+
+	c := make(CustomFields, len(fields))
+
+	for k, v := range fields {
+		c[k] = v
+	}
+
+	return c
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialBuilder(t *testing.T) {
+	issued := time.Date(2010, time.January, 1, 19, 23, 24, 0, time.UTC)
+
+	newBuilder := func() *CredentialBuilder {
+		return NewCredentialBuilder().
+			ID("http://example.edu/credentials/1872").
+			Context("https://www.w3.org/2018/credentials/examples/v1").
+			Type("UniversityDegreeCredential").
+			Issuer("did:example:76e12ec712ebc6f1c221ebfeb1f").
+			Subject(Subject{ID: "did:example:ebfeb1f712ebc6f1c276e12ec21"}).
+			Issued(issued)
+	}
+
+	t.Run("build credential", func(t *testing.T) {
+		vc, err := newBuilder().
+			Context(baseContext, "https://www.w3.org/2018/credentials/examples/v1").
+			Type(vcType).
+			IssuerCustomFields(CustomFields{"name": "Example University"}).
+			Expired(issued.AddDate(10, 0, 0)).
+			Status(&TypedID{ID: "https://example.edu/status/24", Type: "CredentialStatusList2017"}).
+			Schema(TypedID{ID: "https://example.org/schema.json", Type: "JsonSchemaValidator2018"}).
+			CustomField("referenceNumber", 83294847).
+			Build()
+		require.NoError(t, err)
+
+		require.Equal(t, []string{baseContext, "https://www.w3.org/2018/credentials/examples/v1"}, vc.Context)
+		require.Equal(t, []string{vcType, "UniversityDegreeCredential"}, vc.Types)
+		require.Equal(t, "http://example.edu/credentials/1872", vc.ID)
+		require.Equal(t, Issuer{
+			ID:           "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			CustomFields: CustomFields{"name": "Example University"},
+		}, vc.Issuer)
+		require.Equal(t, issued, vc.Issued.Time)
+		require.Equal(t, issued.AddDate(10, 0, 0), vc.Expired.Time)
+		require.Equal(t, "CredentialStatusList2017", vc.Status.Type)
+		require.Len(t, vc.Schemas, 1)
+		require.Equal(t, CustomFields{"referenceNumber": 83294847}, vc.CustomFields)
+
+		// the built credential is valid
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithNoCustomSchemaCheck())
+		require.NoError(t, err)
+	})
+
+	t.Run("builder is reused", func(t *testing.T) {
+		b := newBuilder()
+
+		vc1, err := b.Build()
+		require.NoError(t, err)
+
+		vc2, err := b.Type("AlumniCredential").CustomField("alumniOf", "Example University").Build()
+		require.NoError(t, err)
+
+		require.Equal(t, []string{vcType, "UniversityDegreeCredential"}, vc1.Types)
+		require.Nil(t, vc1.CustomFields)
+		require.Equal(t, []string{vcType, "UniversityDegreeCredential", "AlumniCredential"}, vc2.Types)
+		require.Equal(t, CustomFields{"alumniOf": "Example University"}, vc2.CustomFields)
+	})
+
+	t.Run("required fields", func(t *testing.T) {
+		_, err := newBuilder().Issuer("").Build()
+		require.EqualError(t, err, "build credential: issuer is required")
+
+		_, err = newBuilder().Subject(nil).Build()
+		require.EqualError(t, err, "build credential: credentialSubject is required")
+
+		_, err = NewCredentialBuilder().Issuer("did:example:76e12ec712ebc6f1c221ebfeb1f").
+			Subject("did:example:ebfeb1f712ebc6f1c276e12ec21").Build()
+		require.EqualError(t, err, "build credential: issuanceDate is required")
+
+		_, err = newBuilder().Expired(issued.Add(-time.Hour)).Build()
+		require.EqualError(t, err, "build credential: expirationDate is before issuanceDate")
+	})
+}
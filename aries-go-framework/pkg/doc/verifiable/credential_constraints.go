@@ -0,0 +1,236 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	jsonpathkeys "github.com/kawamuray/jsonpath"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// FieldConstraint is a constraint on a field of the credential as defined by fields of
+// DIF Presentation Exchange constraints (https://identity.foundation/presentation-exchange/#input-descriptor-object).
+type FieldConstraint struct {
+	// Path is a list of JSONPath expressions (e.g. "$.credentialSubject.degree.type"). They are evaluated in order
+	// and the first one that selects a value of the credential is used.
+	Path []string
+
+	// Filter is an optional JSON Schema which every selected value must be valid against.
+	Filter map[string]interface{}
+}
+
+// SatisfiesConstraints checks whether the credential satisfies all field constraints, i.e. every constraint
+// selects at least one value of the credential and the selected values pass its filter.
+func (vc *Credential) SatisfiesConstraints(fields []FieldConstraint) (bool, error) {
+	selected, _, err := vc.selectConstrainedFields(fields)
+	if err != nil {
+		return false, err
+	}
+
+	return selected != nil, nil
+}
+
+// LimitDisclosurePaths returns JSON pointers (https://tools.ietf.org/html/rfc6901) of the credential values
+// which are not selected by the field constraints and thus have to be hidden when the constraints require
+// limit_disclosure. The values which are always disclosed (@context, id, type, issuer, issuanceDate,
+// credentialSubject.id and proof) are not reported.
+// An error is returned if the credential does not satisfy the constraints.
+func (vc *Credential) LimitDisclosurePaths(fields []FieldConstraint) ([]string, error) {
+	selected, doc, err := vc.selectConstrainedFields(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if selected == nil {
+		return nil, errors.New("credential does not satisfy the constraints")
+	}
+
+	var hidden []string
+
+	for _, pointer := range leafPointers(doc, "") {
+		if isAlwaysDisclosed(pointer) || isSelectedPointer(pointer, selected) {
+			continue
+		}
+
+		hidden = append(hidden, pointer)
+	}
+
+	sort.Strings(hidden)
+
+	return hidden, nil
+}
+
+// selectConstrainedFields returns JSON pointers of the credential values selected by the field constraints
+// (nil if the constraints are not satisfied) and the credential decoded into the generic JSON values.
+func (vc *Credential) selectConstrainedFields(fields []FieldConstraint) ([]string, interface{}, error) {
+	vcBytes, err := vc.MarshalJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var doc interface{}
+
+	if err = json.Unmarshal(vcBytes, &doc); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	selected := []string{}
+
+	for i, field := range fields {
+		pointers, err := selectByJSONPaths(vcBytes, field.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("field constraint %d: %w", i, err)
+		}
+
+		if len(pointers) == 0 {
+			return nil, doc, nil
+		}
+
+		valid, err := passFilter(field.Filter, doc, pointers)
+		if err != nil {
+			return nil, nil, fmt.Errorf("field constraint %d: %w", i, err)
+		}
+
+		if !valid {
+			return nil, doc, nil
+		}
+
+		selected = append(selected, pointers...)
+	}
+
+	return selected, doc, nil
+}
+
+// selectByJSONPaths evaluates JSONPath expressions in order and returns JSON pointers of the values
+// selected by the first expression which selects anything.
+func selectByJSONPaths(data []byte, jsonPaths []string) ([]string, error) {
+	for _, jsonPath := range jsonPaths {
+		paths, err := jsonpathkeys.ParsePaths(jsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("parse JSONPath %s: %w", jsonPath, err)
+		}
+
+		eval, err := jsonpathkeys.EvalPathsInReader(bytes.NewReader(data), paths)
+		if err != nil {
+			return nil, fmt.Errorf("evaluate JSONPath %s: %w", jsonPath, err)
+		}
+
+		var pointers []string
+
+		for {
+			result, ok := eval.Next()
+			if !ok {
+				break
+			}
+
+			pointers = append(pointers, keysToJSONPointer(result.Keys))
+		}
+
+		if eval.Error != nil {
+			return nil, fmt.Errorf("evaluate JSONPath %s: %w", jsonPath, eval.Error)
+		}
+
+		if len(pointers) > 0 {
+			return pointers, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func passFilter(filter map[string]interface{}, doc interface{}, pointers []string) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	schema := gojsonschema.NewGoLoader(filter)
+
+	for _, pointer := range pointers {
+		value, err := resolveJSONPointer(doc, pointer)
+		if err != nil {
+			return false, err
+		}
+
+		result, err := gojsonschema.Validate(schema, gojsonschema.NewGoLoader(value))
+		if err != nil {
+			return false, fmt.Errorf("validate value against filter: %w", err)
+		}
+
+		if !result.Valid() {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func keysToJSONPointer(keys []interface{}) string {
+	var sb strings.Builder
+
+	for _, key := range keys {
+		sb.WriteString("/")
+
+		// object keys are returned as byte slices, array indexes as integers
+		if k, ok := key.([]byte); ok {
+			sb.WriteString(escapeJSONPointerToken(string(k)))
+		} else {
+			sb.WriteString(escapeJSONPointerToken(fmt.Sprint(key)))
+		}
+	}
+
+	return sb.String()
+}
+
+// leafPointers returns JSON pointers of all primitive values (and empty objects and arrays) of the document.
+func leafPointers(v interface{}, prefix string) []string {
+	var pointers []string
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			pointers = append(pointers, leafPointers(item, prefix+"/"+escapeJSONPointerToken(k))...)
+		}
+
+	case []interface{}:
+		for i, item := range value {
+			pointers = append(pointers, leafPointers(item, fmt.Sprintf("%s/%d", prefix, i))...)
+		}
+	}
+
+	if len(pointers) == 0 {
+		return []string{prefix}
+	}
+
+	return pointers
+}
+
+func isAlwaysDisclosed(pointer string) bool {
+	for _, p := range []string{
+		"/@context", "/id", "/type", "/issuer", "/issuanceDate", "/credentialSubject/id", "/proof",
+	} {
+		if pointer == p || strings.HasPrefix(pointer, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSelectedPointer(pointer string, selected []string) bool {
+	for _, p := range selected {
+		if pointer == p || strings.HasPrefix(pointer, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
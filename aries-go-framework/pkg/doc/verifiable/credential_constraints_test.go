@@ -0,0 +1,109 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_SatisfiesConstraints(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+	require.NoError(t, err)
+
+	t.Run("constraints are satisfied", func(t *testing.T) {
+		satisfied, err := vc.SatisfiesConstraints([]FieldConstraint{
+			{
+				Path:   []string{"$.credentialSubject.degree.type"},
+				Filter: map[string]interface{}{"type": "string", "const": "BachelorDegree"},
+			},
+			{
+				Path:   []string{"$.type[*]"},
+				Filter: map[string]interface{}{"type": "string"},
+			},
+			{
+				Path: []string{"$.credentialSubject.degree.title", "$.credentialSubject.degree.name"},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, satisfied)
+
+		satisfied, err = vc.SatisfiesConstraints(nil)
+		require.NoError(t, err)
+		require.True(t, satisfied)
+	})
+
+	t.Run("value is not found", func(t *testing.T) {
+		satisfied, err := vc.SatisfiesConstraints([]FieldConstraint{
+			{Path: []string{"$.credentialSubject.degree.type"}},
+			{Path: []string{"$.credentialSubject.age", "$.credentialSubject.birthDate"}},
+		})
+		require.NoError(t, err)
+		require.False(t, satisfied)
+	})
+
+	t.Run("value does not pass filter", func(t *testing.T) {
+		satisfied, err := vc.SatisfiesConstraints([]FieldConstraint{{
+			Path:   []string{"$.credentialSubject.degree.type"},
+			Filter: map[string]interface{}{"type": "string", "const": "MasterDegree"},
+		}})
+		require.NoError(t, err)
+		require.False(t, satisfied)
+	})
+
+	t.Run("invalid JSONPath", func(t *testing.T) {
+		satisfied, err := vc.SatisfiesConstraints([]FieldConstraint{{Path: []string{"credentialSubject"}}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "field constraint 0: parse JSONPath credentialSubject")
+		require.False(t, satisfied)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		satisfied, err := vc.SatisfiesConstraints([]FieldConstraint{{
+			Path:   []string{"$.credentialSubject.degree.type"},
+			Filter: map[string]interface{}{"type": "unknown"},
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "field constraint 0: validate value against filter")
+		require.False(t, satisfied)
+	})
+}
+
+func TestCredential_LimitDisclosurePaths(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+	require.NoError(t, err)
+
+	paths, err := vc.LimitDisclosurePaths([]FieldConstraint{{
+		Path:   []string{"$.credentialSubject.degree.type"},
+		Filter: map[string]interface{}{"type": "string"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/credentialSubject/degree/name/0/@language",
+		"/credentialSubject/degree/name/0/@value",
+		"/credentialSubject/degree/name/1/@language",
+		"/credentialSubject/degree/name/1/@value",
+	}, paths)
+
+	paths, err = vc.LimitDisclosurePaths([]FieldConstraint{{
+		Path: []string{"$.credentialSubject.degree.name"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/credentialSubject/degree/type"}, paths)
+
+	paths, err = vc.LimitDisclosurePaths([]FieldConstraint{{
+		Path: []string{"$.credentialSubject.degree"},
+	}})
+	require.NoError(t, err)
+	require.Empty(t, paths)
+
+	paths, err = vc.LimitDisclosurePaths([]FieldConstraint{{
+		Path: []string{"$.credentialSubject.age"},
+	}})
+	require.EqualError(t, err, "credential does not satisfy the constraints")
+	require.Nil(t, paths)
+}
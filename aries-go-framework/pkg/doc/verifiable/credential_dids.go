@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"sort"
+	"strings"
+)
+
+// ReferencedDIDs returns all the DIDs referenced by the credential: the issuer, the ids of the credential
+// subjects and of the objects nested into them, the verification methods of the linked data proofs and
+// the credential status (including the status list credential). DID URLs are reduced to the DIDs
+// (e.g. "did:example:123#key-1" to "did:example:123"). The DIDs are deduplicated and returned
+// in the order of their first occurrence.
+func (vc *Credential) ReferencedDIDs() []string {
+	var dids []string
+
+	seen := make(map[string]bool)
+
+	add := func(value string) {
+		did, ok := didOf(value)
+		if !ok || seen[did] {
+			return
+		}
+
+		seen[did] = true
+		dids = append(dids, did)
+	}
+
+	add(vc.Issuer.ID)
+
+	collectSubjectDIDs(vc.Subject, add)
+
+	for _, p := range vc.Proofs {
+		switch vm := p["verificationMethod"].(type) {
+		case string:
+			add(vm)
+		case map[string]interface{}:
+			id, _ := vm["id"].(string)
+			add(id)
+		}
+	}
+
+	if vc.Status != nil {
+		add(vc.Status.ID)
+
+		if format, ok := defaultStatusListFormats()[vc.Status.Type]; ok {
+			listVC, _ := vc.Status.CustomFields[format.ListCredentialField].(string)
+			add(listVC)
+		}
+	}
+
+	return dids
+}
+
+// collectSubjectDIDs passes the ids of the subject and of the objects nested into it to add.
+func collectSubjectDIDs(subject interface{}, add func(string)) {
+	switch s := subject.(type) {
+	case nil:
+	case string:
+		add(s)
+	case Subject:
+		add(s.ID)
+		collectSubjectDIDs(map[string]interface{}(s.CustomFields), add)
+	case []Subject:
+		for i := range s {
+			collectSubjectDIDs(s[i], add)
+		}
+	case map[string]interface{}:
+		id, _ := s["id"].(string)
+		add(id)
+
+		keys := make([]string, 0, len(s))
+		for k := range s {
+			keys = append(keys, k)
+		}
+
+		// the keys are sorted to keep the order of DIDs stable
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			collectSubjectDIDs(nestedObjects(s[k]), add)
+		}
+	case []map[string]interface{}:
+		for i := range s {
+			collectSubjectDIDs(s[i], add)
+		}
+	case []interface{}:
+		for i := range s {
+			collectSubjectDIDs(nestedObjects(s[i]), add)
+		}
+	default:
+		// struct of custom type
+		if sMap, err := toMap(s); err == nil {
+			collectSubjectDIDs(sMap, add)
+		}
+	}
+}
+
+// nestedObjects returns the value if it's an object or an array, nil otherwise (plain values of the claims
+// are not the ids).
+func nestedObjects(v interface{}) interface{} {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return v
+	default:
+		return nil
+	}
+}
+
+// didOf returns the DID of the value if it's a DID or DID URL.
+func didOf(value string) (string, bool) {
+	if !strings.HasPrefix(value, "did:") || !didRegexp.MatchString(value) {
+		return "", false
+	}
+
+	if i := strings.IndexAny(value, "/?#"); i >= 0 {
+		value = value[:i]
+	}
+
+	return value, true
+}
@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_ReferencedDIDs(t *testing.T) {
+	t.Run("DIDs of all the well-known locations", func(t *testing.T) {
+		vc := &Credential{
+			Issuer: Issuer{ID: "did:example:issuer"},
+			Subject: []map[string]interface{}{
+				{
+					"id": "did:example:subject1",
+					"degree": map[string]interface{}{
+						"id":   "did:example:university#degree",
+						"name": "did:example:not-an-id",
+					},
+					"siblings": []interface{}{
+						map[string]interface{}{"id": "did:example:sibling"},
+						"did:example:not-an-id",
+					},
+				},
+				{
+					"id":    "https://example.com/subject2",
+					"other": map[string]interface{}{"id": 42},
+				},
+			},
+			Proofs: []Proof{
+				{"verificationMethod": "did:example:issuer#key-1"},
+				{"verificationMethod": map[string]interface{}{"id": "did:example:cosigner#key-1"}},
+			},
+			Status: &TypedID{
+				ID:   "did:example:status-issuer/status/1#42",
+				Type: statusList2021Entry,
+				CustomFields: CustomFields{
+					statusListCredentialField: "did:example:list-issuer/credentials/status/1",
+				},
+			},
+		}
+
+		require.Equal(t, []string{
+			"did:example:issuer",
+			"did:example:subject1",
+			"did:example:university",
+			"did:example:sibling",
+			"did:example:cosigner",
+			"did:example:status-issuer",
+			"did:example:list-issuer",
+		}, vc.ReferencedDIDs())
+	})
+
+	t.Run("subject of Subject type", func(t *testing.T) {
+		vc := &Credential{
+			Issuer: Issuer{ID: "https://example.edu/issuers/14"},
+			Subject: Subject{
+				ID:           "did:example:subject",
+				CustomFields: CustomFields{"spouse": map[string]interface{}{"id": "did:example:spouse"}},
+			},
+		}
+
+		require.Equal(t, []string{"did:example:subject", "did:example:spouse"}, vc.ReferencedDIDs())
+	})
+
+	t.Run("subject of custom type", func(t *testing.T) {
+		type customSubject struct {
+			ID string `json:"id"`
+		}
+
+		vc := &Credential{
+			Issuer:  Issuer{ID: "did:example:issuer"},
+			Subject: customSubject{ID: "did:example:issuer"},
+		}
+
+		require.Equal(t, []string{"did:example:issuer"}, vc.ReferencedDIDs())
+	})
+
+	t.Run("no DIDs", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		vc.Issuer.ID = "https://example.edu/issuers/14"
+		vc.Subject = "https://example.com/subject"
+
+		require.Empty(t, vc.ReferencedDIDs())
+	})
+}
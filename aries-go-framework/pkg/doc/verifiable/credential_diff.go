@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Difference describes a value which differs in the compared credentials.
+type Difference struct {
+	// Path is a JSON pointer (https://tools.ietf.org/html/rfc6901) of the value, e.g. "/credentialSubject/name".
+	Path string
+
+	// A and B are the values of the first and the second credential; nil means that the value is not defined.
+	A interface{}
+	B interface{}
+}
+
+// CredentialsEqual compares JSON content of the credentials ignoring their proofs and order of the fields.
+// It returns the differences ordered by path if the credentials are not equal.
+// An error of marshalling of any credential is reported as a difference of the root path.
+func CredentialsEqual(a, b *Credential) (bool, []Difference) {
+	aDoc, errA := credentialDocWithoutProof(a)
+	bDoc, errB := credentialDocWithoutProof(b)
+
+	if errA != nil || errB != nil {
+		return false, []Difference{{Path: "", A: errorValue(errA, aDoc), B: errorValue(errB, bDoc)}}
+	}
+
+	diffs := diffJSONValues("", aDoc, bDoc)
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+
+	return len(diffs) == 0, diffs
+}
+
+func credentialDocWithoutProof(vc *Credential) (interface{}, error) {
+	if vc == nil {
+		return nil, nil
+	}
+
+	vcBytes, err := vc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+
+	if err = json.Unmarshal(vcBytes, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	delete(doc, "proof")
+
+	return doc, nil
+}
+
+func errorValue(err error, doc interface{}) interface{} {
+	if err != nil {
+		return err.Error()
+	}
+
+	return doc
+}
+
+func diffJSONValues(path string, a, b interface{}) []Difference {
+	switch aValue := a.(type) {
+	case map[string]interface{}:
+		if bValue, ok := b.(map[string]interface{}); ok {
+			return diffJSONObjects(path, aValue, bValue)
+		}
+
+	case []interface{}:
+		if bValue, ok := b.([]interface{}); ok {
+			return diffJSONArrays(path, aValue, bValue)
+		}
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+
+	return []Difference{{Path: path, A: a, B: b}}
+}
+
+func diffJSONObjects(path string, a, b map[string]interface{}) []Difference {
+	var diffs []Difference
+
+	for k, aValue := range a {
+		diffs = append(diffs, diffJSONValues(path+"/"+escapeJSONPointerToken(k), aValue, b[k])...)
+	}
+
+	for k, bValue := range b {
+		if _, ok := a[k]; !ok {
+			diffs = append(diffs, Difference{Path: path + "/" + escapeJSONPointerToken(k), B: bValue})
+		}
+	}
+
+	return diffs
+}
+
+func diffJSONArrays(path string, a, b []interface{}) []Difference {
+	var diffs []Difference
+
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var aValue, bValue interface{}
+
+		if i < len(a) {
+			aValue = a[i]
+		}
+
+		if i < len(b) {
+			bValue = b[i]
+		}
+
+		diffs = append(diffs, diffJSONValues(fmt.Sprintf("%s/%d", path, i), aValue, bValue)...)
+	}
+
+	return diffs
+}
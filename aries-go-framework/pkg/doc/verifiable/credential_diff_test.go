@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialsEqual(t *testing.T) {
+	issued, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+	require.NoError(t, err)
+
+	t.Run("equal credentials", func(t *testing.T) {
+		stored, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+		require.NoError(t, err)
+
+		stored.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+		equal, diffs := CredentialsEqual(issued, stored)
+		require.True(t, equal)
+		require.Empty(t, diffs)
+
+		equal, diffs = CredentialsEqual(nil, nil)
+		require.True(t, equal)
+		require.Empty(t, diffs)
+	})
+
+	t.Run("different credentials", func(t *testing.T) {
+		stored, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+		require.NoError(t, err)
+
+		stored.ID = "http://example.edu/credentials/1873"
+		stored.Types = append(stored.Types, "AlumniCredential")
+		stored.CustomFields = CustomFields{"referenceNumber": 83294847}
+
+		subject, ok := stored.Subject.([]Subject)
+		require.True(t, ok)
+
+		delete(subject[0].CustomFields["degree"].(map[string]interface{}), "type")
+
+		equal, diffs := CredentialsEqual(issued, stored)
+		require.False(t, equal)
+		require.Equal(t, []Difference{
+			{
+				Path: "/credentialSubject/degree/type",
+				A:    "BachelorDegree",
+			},
+			{
+				Path: "/id",
+				A:    "http://example.edu/credentials/1872",
+				B:    "http://example.edu/credentials/1873",
+			},
+			{
+				Path: "/referenceNumber",
+				B:    83294847.0,
+			},
+			{
+				Path: "/type/2",
+				B:    "AlumniCredential",
+			},
+		}, diffs)
+	})
+
+	t.Run("one of credentials is not defined", func(t *testing.T) {
+		equal, diffs := CredentialsEqual(issued, nil)
+		require.False(t, equal)
+		require.Len(t, diffs, 1)
+		require.Equal(t, "", diffs[0].Path)
+		require.NotNil(t, diffs[0].A)
+		require.Nil(t, diffs[0].B)
+	})
+
+	t.Run("credential cannot be marshalled", func(t *testing.T) {
+		invalid, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+		require.NoError(t, err)
+
+		invalid.CustomFields = CustomFields{"invalid": make(chan int)}
+
+		equal, diffs := CredentialsEqual(issued, invalid)
+		require.False(t, equal)
+		require.Len(t, diffs, 1)
+		require.Contains(t, diffs[0].B, "unsupported type")
+	})
+}
@@ -0,0 +1,44 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Fingerprint returns a stable content hash of the credential: hex encoded SHA-256 of the credential
+// without proofs serialized using JSON Canonicalization Scheme (JCS, RFC 8785). The fingerprint depends
+// neither on the proofs nor on the JSON formatting (whitespaces, the order of fields and number format),
+// so it can be used to deduplicate and cache the credentials. The JSON-LD semantics is not taken into account,
+// e.g. the credentials using different terms for the same IRI have different fingerprints.
+// The numbers of the credential are float64, so the fingerprint of the parsed credential having a number
+// which float64 can't represent exactly (e.g. an integer above 2^53) is not computed, an error is returned instead.
+func (vc *Credential) Fingerprint() (string, error) {
+	if vc.decodedJSON != nil {
+		if err := checkExactNumbers(vc.decodedJSON); err != nil {
+			return "", fmt.Errorf("fingerprint of verifiable credential: %w", err)
+		}
+	}
+
+	vcCopy := *vc
+	vcCopy.Proofs = nil
+
+	raw, err := vcCopy.raw()
+	if err != nil {
+		return "", fmt.Errorf("fingerprint of verifiable credential: %w", err)
+	}
+
+	vcBytes, err := marshalCanonicalJSON(raw)
+	if err != nil {
+		return "", fmt.Errorf("fingerprint of verifiable credential: %w", err)
+	}
+
+	digest := sha256.Sum256(vcBytes)
+
+	return hex.EncodeToString(digest[:]), nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_Fingerprint(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	fingerprint, err := vc.Fingerprint()
+	require.NoError(t, err)
+	require.Len(t, fingerprint, 64)
+
+	t.Run("same credential with different formatting", func(t *testing.T) {
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		// json.Marshal sorts the keys and removes the whitespaces of the original document
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+		require.NotEqual(t, validCredential, string(vcBytes))
+
+		otherVC, err := parseTestCredential(t, vcBytes, WithPreserveFieldOrder())
+		require.NoError(t, err)
+
+		otherFingerprint, err := otherVC.Fingerprint()
+		require.NoError(t, err)
+		require.Equal(t, fingerprint, otherFingerprint)
+	})
+
+	t.Run("proofs are not taken into account", func(t *testing.T) {
+		vcCopy := *vc
+		vcCopy.Proofs = []Proof{{"type": "Ed25519Signature2018", "jws": "eyJ..sig"}}
+
+		otherFingerprint, err := vcCopy.Fingerprint()
+		require.NoError(t, err)
+		require.Equal(t, fingerprint, otherFingerprint)
+	})
+
+	t.Run("different content", func(t *testing.T) {
+		vcCopy := *vc
+		vcCopy.ID = "http://example.edu/credentials/other"
+
+		otherFingerprint, err := vcCopy.Fingerprint()
+		require.NoError(t, err)
+		require.NotEqual(t, fingerprint, otherFingerprint)
+	})
+
+	t.Run("numbers which can't be represented exactly", func(t *testing.T) {
+		parseWithNumber := func(n string) *Credential {
+			vcMap, err := toMap(validCredential)
+			require.NoError(t, err)
+
+			vcMap["credentialSubject"].(map[string]interface{})["n"] = json.Number(n)
+
+			vcBytes, err := json.Marshal(vcMap)
+			require.NoError(t, err)
+
+			otherVC, err := parseTestCredential(t, vcBytes)
+			require.NoError(t, err)
+
+			return otherVC
+		}
+
+		_, err := parseWithNumber("9007199254740992").Fingerprint()
+		require.NoError(t, err)
+
+		// the credential is parsed, the number is checked only when the fingerprint is computed;
+		// it would be decoded into the same float64 as 9007199254740992
+		_, err = parseWithNumber("9007199254740993").Fingerprint()
+		require.EqualError(t, err, "fingerprint of verifiable credential: "+
+			"number 9007199254740993 can't be represented exactly as IEEE 754 double")
+	})
+
+	t.Run("invalid credential", func(t *testing.T) {
+		_, err := (&Credential{Subject: make(chan int)}).Fingerprint()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "fingerprint of verifiable credential")
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	jsonldGraph   = "@graph"
+	jsonldContext = "@context"
+)
+
+// unwrapGraph extracts the credential node from JSON-LD document which wraps the credential into "@graph".
+// The credential node inherits @context of the document unless it defines its own one.
+// It returns false if the document has no "@graph".
+func unwrapGraph(docBytes []byte) ([]byte, bool, error) {
+	var doc map[string]interface{}
+
+	if err := json.Unmarshal(docBytes, &doc); err != nil {
+		return nil, false, fmt.Errorf("unwrap @graph: %w", err)
+	}
+
+	graph, ok := doc[jsonldGraph]
+	if !ok {
+		return docBytes, false, nil
+	}
+
+	var nodes []interface{}
+
+	switch g := graph.(type) {
+	case []interface{}:
+		nodes = g
+	case map[string]interface{}:
+		nodes = []interface{}{g}
+	default:
+		return nil, false, errors.New("unwrap @graph: @graph is not an array or object")
+	}
+
+	var credentialNode map[string]interface{}
+
+	for _, n := range nodes {
+		node, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if types, err := decodeType(node["type"]); err != nil || !containsString(types, vcType) {
+			continue
+		}
+
+		if credentialNode != nil {
+			return nil, false, errors.New("unwrap @graph: more than one credential is defined")
+		}
+
+		credentialNode = node
+	}
+
+	if credentialNode == nil {
+		return nil, false, errors.New("unwrap @graph: credential is not found")
+	}
+
+	if _, ok := credentialNode[jsonldContext]; !ok && doc[jsonldContext] != nil {
+		credentialNode[jsonldContext] = doc[jsonldContext]
+	}
+
+	nodeBytes, err := json.Marshal(credentialNode)
+	if err != nil {
+		return nil, false, fmt.Errorf("unwrap @graph: %w", err)
+	}
+
+	return nodeBytes, true, nil
+}
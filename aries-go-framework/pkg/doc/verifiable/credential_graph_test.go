@@ -0,0 +1,166 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestWithUnwrapGraph(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	var node map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(vcBytes, &node))
+
+	context := node["@context"]
+	delete(node, "@context")
+
+	graphDoc := func(t *testing.T, nodes ...interface{}) []byte {
+		t.Helper()
+
+		docBytes, err := json.Marshal(map[string]interface{}{
+			"@context": context,
+			"@graph":   nodes,
+		})
+		require.NoError(t, err)
+
+		return docBytes
+	}
+
+	s, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(s),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	fetcher := WithPublicKeyFetcher(SingleKey(s.PublicKeyBytes(), kms.ED25519))
+
+	t.Run("unwrap credential from @graph", func(t *testing.T) {
+		otherNode := map[string]interface{}{"id": "did:example:other", "type": "Person"}
+
+		graphVC, err := parseTestCredential(t, graphDoc(t, otherNode, node), WithUnwrapGraph())
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, graphVC.ID)
+		require.Equal(t, vc.Context, graphVC.Context)
+		require.Equal(t, vc.Types, graphVC.Types)
+		require.Equal(t, vc.Issuer, graphVC.Issuer)
+
+		// the document without @graph is parsed as usual
+		_, err = parseTestCredential(t, vcBytes, WithUnwrapGraph())
+		require.NoError(t, err)
+
+		// @graph is not unwrapped by default
+		_, err = parseTestCredential(t, graphDoc(t, node))
+		require.Error(t, err)
+
+		// neither the wrapping document nor the credential node has a proof
+		_, err = parseTestCredential(t, graphDoc(t, node), WithUnwrapGraph(), WithRequireProof())
+		require.ErrorIs(t, err, ErrProofMissing)
+
+		_, err = parseTestCredential(t, vcBytes, WithUnwrapGraph(), WithRequireProof())
+		require.ErrorIs(t, err, ErrProofMissing)
+	})
+
+	t.Run("proof of the wrapping document", func(t *testing.T) {
+		signedDoc, err := signer.New(ss).Sign(&signer.Context{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: proof.SignatureJWS,
+			VerificationMethod:      "did:example:123456#key1",
+		}, graphDoc(t, node), jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		graphVC, err := parseTestCredential(t, signedDoc, WithUnwrapGraph(), fetcher)
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, graphVC.ID)
+		require.Empty(t, graphVC.Proofs)
+
+		// the proof of the wrapping document satisfies the required proof
+		_, err = parseTestCredential(t, signedDoc, WithUnwrapGraph(), WithRequireProof(), fetcher)
+		require.NoError(t, err)
+
+		var signedMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(signedDoc, &signedMap))
+
+		graph, ok := signedMap["@graph"].([]interface{})
+		require.True(t, ok)
+
+		graph[0].(map[string]interface{})["id"] = "http://example.edu/credentials/tampered"
+
+		tamperedDoc, err := json.Marshal(signedMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, tamperedDoc, WithUnwrapGraph(), fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+	})
+
+	t.Run("proof of the credential node", func(t *testing.T) {
+		signedVC, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+
+		require.NoError(t, signedVC.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ss,
+			VerificationMethod:      "did:example:123456#key1",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t))))
+
+		signedNode := func(t *testing.T, vc *Credential) map[string]interface{} {
+			t.Helper()
+
+			vcBytes, err := vc.MarshalJSON()
+			require.NoError(t, err)
+
+			var n map[string]interface{}
+
+			require.NoError(t, json.Unmarshal(vcBytes, &n))
+			delete(n, "@context")
+
+			return n
+		}
+
+		graphVC, err := parseTestCredential(t, graphDoc(t, signedNode(t, signedVC)), WithUnwrapGraph(), fetcher)
+		require.NoError(t, err)
+		require.Len(t, graphVC.Proofs, 1)
+
+		_, err = parseTestCredential(t, graphDoc(t, signedNode(t, signedVC)), WithUnwrapGraph(), WithRequireProof(),
+			fetcher)
+		require.NoError(t, err)
+
+		signedVC.ID = "http://example.edu/credentials/tampered"
+
+		_, err = parseTestCredential(t, graphDoc(t, signedNode(t, signedVC)), WithUnwrapGraph(), fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+	})
+
+	t.Run("invalid @graph", func(t *testing.T) {
+		_, err := parseTestCredential(t, graphDoc(t, map[string]interface{}{"type": "Person"}), WithUnwrapGraph())
+		require.EqualError(t, err, "decode new credential: unwrap @graph: credential is not found")
+
+		_, err = parseTestCredential(t, graphDoc(t, node, node), WithUnwrapGraph())
+		require.EqualError(t, err, "decode new credential: unwrap @graph: more than one credential is defined")
+
+		_, err = parseTestCredential(t, []byte(`{"@graph": "credential"}`), WithUnwrapGraph())
+		require.EqualError(t, err, "decode new credential: unwrap @graph: @graph is not an array or object")
+	})
+}
@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+// JSONLDForm is a form of JSON-LD document (see https://www.w3.org/TR/json-ld11/#forms-of-json-ld).
+type JSONLDForm int
+
+const (
+	// JSONLDCompacted is the compacted form of the document using its own context.
+	JSONLDCompacted JSONLDForm = iota
+
+	// JSONLDExpanded is the expanded form of the document, i.e. all terms are expanded into IRIs
+	// and the context is removed.
+	JSONLDExpanded
+
+	// JSONLDFlattened is the flattened form of the document compacted using its own context, i.e. all nodes
+	// (including the nested ones, e.g. credential subject and proof) are put into the top-level "@graph".
+	JSONLDFlattened
+)
+
+// MarshalJSONLD converts Verifiable Credential to the JSON-LD document of the given form. The contexts are loaded
+// by the document loader defined by jsonld.WithDocumentLoader (the default one of json-gold otherwise).
+func (vc *Credential) MarshalJSONLD(form JSONLDForm, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	vcDoc, err := toMap(vc)
+	if err != nil {
+		return nil, fmt.Errorf("JSON-LD marshalling of verifiable credential: %w", err)
+	}
+
+	var doc interface{}
+
+	switch form {
+	case JSONLDCompacted:
+		doc, err = jsonld.Default().Compact(vcDoc, nil, opts...)
+	case JSONLDExpanded:
+		doc, err = jsonld.Default().Expand(vcDoc, opts...)
+	case JSONLDFlattened:
+		doc, err = jsonld.Default().Flatten(vcDoc, nil, opts...)
+	default:
+		return nil, fmt.Errorf("JSON-LD marshalling of verifiable credential: unsupported form %d", form)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("JSON-LD marshalling of verifiable credential: %w", err)
+	}
+
+	return json.Marshal(doc)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+func TestCredential_MarshalJSONLD(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	loaderOpt := jsonldsig.WithDocumentLoader(createTestDocumentLoader(t))
+
+	t.Run("compacted", func(t *testing.T) {
+		docBytes, err := vc.MarshalJSONLD(JSONLDCompacted, loaderOpt)
+		require.NoError(t, err)
+
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(docBytes, &doc))
+		require.Equal(t, vc.ID, doc["id"])
+		require.Equal(t, "VerifiableCredential", doc["type"])
+		require.Contains(t, doc, "@context")
+	})
+
+	t.Run("expanded", func(t *testing.T) {
+		docBytes, err := vc.MarshalJSONLD(JSONLDExpanded, loaderOpt)
+		require.NoError(t, err)
+
+		var doc []map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(docBytes, &doc))
+		require.Len(t, doc, 1)
+		require.Equal(t, vc.ID, doc[0]["@id"])
+		require.Equal(t, []interface{}{"https://www.w3.org/2018/credentials#VerifiableCredential"}, doc[0]["@type"])
+		require.Contains(t, doc[0], "https://www.w3.org/2018/credentials#credentialSubject")
+		require.NotContains(t, doc[0], "@context")
+	})
+
+	t.Run("flattened", func(t *testing.T) {
+		docBytes, err := vc.MarshalJSONLD(JSONLDFlattened, loaderOpt)
+		require.NoError(t, err)
+
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(docBytes, &doc))
+		require.Contains(t, doc, "@context")
+
+		graph, ok := doc["@graph"].([]interface{})
+		require.True(t, ok)
+
+		ids := make([]interface{}, len(graph))
+		for i := range graph {
+			ids[i] = graph[i].(map[string]interface{})["id"]
+		}
+
+		require.Contains(t, ids, vc.ID)
+		require.Contains(t, ids, vc.Issuer.ID)
+	})
+
+	t.Run("unsupported form", func(t *testing.T) {
+		_, err := vc.MarshalJSONLD(JSONLDForm(-1), loaderOpt)
+		require.EqualError(t, err, "JSON-LD marshalling of verifiable credential: unsupported form -1")
+	})
+
+	t.Run("context loading error", func(t *testing.T) {
+		vcCopy := *vc
+		vcCopy.Context = append([]string{}, vc.Context...)
+		vcCopy.Context = append(vcCopy.Context, "https://unknown.example.com/context/v1")
+
+		_, err := vcCopy.MarshalJSONLD(JSONLDExpanded, loaderOpt)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JSON-LD marshalling of verifiable credential")
+	})
+}
@@ -6,8 +6,18 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 // MarshalJWS serializes JWT into signed form (JWS).
+// The claims (including the "vc" object) are serialized using JSON Canonicalization Scheme (JCS),
+// so the same claims always yield the same signing input.
 func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jcc, signatureAlg, signer, keyID)
+	return marshalJWS(jcc, signatureAlg, signer, keyID, nil)
+}
+
+// MarshalJWSWithHeaders serializes JWT into signed form (JWS) with additional JOSE headers,
+// e.g. {"typ": "vc+jwt", "cty": "vc"}. The headers override the default ones ("typ" is "JWT" by default),
+// "alg" and "kid" headers are not allowed as they are defined by signatureAlg and keyID.
+func (jcc *JWTCredClaims) MarshalJWSWithHeaders(signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	headers map[string]interface{}) (string, error) {
+	return marshalJWS(jcc, signatureAlg, signer, keyID, headers)
 }
 
 func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) (*JWTCredClaims, error) {
@@ -21,8 +31,9 @@ func unmarshalJWSClaims(rawJwt string, checkProof bool, fetcher PublicKeyFetcher
 	return &claims, err
 }
 
-func decodeCredJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher) ([]byte, error) {
+func decodeCredJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher,
+	policy JWTClaimsConflictPolicy) ([]byte, error) {
 	return decodeCredJWT(rawJwt, func(vcJWTBytes string) (*JWTCredClaims, error) {
 		return unmarshalJWSClaims(rawJwt, checkProof, fetcher)
-	})
+	}, policy)
 }
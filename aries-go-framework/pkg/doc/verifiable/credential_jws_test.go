@@ -8,7 +8,9 @@ package verifiable
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/square/go-jose/v3"
@@ -38,7 +40,7 @@ func TestJWTCredClaimsMarshalJWS(t *testing.T) {
 				Type:  kms.RSARS256,
 				Value: signer.PublicKeyBytes(),
 			}, nil
-		})
+		}, JWTClaimsPreferJWT)
 		require.NoError(t, err)
 
 		vcRaw := new(rawCredential)
@@ -48,6 +50,74 @@ func TestJWTCredClaimsMarshalJWS(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, vc.stringJSON(t), vcRaw.stringJSON(t))
 	})
+
+	t.Run("Marshal signed JWT with headers", func(t *testing.T) {
+		jws, err := jwtClaims.MarshalJWSWithHeaders(RS256, signer, "any",
+			map[string]interface{}{"typ": VCJWTType, "cty": "vc"})
+		require.NoError(t, err)
+
+		headers, err := parseJWSHeaders(jws)
+		require.NoError(t, err)
+
+		typ, _ := headers.Type()
+		require.Equal(t, VCJWTType, typ)
+
+		cty, _ := headers.ContentType()
+		require.Equal(t, "vc", cty)
+
+		kid, _ := headers.KeyID()
+		require.Equal(t, "any", kid)
+
+		vcBytes, err := decodeCredJWS(jws, true, func(issuerID, keyID string) (*verifier.PublicKey, error) {
+			return &verifier.PublicKey{
+				Type:  kms.RSARS256,
+				Value: signer.PublicKeyBytes(),
+			}, nil
+		}, JWTClaimsPreferJWT)
+		require.NoError(t, err)
+
+		vcRaw := new(rawCredential)
+		require.NoError(t, json.Unmarshal(vcBytes, &vcRaw))
+		require.Equal(t, vc.stringJSON(t), vcRaw.stringJSON(t))
+	})
+
+	t.Run("Marshal signed JWT with alg or kid header", func(t *testing.T) {
+		_, err := jwtClaims.MarshalJWSWithHeaders(RS256, signer, "any", map[string]interface{}{"alg": "none"})
+		require.EqualError(t, err, "alg header can't be overridden")
+
+		_, err = jwtClaims.MarshalJWSWithHeaders(RS256, signer, "any", map[string]interface{}{"kid": "other"})
+		require.EqualError(t, err, "kid header can't be overridden")
+	})
+
+	t.Run("Marshal signed JWT with canonical claims", func(t *testing.T) {
+		newClaims := func(referenceNumber interface{}) *JWTCredClaims {
+			vc, err := parseTestCredential(t, []byte(validCredential))
+			require.NoError(t, err)
+
+			vc.CustomFields = CustomFields{"referenceNumber": referenceNumber, "note": "<b>Tom & Jerry</b>"}
+
+			claims, err := vc.JWTClaims(true)
+			require.NoError(t, err)
+
+			return claims
+		}
+
+		jws1, err := newClaims(83294847.0).MarshalJWS(RS256, signer, "any")
+		require.NoError(t, err)
+
+		jws2, err := newClaims(json.Number("83294847")).MarshalJWS(RS256, signer, "any")
+		require.NoError(t, err)
+
+		require.Equal(t, jws1, jws2)
+
+		payload, err := base64.RawURLEncoding.DecodeString(strings.Split(jws1, ".")[1])
+		require.NoError(t, err)
+		require.Contains(t, string(payload), `"note":"<b>Tom & Jerry</b>","referenceNumber":83294847`)
+
+		canonical, err := marshalCanonicalJSON(newClaims(83294847))
+		require.NoError(t, err)
+		require.Equal(t, canonical, payload)
+	})
 }
 
 type invalidCredClaims struct {
@@ -70,7 +140,7 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 	validJWS := createRS256JWS(t, []byte(jwtTestCredential), signer, false)
 
 	t.Run("Successful JWS decoding", func(t *testing.T) {
-		vcBytes, err := decodeCredJWS(string(validJWS), true, pkFetcher)
+		vcBytes, err := decodeCredJWS(string(validJWS), true, pkFetcher, JWTClaimsPreferJWT)
 		require.NoError(t, err)
 
 		vcRaw := new(rawCredential)
@@ -83,7 +153,7 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 	})
 
 	t.Run("Invalid serialized JWS", func(t *testing.T) {
-		jws, err := decodeCredJWS("invalid JWS", true, pkFetcher)
+		jws, err := decodeCredJWS("invalid JWS", true, pkFetcher, JWTClaimsPreferJWT)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unmarshal VC JWT claims")
 		require.Nil(t, jws)
@@ -106,7 +176,7 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 		jwtCompact, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
 		require.NoError(t, err)
 
-		jws, err := decodeCredJWS(jwtCompact, true, pkFetcher)
+		jws, err := decodeCredJWS(jwtCompact, true, pkFetcher, JWTClaimsPreferJWT)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unmarshal VC JWT claims")
 		require.Nil(t, jws)
@@ -124,7 +194,7 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 			}, nil
 		}
 
-		jws, err := decodeCredJWS(string(validJWS), true, pkFetcherOther)
+		jws, err := decodeCredJWS(string(validJWS), true, pkFetcherOther, JWTClaimsPreferJWT)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unmarshal VC JWT claims")
 		require.Nil(t, jws)
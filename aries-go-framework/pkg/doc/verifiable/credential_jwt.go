@@ -13,6 +13,7 @@ import (
 
 	josejwt "github.com/square/go-jose/v3/jwt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 )
 
@@ -24,11 +25,45 @@ const (
 	vcIssuerIDField       = "id"
 )
 
+// JWTClaimsConflictPolicy defines how the conflicts of the registered JWT claims (iss, jti, nbf/iat, exp)
+// and the corresponding fields of "vc" claim (issuer, id, issuanceDate, expirationDate) are resolved
+// when the credential in JWT form is parsed (see WithJWTClaimsConflictPolicy).
+type JWTClaimsConflictPolicy int
+
+const (
+	// JWTClaimsPreferJWT makes the registered JWT claims override the fields of "vc" claim (default).
+	JWTClaimsPreferJWT JWTClaimsConflictPolicy = iota
+
+	// JWTClaimsPreferVC keeps the fields of "vc" claim, the registered JWT claims define the missing ones only.
+	// The issuer is the exception: the JWS is verified by the key of "iss" claim, so the credential is rejected
+	// with ErrJWTClaimsConflict if the issuer of "vc" claim differs.
+	JWTClaimsPreferVC
+
+	// JWTClaimsStrict rejects the credential with ErrJWTClaimsConflict if any registered JWT claim
+	// does not match the field of "vc" claim. The dates are compared with a precision of seconds.
+	JWTClaimsStrict
+)
+
+// ErrJWTClaimsConflict is returned when the registered JWT claim does not match the field of "vc" claim
+// and JWTClaimsStrict policy is applied.
+var ErrJWTClaimsConflict = errors.New("JWT claim conflicts with vc claim")
+
 // JWTCredClaims is JWT Claims extension by Verifiable Credential (with custom "vc" claim).
 type JWTCredClaims struct {
 	*jwt.Claims
 
 	VC map[string]interface{} `json:"vc,omitempty"`
+
+	// Confirmation is the "cnf" claim binding the credential to the key of the holder.
+	Confirmation *HolderConfirmation `json:"cnf,omitempty"`
+}
+
+// HolderConfirmation is the "cnf" (confirmation) claim of JWT VC (RFC 7800). It defines the key of the holder
+// which the credential is bound to, either as a public key in JWK form or as an ID of the key
+// (e.g. DID URL of the verification method).
+type HolderConfirmation struct {
+	JWK *jwk.JWK `json:"jwk,omitempty"`
+	KID string   `json:"kid,omitempty"`
 }
 
 // newJWTCredClaims creates JWT Claims of VC with an option to minimize certain fields of VC
@@ -75,8 +110,9 @@ func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 	}
 
 	credClaims := &JWTCredClaims{
-		Claims: jwtClaims,
-		VC:     vcMap,
+		Claims:       jwtClaims,
+		VC:           vcMap,
+		Confirmation: vc.Confirmation,
 	}
 
 	return credClaims, nil
@@ -87,14 +123,18 @@ type JWTCredClaimsUnmarshaller func(vcJWTBytes string) (*JWTCredClaims, error)
 
 // decodeCredJWT parses JWT from the specified bytes array in compact format using unmarshaller.
 // It returns decoded Verifiable Credential refined by JWT Claims in raw byte array form.
-func decodeCredJWT(rawJWT string, unmarshaller JWTCredClaimsUnmarshaller) ([]byte, error) {
+// The conflicts of JWT claims and "vc" claim are resolved according to the policy.
+func decodeCredJWT(rawJWT string, unmarshaller JWTCredClaimsUnmarshaller,
+	policy JWTClaimsConflictPolicy) ([]byte, error) {
 	credClaims, err := unmarshaller(rawJWT)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal VC JWT claims: %w", err)
 	}
 
 	// Apply VC-related claims from JWT.
-	credClaims.refineFromJWTClaims()
+	if err = credClaims.refineFromJWTClaimsWithPolicy(policy); err != nil {
+		return nil, err
+	}
 
 	vcData, err := json.Marshal(credClaims.VC)
 	if err != nil {
@@ -104,6 +144,20 @@ func decodeCredJWT(rawJWT string, unmarshaller JWTCredClaimsUnmarshaller) ([]byt
 	return vcData, nil
 }
 
+// peekCredConfirmation returns "cnf" claim of the credential in JWT form without checking the JWT signature
+// (it's checked when the credential is decoded).
+func peekCredConfirmation(vcJWT string) (*HolderConfirmation, error) {
+	var claims struct {
+		Confirmation *HolderConfirmation `json:"cnf,omitempty"`
+	}
+
+	if err := unmarshalJWS(vcJWT, false, nil, &claims); err != nil {
+		return nil, fmt.Errorf("decode cnf claim: %w", err)
+	}
+
+	return claims.Confirmation, nil
+}
+
 func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	vcMap := jcc.VC
 	claims := jcc.Claims
@@ -132,6 +186,141 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	}
 }
 
+// jwtClaimField is a registered JWT claim and the corresponding field of "vc" claim.
+type jwtClaimField struct {
+	claim    string
+	field    string
+	jwtValue string
+	vcValue  string
+	isDate   bool
+}
+
+// claimFields returns the registered JWT claims which are defined together with the corresponding fields.
+func (jcc *JWTCredClaims) claimFields() []jwtClaimField {
+	claims := jcc.Claims
+
+	fields := []jwtClaimField{
+		{claim: "iss", field: vcIssuerField, jwtValue: claims.Issuer, vcValue: vcIssuerID(jcc.VC)},
+		{claim: "jti", field: vcIDField, jwtValue: claims.ID, vcValue: safeStringValue(jcc.VC[vcIDField])},
+	}
+
+	// both "nbf" and "iat" claims define issuanceDate
+	for _, issuance := range []struct {
+		claim string
+		date  *josejwt.NumericDate
+	}{{"nbf", claims.NotBefore}, {"iat", claims.IssuedAt}} {
+		if issuance.date == nil {
+			continue
+		}
+
+		fields = append(fields, jwtClaimField{
+			claim:    issuance.claim,
+			field:    vcIssuanceDateField,
+			jwtValue: issuance.date.Time().UTC().Format(time.RFC3339),
+			vcValue:  safeStringValue(jcc.VC[vcIssuanceDateField]),
+			isDate:   true,
+		})
+	}
+
+	if claims.Expiry != nil {
+		fields = append(fields, jwtClaimField{
+			claim:    "exp",
+			field:    vcExpirationDateField,
+			jwtValue: claims.Expiry.Time().UTC().Format(time.RFC3339),
+			vcValue:  safeStringValue(jcc.VC[vcExpirationDateField]),
+			isDate:   true,
+		})
+	}
+
+	defined := fields[:0]
+
+	for _, f := range fields {
+		if f.jwtValue != "" && f.vcValue != "" {
+			defined = append(defined, f)
+		}
+	}
+
+	return defined
+}
+
+// conflicts checks if the values of JWT claim and "vc" field differ.
+func (f *jwtClaimField) conflicts() bool {
+	if !f.isDate {
+		return f.jwtValue != f.vcValue
+	}
+
+	jwtTime, err := time.Parse(time.RFC3339, f.jwtValue)
+	if err != nil {
+		return true
+	}
+
+	vcTime, err := time.Parse(time.RFC3339Nano, f.vcValue)
+	if err != nil {
+		return true
+	}
+
+	return !jwtTime.Equal(vcTime.Truncate(time.Second))
+}
+
+func (f *jwtClaimField) conflictError() error {
+	return fmt.Errorf("%w: %s claim %s does not match %s %s", ErrJWTClaimsConflict, f.claim, f.jwtValue, f.field,
+		f.vcValue)
+}
+
+func (jcc *JWTCredClaims) refineFromJWTClaimsWithPolicy(policy JWTClaimsConflictPolicy) error {
+	switch policy {
+	case JWTClaimsPreferJWT:
+		jcc.refineFromJWTClaims()
+
+	case JWTClaimsPreferVC:
+		fields := jcc.claimFields()
+
+		for i := range fields {
+			// the issuer of "vc" claim can't override "iss" claim the JWS is verified against
+			if fields[i].field == vcIssuerField && fields[i].conflicts() {
+				return fields[i].conflictError()
+			}
+		}
+
+		jcc.refineFromJWTClaims()
+
+		// restore the fields defined by "vc" claim
+		for _, f := range fields {
+			if f.field != vcIssuerField {
+				jcc.VC[f.field] = f.vcValue
+			}
+		}
+
+	case JWTClaimsStrict:
+		fields := jcc.claimFields()
+
+		for i := range fields {
+			if fields[i].conflicts() {
+				return fields[i].conflictError()
+			}
+		}
+
+		jcc.refineFromJWTClaims()
+
+	default:
+		return fmt.Errorf("unsupported JWT claims conflict policy: %d", policy)
+	}
+
+	return nil
+}
+
+// vcIssuerID returns id of the issuer defined by "vc" claim either as a string or as a struct.
+func vcIssuerID(vcMap map[string]interface{}) string {
+	switch issuer := vcMap[vcIssuerField].(type) {
+	case string:
+		return issuer
+	case map[string]interface{}:
+		return safeStringValue(issuer[vcIssuerIDField])
+	default:
+		return ""
+	}
+}
+
 func refineVCIssuerFromJWTClaims(vcMap map[string]interface{}, iss string) {
 	// Issuer of Verifiable Credential could be either string (id) or struct (with "id" field).
 	if _, exists := vcMap[vcIssuerField]; !exists {
@@ -0,0 +1,131 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+const (
+	// JWTProofType is a type of the proof which records the original JWT of the credential
+	// converted into JSON-LD form (see Credential.MarshalJSONLDFromJWT).
+	JWTProofType = "JwtProof2020"
+
+	jwtProofJWTField = "jwt"
+)
+
+// MarshalJSONLDFromJWT converts the credential parsed from JWT (JWS or unsecured JWT) into JSON-LD form.
+// The credential data is the "vc" claim refined by the registered JWT claims (iss, sub, jti, nbf, exp)
+// the same way as by ParseCredential, its proofs are replaced by a single proof of JWTProofType recording
+// the original JWT as is, e.g.
+//
+//	"proof": {"type": "JwtProof2020", "jwt": "eyJhbGciOiJFZERTQSIs..."}
+//
+// The conversion is lossless: the data model fields are represented in JSON-LD, while the claims which
+// are not a part of the data model (e.g. aud, cnf, custom claims and JOSE headers) and the JWS signature
+// are preserved in the recorded JWT only. The JWT is not re-signed, so the original one can be re-transmitted
+// (see MarshalJWTFromJSONLD). The JSON-LD form is not a linked data proof, i.e. to verify the credential
+// parse the recorded JWT.
+//
+// The credential which was not parsed from JWT (or from JSON-LD form created by this method) is rejected,
+// as its conversion would require signing (see JWTClaims).
+func (vc *Credential) MarshalJSONLDFromJWT() ([]byte, error) {
+	vcJWT, err := vc.recordedJWT()
+	if err != nil {
+		return nil, fmt.Errorf("marshal JSON-LD from JWT: %w", err)
+	}
+
+	vcCopy := *vc
+	vcCopy.Proofs = []Proof{{"type": JWTProofType, jwtProofJWTField: vcJWT}}
+
+	return vcCopy.MarshalJSON()
+}
+
+// MarshalJWTFromJSONLD returns the original JWT of the credential converted into JSON-LD form by
+// MarshalJSONLDFromJWT (or parsed from JWT). The JWT is returned exactly as it was recorded, so it's lossless.
+// An error is returned if the credential data differs from the recorded JWT (e.g. the JSON-LD credential
+// has been modified after the conversion), as the changes would be lost. The JWT signature is not checked.
+func (vc *Credential) MarshalJWTFromJSONLD() (string, error) {
+	vcJWT, err := vc.recordedJWT()
+	if err != nil {
+		return "", fmt.Errorf("marshal JWT from JSON-LD: %w", err)
+	}
+
+	jwtVC, err := ParseCredential([]byte(vcJWT), WithDisabledProofCheck(), WithCredentialNoValidation())
+	if err != nil {
+		return "", fmt.Errorf("marshal JWT from JSON-LD: parse recorded JWT: %w", err)
+	}
+
+	same, err := sameCredentialData(vc, jwtVC)
+	if err != nil {
+		return "", fmt.Errorf("marshal JWT from JSON-LD: %w", err)
+	}
+
+	if !same {
+		return "", errors.New("marshal JWT from JSON-LD: credential differs from the recorded JWT")
+	}
+
+	return vcJWT, nil
+}
+
+// JWTString returns the original JWT (compact JWS or unsecured JWT) the credential is parsed from, e.g. to forward
+// it as is, and whether the credential originated from JWT.
+func (vc *Credential) JWTString() (string, bool) {
+	return vc.jwt, vc.jwt != ""
+}
+
+// recordedJWT returns the JWT the credential is parsed from or the one recorded in the proof of JWTProofType.
+func (vc *Credential) recordedJWT() (string, error) {
+	if vcJWT, ok := vc.JWTString(); ok {
+		return vcJWT, nil
+	}
+
+	for _, p := range vc.Proofs {
+		if p["type"] != JWTProofType {
+			continue
+		}
+
+		if vcJWT, ok := p[jwtProofJWTField].(string); ok && vcJWT != "" {
+			return vcJWT, nil
+		}
+	}
+
+	return "", errors.New("credential is not parsed from JWT and has no JWT proof")
+}
+
+// sameCredentialData compares JSON form of the credentials ignoring the proofs.
+func sameCredentialData(vc, other *Credential) (bool, error) {
+	vcData, err := credentialDataWithoutProofs(vc)
+	if err != nil {
+		return false, err
+	}
+
+	otherData, err := credentialDataWithoutProofs(other)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(vcData, otherData), nil
+}
+
+func credentialDataWithoutProofs(vc *Credential) (interface{}, error) {
+	vcCopy := *vc
+	vcCopy.Proofs = nil
+
+	vcBytes, err := vcCopy.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+
+	err = json.Unmarshal(vcBytes, &data)
+
+	return data, err
+}
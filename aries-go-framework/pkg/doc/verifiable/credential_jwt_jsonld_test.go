@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCredential_MarshalJSONLDFromJWT(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vc.Issuer.ID+"#keys-1")
+	require.NoError(t, err)
+
+	jwtVC, err := parseTestCredential(t, []byte(vcJWS),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.NoError(t, err)
+
+	t.Run("JWT to JSON-LD and back", func(t *testing.T) {
+		vcJSONLD, err := jwtVC.MarshalJSONLDFromJWT()
+		require.NoError(t, err)
+
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(vcJSONLD, &vcMap))
+		require.Equal(t, map[string]interface{}{"type": JWTProofType, "jwt": vcJWS}, vcMap["proof"])
+		require.Equal(t, vc.ID, vcMap["id"])
+
+		storedVC, err := parseTestCredential(t, vcJSONLD, WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		restoredJWS, err := storedVC.MarshalJWTFromJSONLD()
+		require.NoError(t, err)
+		require.Equal(t, vcJWS, restoredJWS)
+
+		// the stored credential can be converted again
+		vcJSONLD2, err := storedVC.MarshalJSONLDFromJWT()
+		require.NoError(t, err)
+		require.JSONEq(t, string(vcJSONLD), string(vcJSONLD2))
+
+		restoredJWS, err = jwtVC.MarshalJWTFromJSONLD()
+		require.NoError(t, err)
+		require.Equal(t, vcJWS, restoredJWS)
+	})
+
+	t.Run("unsecured JWT", func(t *testing.T) {
+		vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		unsecuredVC, err := parseTestCredential(t, []byte(vcJWT), WithAllowUnsecuredJWT())
+		require.NoError(t, err)
+
+		vcJSONLD, err := unsecuredVC.MarshalJSONLDFromJWT()
+		require.NoError(t, err)
+
+		storedVC, err := parseTestCredential(t, vcJSONLD, WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		restoredJWT, err := storedVC.MarshalJWTFromJSONLD()
+		require.NoError(t, err)
+		require.Equal(t, vcJWT, restoredJWT)
+	})
+
+	t.Run("credential is modified after conversion", func(t *testing.T) {
+		vcJSONLD, err := jwtVC.MarshalJSONLDFromJWT()
+		require.NoError(t, err)
+
+		storedVC, err := parseTestCredential(t, vcJSONLD, WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		storedVC.ID = "http://example.edu/credentials/other"
+
+		_, err = storedVC.MarshalJWTFromJSONLD()
+		require.EqualError(t, err, "marshal JWT from JSON-LD: credential differs from the recorded JWT")
+	})
+
+	t.Run("credential is not parsed from JWT", func(t *testing.T) {
+		_, err := vc.MarshalJSONLDFromJWT()
+		require.EqualError(t, err, "marshal JSON-LD from JWT: credential is not parsed from JWT and has no JWT proof")
+
+		_, err = vc.MarshalJWTFromJSONLD()
+		require.EqualError(t, err, "marshal JWT from JSON-LD: credential is not parsed from JWT and has no JWT proof")
+	})
+
+	t.Run("invalid recorded JWT", func(t *testing.T) {
+		vcCopy := *vc
+		vcCopy.Proofs = []Proof{{"type": JWTProofType, "jwt": "invalid JWT"}}
+
+		_, err := vcCopy.MarshalJWTFromJSONLD()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "marshal JWT from JSON-LD: parse recorded JWT")
+	})
+}
+
+func TestCredential_JWTString(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vcJWT, ok := vc.JWTString()
+	require.False(t, ok)
+	require.Empty(t, vcJWT)
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vc.Issuer.ID+"#keys-1")
+	require.NoError(t, err)
+
+	jwsVC, err := parseTestCredential(t, []byte(vcJWS),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.NoError(t, err)
+
+	vcJWT, ok = jwsVC.JWTString()
+	require.True(t, ok)
+	require.Equal(t, vcJWS, vcJWT)
+
+	unsecuredJWT, err := jwtClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	unsecuredVC, err := parseTestCredential(t, []byte(unsecuredJWT), WithAllowUnsecuredJWT())
+	require.NoError(t, err)
+
+	vcJWT, ok = unsecuredVC.JWTString()
+	require.True(t, ok)
+	require.Equal(t, unsecuredJWT, vcJWT)
+}
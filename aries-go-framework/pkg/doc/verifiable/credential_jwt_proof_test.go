@@ -9,6 +9,7 @@ import (
 	"crypto/ed25519"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -66,7 +67,7 @@ func TestParseCredentialFromJWS(t *testing.T) {
 		vc, err := parseTestCredential(t, testCred)
 		require.NoError(t, err)
 
-		require.Equal(t, vc, vcFromJWT)
+		require.Equal(t, vc, withoutJWT(vcFromJWT))
 	})
 
 	t.Run("Decoding credential from JWS with minimized fields of \"vc\" claim", func(t *testing.T) {
@@ -79,7 +80,54 @@ func TestParseCredentialFromJWS(t *testing.T) {
 		vc, err := parseTestCredential(t, testCred)
 		require.NoError(t, err)
 
-		require.Equal(t, vc, vcFromJWT)
+		require.Equal(t, vc, withoutJWT(vcFromJWT))
+	})
+
+	t.Run("Decoding credential from JWS without JSON-LD document loader", func(t *testing.T) {
+		vcFromJWT, err := ParseCredential(
+			createEdDSAJWS(t, testCred, ed25519Signer, false),
+			WithPublicKeyFetcher(ed25519KeyFetcher))
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, testCred)
+		require.NoError(t, err)
+
+		require.Equal(t, vc, withoutJWT(vcFromJWT))
+	})
+
+	t.Run("Decoding credential from JWS with explicit JSON-LD validation", func(t *testing.T) {
+		// "university" is not defined in the JSON-LD context
+		definedCred := strings.Replace(jwtTestCredential, `,
+		"university": "MIT"`, "", 1)
+
+		vcFromJWT, err := parseTestCredential(t,
+			createEdDSAJWS(t, []byte(definedCred), ed25519Signer, false),
+			WithPublicKeyFetcher(ed25519KeyFetcher),
+			WithJSONLDValidation(),
+			WithStrictValidation())
+		require.NoError(t, err)
+		require.NotNil(t, vcFromJWT)
+
+		vcFromJWT, err = parseTestCredential(t,
+			createEdDSAJWS(t, []byte(strings.Replace(definedCred,
+				`"type": "BachelorDegree"`, `"type": "BachelorDegree", "undefinedTerm": "value"`, 1)), ed25519Signer, false),
+			WithPublicKeyFetcher(ed25519KeyFetcher),
+			WithJSONLDValidation(),
+			WithStrictValidation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JSON-LD doc has different structure after compaction")
+		require.Nil(t, vcFromJWT)
+	})
+
+	t.Run("Decoding credential from JWS with strict validation", func(t *testing.T) {
+		vcFromJWT, err := parseTestCredential(t,
+			createEdDSAJWS(t, []byte(strings.Replace(jwtTestCredential,
+				`"type": "BachelorDegree"`, `"type": "BachelorDegree", "undefinedTerm": "value"`, 1)), ed25519Signer, false),
+			WithPublicKeyFetcher(ed25519KeyFetcher),
+			WithStrictValidation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JSON-LD doc has different structure after compaction")
+		require.Nil(t, vcFromJWT)
 	})
 
 	t.Run("Failed JWT signature verification of credential", func(t *testing.T) {
@@ -140,32 +188,42 @@ func TestParseCredentialFromJWS_EdDSA(t *testing.T) {
 	require.NoError(t, err)
 
 	// unmarshalled credential must be the same as original one
-	require.Equal(t, vc, vcFromJWS)
+	require.Equal(t, vc, withoutJWT(vcFromJWS))
 }
 
 func TestParseCredentialFromUnsecuredJWT(t *testing.T) {
 	testCred := []byte(jwtTestCredential)
 
 	t.Run("Unsecured JWT decoding with no fields minimization", func(t *testing.T) {
-		vcFromJWT, err := parseTestCredential(t, createUnsecuredJWT(t, testCred, false))
+		vcFromJWT, err := parseTestCredential(t, createUnsecuredJWT(t, testCred, false), WithAllowUnsecuredJWT())
 
 		require.NoError(t, err)
 
 		vc, err := parseTestCredential(t, testCred)
 		require.NoError(t, err)
 
-		require.Equal(t, vc, vcFromJWT)
+		require.Equal(t, vc, withoutJWT(vcFromJWT))
 	})
 
 	t.Run("Unsecured JWT decoding with minimized fields", func(t *testing.T) {
-		vcFromJWT, err := parseTestCredential(t, createUnsecuredJWT(t, testCred, true))
+		vcFromJWT, err := parseTestCredential(t, createUnsecuredJWT(t, testCred, true), WithAllowUnsecuredJWT())
 
 		require.NoError(t, err)
 
 		vc, err := parseTestCredential(t, testCred)
 		require.NoError(t, err)
 
-		require.Equal(t, vc, vcFromJWT)
+		require.Equal(t, vc, withoutJWT(vcFromJWT))
+	})
+
+	t.Run("Unsecured JWT is not allowed", func(t *testing.T) {
+		_, err := parseTestCredential(t, createUnsecuredJWT(t, testCred, false))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrUnsecuredJWT))
+
+		// no proof is checked at all
+		_, err = parseTestCredential(t, createUnsecuredJWT(t, testCred, false), WithDisabledProofCheck())
+		require.NoError(t, err)
 	})
 }
 
@@ -14,12 +14,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
 func TestDecodeJWT(t *testing.T) {
 	vcBytes, err := decodeCredJWT("", func(string) (*JWTCredClaims, error) {
 		return nil, errors.New("cannot parse JWT claims")
-	})
+	}, JWTClaimsPreferJWT)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "cannot parse JWT claims")
 	require.Nil(t, vcBytes)
@@ -53,3 +55,116 @@ func TestRefineVcFromJwtClaims(t *testing.T) {
 	require.Equal(t, "2019-08-10T00:00:00Z", vcMap["issuanceDate"])
 	require.Equal(t, "2029-08-10T00:00:00Z", vcMap["expirationDate"])
 }
+
+func TestWithJWTClaimsConflictPolicy(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vcExpired := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	jwtExpired := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	vc.Expired = util.NewTime(vcExpired)
+
+	newJWS := func(t *testing.T, modify func(claims *JWTCredClaims)) string {
+		t.Helper()
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		modify(jwtClaims)
+
+		vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#keys-1")
+		require.NoError(t, err)
+
+		return vcJWS
+	}
+
+	// "exp" claim says the credential has expired, but expirationDate of "vc" claim does not
+	conflictingExpiry := newJWS(t, func(claims *JWTCredClaims) {
+		claims.Expiry = josejwt.NewNumericDate(jwtExpired)
+	})
+
+	parse := func(vcJWS string, opts ...CredentialOpt) (*Credential, error) {
+		return parseTestCredential(t, []byte(vcJWS), append([]CredentialOpt{
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		}, opts...)...)
+	}
+
+	t.Run("JWT claims are preferred by default", func(t *testing.T) {
+		for _, opts := range [][]CredentialOpt{nil, {WithJWTClaimsConflictPolicy(JWTClaimsPreferJWT)}} {
+			vcParsed, err := parse(conflictingExpiry, opts...)
+			require.NoError(t, err)
+			require.Equal(t, jwtExpired, vcParsed.Expired.Time)
+		}
+	})
+
+	t.Run("vc claim is preferred", func(t *testing.T) {
+		vcParsed, err := parse(conflictingExpiry, WithJWTClaimsConflictPolicy(JWTClaimsPreferVC))
+		require.NoError(t, err)
+		require.Equal(t, vcExpired, vcParsed.Expired.Time)
+
+		vcParsed, err = parse(newJWS(t, func(claims *JWTCredClaims) {
+			claims.ID = "http://example.edu/credentials/other"
+		}), WithJWTClaimsConflictPolicy(JWTClaimsPreferVC))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcParsed.ID)
+
+		// the issuer of vc claim can't override iss claim the JWS is verified against
+		_, err = parse(newJWS(t, func(claims *JWTCredClaims) {
+			claims.Issuer = "did:example:other"
+		}), WithJWTClaimsConflictPolicy(JWTClaimsPreferVC))
+		require.True(t, errors.Is(err, ErrJWTClaimsConflict))
+		require.Contains(t, err.Error(), "iss claim did:example:other does not match issuer "+vc.Issuer.ID)
+
+		// JWT claims define the fields missing in vc claim
+		vcParsed, err = parse(newJWS(t, func(claims *JWTCredClaims) {
+			delete(claims.VC, "expirationDate")
+		}), WithJWTClaimsConflictPolicy(JWTClaimsPreferVC))
+		require.NoError(t, err)
+		require.Equal(t, vcExpired, vcParsed.Expired.Time)
+	})
+
+	t.Run("conflicts are rejected", func(t *testing.T) {
+		strict := WithJWTClaimsConflictPolicy(JWTClaimsStrict)
+
+		_, err := parse(conflictingExpiry, strict)
+		require.True(t, errors.Is(err, ErrJWTClaimsConflict))
+		require.EqualError(t, err, "decode new credential: JWS decoding: JWT claim conflicts with vc claim: "+
+			"exp claim 2020-01-01T00:00:00Z does not match expirationDate 2030-01-01T00:00:00Z")
+
+		_, err = parse(newJWS(t, func(claims *JWTCredClaims) {
+			claims.Issuer = "did:example:other"
+		}), strict)
+		require.True(t, errors.Is(err, ErrJWTClaimsConflict))
+		require.Contains(t, err.Error(), "iss claim did:example:other does not match issuer")
+
+		_, err = parse(newJWS(t, func(claims *JWTCredClaims) {
+			claims.IssuedAt = josejwt.NewNumericDate(jwtExpired)
+		}), strict)
+		require.True(t, errors.Is(err, ErrJWTClaimsConflict))
+		require.Contains(t, err.Error(), "iat claim 2020-01-01T00:00:00Z does not match issuanceDate")
+
+		_, err = parse(newJWS(t, func(claims *JWTCredClaims) {
+			claims.NotBefore = josejwt.NewNumericDate(jwtExpired)
+		}), strict)
+		require.True(t, errors.Is(err, ErrJWTClaimsConflict))
+		require.Contains(t, err.Error(), "nbf claim 2020-01-01T00:00:00Z does not match issuanceDate")
+
+		// the dates are compared with a precision of seconds
+		vc.Expired = util.NewTime(vcExpired.Add(500 * time.Millisecond))
+		defer func() { vc.Expired = util.NewTime(vcExpired) }()
+
+		vcParsed, err := parse(newJWS(t, func(*JWTCredClaims) {}), strict)
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcParsed.ID)
+	})
+
+	t.Run("unsupported policy", func(t *testing.T) {
+		_, err := parse(conflictingExpiry, WithJWTClaimsConflictPolicy(JWTClaimsConflictPolicy(-1)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported JWT claims conflict policy: -1")
+	})
+}
@@ -25,6 +25,6 @@ func unmarshalUnsecuredJWTClaims(rawJWT string) (*JWTCredClaims, error) {
 	return &claims, nil
 }
 
-func decodeCredJWTUnsecured(rawJwt string) ([]byte, error) {
-	return decodeCredJWT(rawJwt, unmarshalUnsecuredJWTClaims)
+func decodeCredJWTUnsecured(rawJwt string, policy JWTClaimsConflictPolicy) ([]byte, error) {
+	return decodeCredJWT(rawJwt, unmarshalUnsecuredJWTClaims, policy)
 }
@@ -26,7 +26,7 @@ func TestCredentialJWTClaimsMarshallingToUnsecuredJWT(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, sJWT)
 
-	vcBytes, err := decodeCredJWTUnsecured(sJWT)
+	vcBytes, err := decodeCredJWTUnsecured(sJWT, JWTClaimsPreferJWT)
 	require.NoError(t, err)
 
 	vcRaw := new(rawCredential)
@@ -48,13 +48,13 @@ func TestCredUnsecuredJWTDecoderParseJWTClaims(t *testing.T) {
 		sJWT, err := jwtClaims.MarshalUnsecuredJWT()
 		require.NoError(t, err)
 
-		decodedCred, err := decodeCredJWTUnsecured(sJWT)
+		decodedCred, err := decodeCredJWTUnsecured(sJWT, JWTClaimsPreferJWT)
 		require.NoError(t, err)
 		require.NotNil(t, decodedCred)
 	})
 
 	t.Run("Invalid serialized unsecured JWT", func(t *testing.T) {
-		vcBytes, err := decodeCredJWTUnsecured("invalid JWS")
+		vcBytes, err := decodeCredJWTUnsecured("invalid JWS", JWTClaimsPreferJWT)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "parse VC in JWT Unsecured form")
 		require.Nil(t, vcBytes)
@@ -69,7 +69,7 @@ func TestCredUnsecuredJWTDecoderParseJWTClaims(t *testing.T) {
 		rawJWT, err := marshalUnsecuredJWT(jose.Headers{}, claims)
 		require.NoError(t, err)
 
-		vcBytes, err := decodeCredJWTUnsecured(rawJWT)
+		vcBytes, err := decodeCredJWTUnsecured(rawJWT, JWTClaimsPreferJWT)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "unmarshal VC JWT claims")
 		require.Nil(t, vcBytes)
@@ -12,13 +12,26 @@ import (
 )
 
 // AddLinkedDataProof appends proof to the Verifiable Credential.
+// ErrDuplicateProof is returned if the credential already has a proof with the same verification method
+// and purpose, unless LinkedDataProofContext.AllowDuplicateProof is set.
+//
+// Terms expanded by "@vocab" of the context are signed as the vocab IRIs, so the proof breaks if the vocab changes.
+// A blank node vocab (e.g. "_:") produces blank node predicates which are dropped by the RDF canonicalization,
+// i.e. such terms are NOT covered by the proof. Use LinkedDataProofContext.SafeCanonicalization to reject such
+// credentials with ErrUnsafeCanonicalization.
 func (vc *Credential) AddLinkedDataProof(context *LinkedDataProofContext, jsonldOpts ...jsonld.ProcessorOpts) error {
-	vcBytes, err := vc.MarshalJSON()
+	if err := checkDuplicateProof(context, vc.Proofs, defaultProofPurpose); err != nil {
+		return err
+	}
+
+	// The credential is marshalled to JSON and unmarshalled into JSON-LD object; the proof is added to
+	// the object in place, so the signed document isn't marshalled and unmarshalled again to get the proofs.
+	vcDoc, err := toMap(vc)
 	if err != nil {
 		return fmt.Errorf("add linked data proof to VC: %w", err)
 	}
 
-	proofs, err := addLinkedDataProof(context, vcBytes, jsonldOpts...)
+	proofs, err := addLinkedDataProof(context, vcDoc, jsonldOpts...)
 	if err != nil {
 		return err
 	}
@@ -27,3 +40,12 @@ func (vc *Credential) AddLinkedDataProof(context *LinkedDataProofContext, jsonld
 
 	return nil
 }
+
+// WithoutProofs returns a copy of the Verifiable Credential with all the proofs removed, e.g. to sign it anew.
+// The copy is shallow, i.e. the credential values (like subject) are shared with the original credential.
+func (vc *Credential) WithoutProofs() *Credential {
+	vcCopy := *vc
+	vcCopy.Proofs = nil
+
+	return &vcCopy
+}
@@ -13,7 +13,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -25,6 +27,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
 	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
@@ -32,6 +35,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 )
@@ -70,6 +74,95 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2018(t *testing.T) {
 }
 
 //nolint:lll
+func TestParseCredentialFromLinkedDataProof_TypeForms(t *testing.T) {
+	sigSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(sigSigner),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}
+
+	fetcher := SingleKey(sigSigner.PublicKeyBytes(), kms.ED25519)
+
+	tests := []struct {
+		name    string
+		vcType  interface{}
+		rawType interface{}
+	}{
+		{
+			name:    "single type as string",
+			vcType:  "VerifiableCredential",
+			rawType: "VerifiableCredential",
+		},
+		{
+			name:    "single type as array",
+			vcType:  []interface{}{"VerifiableCredential"},
+			rawType: "VerifiableCredential",
+		},
+		{
+			name:    "several types",
+			vcType:  []interface{}{"VerifiableCredential", "UniversityDegreeCredential"},
+			rawType: []interface{}{"VerifiableCredential", "UniversityDegreeCredential"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			r := require.New(t)
+
+			vcMap, err := toMap(validCredential)
+			r.NoError(err)
+
+			vcMap["type"] = tc.vcType
+
+			vcBytes, err := json.Marshal(vcMap)
+			r.NoError(err)
+
+			// the proof is created for the original form of the type
+			signedBytes, err := signer.New(sigSuite).Sign(mapContext(ldpContext), vcBytes,
+				jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+			r.NoError(err)
+
+			vc, err := parseTestCredential(t, signedBytes,
+				WithEmbeddedSignatureSuites(sigSuite), WithPublicKeyFetcher(fetcher))
+			r.NoError(err)
+
+			// the proof is still valid after the type is marshalled in the canonical form
+			vcBytes, err = json.Marshal(vc)
+			r.NoError(err)
+
+			vcMap, err = toMap(vcBytes)
+			r.NoError(err)
+			r.Equal(tc.rawType, vcMap["type"])
+
+			vcWithLdp, err := parseTestCredential(t, vcBytes,
+				WithEmbeddedSignatureSuites(sigSuite), WithPublicKeyFetcher(fetcher))
+			r.NoError(err)
+			r.Equal(vc, vcWithLdp)
+
+			vp, err := NewPresentation(WithCredentials(vc))
+			r.NoError(err)
+
+			vpBytes, err := json.Marshal(vp)
+			r.NoError(err)
+
+			_, err = newTestPresentation(t, vpBytes,
+				WithPresEmbeddedSignatureSuites(sigSuite), WithPresPublicKeyFetcher(fetcher),
+				WithPresVerifyAllEmbedded(0))
+			r.NoError(err)
+		})
+	}
+}
+
 func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
 	r := require.New(t)
 
@@ -450,6 +543,105 @@ func TestExtraContextWithLDP(t *testing.T) {
 	r.NotNil(vcWithLdp)
 }
 
+func TestVocabContextWithLDP(t *testing.T) {
+	vcJSONTemplate := `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    {"@vocab": "%s"}
+  ],
+  "type": ["VerifiableCredential", "ExampleCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "favoriteColor": "blue"
+  }
+}
+`
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}
+
+	signAndTamper := func(t *testing.T, vocab string) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(fmt.Sprintf(vcJSONTemplate, vocab)), WithStrictValidation())
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithStrictValidation())
+		require.NoError(t, err)
+
+		return []byte(strings.Replace(string(vcBytes), `"blue"`, `"red"`, 1))
+	}
+
+	t.Run("vocab IRI terms are covered by proof", func(t *testing.T) {
+		tampered := signAndTamper(t, "https://example.com/vocab#")
+
+		_, err := parseTestCredential(t, tampered,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid signature")
+	})
+
+	t.Run("blank node vocab terms are not covered by proof", func(t *testing.T) {
+		tampered := signAndTamper(t, "_:")
+
+		_, err := parseTestCredential(t, tampered,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+	})
+
+	t.Run("blank node vocab terms are rejected with safe canonicalization", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(fmt.Sprintf(vcJSONTemplate, "_:")), WithStrictValidation())
+		require.NoError(t, err)
+
+		safeContext := *ldpContext
+		safeContext.SafeCanonicalization = true
+
+		err = vc.AddLinkedDataProof(&safeContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.True(t, errors.Is(err, ErrUnsafeCanonicalization))
+		require.Contains(t, err.Error(), "blank node predicates _:favoriteColor")
+
+		// the proof made without the check is rejected on verification with the check
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithSafeCanonicalizationCheck())
+		require.True(t, errors.Is(err, ErrUnsafeCanonicalization))
+		require.Contains(t, err.Error(), "check embedded proof: "+
+			"terms are dropped by canonicalization and are not signed: blank node predicates _:favoriteColor")
+	})
+}
+
 func TestParseCredentialFromLinkedDataProof_BbsBlsSignature2020(t *testing.T) {
 	r := require.New(t)
 
@@ -986,6 +1178,67 @@ func TestParseCredential_JSONLiteralsNotSupported(t *testing.T) {
 	require.NotNil(t, vc)
 }
 
+func TestParseCredential_ConcurrentSharedOpts(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	// the options are built once and shared by all the goroutines (run with -race)
+	opts := []CredentialOpt{
+		WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithCredentialSchemaLoader(NewCredentialSchemaLoaderBuilder().
+			SetCache(NewExpirableSchemaCache(32*1024*1024, time.Hour)).
+			Build()),
+		WithExpectedCredentialTypes("VerifiableCredential"),
+		WithStrictValidation(),
+	}
+
+	const n = 20
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := ParseCredential(vcBytes, opts...)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		r.NoError(err)
+	}
+}
+
 //nolint:lll
 func TestParseCredential_ProofCreatedWithMillisec(t *testing.T) {
 	vcJSON := `
@@ -1034,6 +1287,78 @@ func TestParseCredential_ProofCreatedWithMillisec(t *testing.T) {
 	require.NotNil(t, vc)
 }
 
+func TestParseCredential_ProofExpires(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	created := time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC)
+	expires := created.Add(time.Hour)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+		Created:                 &created,
+		Expires:                 &expires,
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+	require.Equal(t, "2021-03-01T11:00:00Z", vc.Proofs[0]["expires"])
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	parse := func(t *testing.T, vcBytes []byte, at time.Time) error {
+		t.Helper()
+
+		SetClock(ClockFunc(func() time.Time { return at }))
+		defer SetClock(nil)
+
+		_, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+
+		return err
+	}
+
+	t.Run("proof is not expired", func(t *testing.T) {
+		require.NoError(t, parse(t, vcBytes, created.Add(time.Minute)))
+	})
+
+	t.Run("proof is expired within clock skew", func(t *testing.T) {
+		require.NoError(t, parse(t, vcBytes, expires.Add(time.Minute)))
+	})
+
+	t.Run("proof is expired", func(t *testing.T) {
+		err := parse(t, vcBytes, expires.Add(time.Hour))
+		require.EqualError(t, err,
+			"decode new credential: check embedded proof: proof expired at 2021-03-01T11:00:00Z")
+	})
+
+	t.Run("expiration time is signed", func(t *testing.T) {
+		tampered := strings.Replace(string(vcBytes), "2021-03-01T11:00:00Z", "2021-03-01T12:00:00Z", 1)
+
+		err := parse(t, []byte(tampered), created.Add(time.Minute))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid signature")
+	})
+
+	t.Run("invalid expiration time", func(t *testing.T) {
+		invalid := strings.Replace(string(vcBytes), "2021-03-01T11:00:00Z", "tomorrow", 1)
+
+		err := parse(t, []byte(invalid), created.Add(time.Minute))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof: invalid proof expiration time")
+	})
+}
+
 func TestParseCredentialWithSeveralLinkedDataProofs(t *testing.T) {
 	r := require.New(t)
 
@@ -1101,6 +1426,75 @@ func TestParseCredentialWithSeveralLinkedDataProofs(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialWithVerifyProofByVerificationMethod(t *testing.T) {
+	r := require.New(t)
+
+	trustedSigner, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	untrustedSigner, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(trustedSigner)),
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(untrustedSigner)),
+		VerificationMethod:      "did:example:987654#key2",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	// only the key of the trusted signer is known
+	pubKeyFetcher := func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+		if issuerID == "did:example:123456" && keyID == "#key1" {
+			return &sigverifier.PublicKey{Type: kms.ED25519, Value: trustedSigner.PublicKeyBytes()}, nil
+		}
+
+		return nil, errors.New("untrusted key")
+	}
+
+	t.Run("all proofs are checked by default", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(pubKeyFetcher))
+		r.Error(err)
+		r.Contains(err.Error(), "untrusted key")
+	})
+
+	t.Run("only the proof with the given verification method is checked", func(t *testing.T) {
+		vcWithLdp, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(pubKeyFetcher),
+			WithVerifyProofByVerificationMethod("did:example:123456#key1"))
+		r.NoError(err)
+		r.Len(vcWithLdp.Proofs, 2)
+	})
+
+	t.Run("no proof with the given verification method", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(pubKeyFetcher),
+			WithVerifyProofByVerificationMethod("did:example:123456#key3"))
+		r.Error(err)
+		r.Contains(err.Error(), "no proof with verification method did:example:123456#key3")
+	})
+}
+
 func createLocalCrypto() (*LocalCrypto, error) {
 	lKMS, err := createKMS()
 	if err != nil {
@@ -1288,6 +1682,174 @@ func TestCredential_AddLinkedDataProof(t *testing.T) {
 		r.Len(capabilities, 1)
 		r.Equal(rootCapability, capabilities[0])
 	})
+
+	t.Run("sign and verify proof with capabilityInvocation fields", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:xyz#key-1",
+			Purpose:                 "capabilityInvocation",
+			AdditionalProofFields: map[string]interface{}{
+				"capability":       "https://edv.com/foo/zcap/123",
+				"capabilityAction": "read",
+			},
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+
+		r.Len(vc.Proofs, 1)
+		r.Equal("capabilityInvocation", vc.Proofs[0]["proofPurpose"])
+		r.Equal("https://edv.com/foo/zcap/123", vc.Proofs[0]["capability"])
+		r.Equal("read", vc.Proofs[0]["capabilityAction"])
+
+		raw, err := json.Marshal(vc)
+		r.NoError(err)
+
+		result, err := ParseCredential(raw,
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		)
+		r.NoError(err)
+		r.Len(result.Proofs, 1)
+		r.Equal("read", result.Proofs[0]["capabilityAction"])
+
+		// the additional fields are signed
+		vc.Proofs[0]["capabilityAction"] = "write"
+
+		raw, err = json.Marshal(vc)
+		r.NoError(err)
+
+		_, err = ParseCredential(raw,
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		)
+		r.Error(err)
+		r.Contains(err.Error(), "check embedded proof")
+	})
+
+	t.Run("Add duplicate Linked Data proof to VC", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		ldpContext := &LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:xyz#key-1",
+		}
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+
+		// the same verification method and default purpose
+		ldpContext.Purpose = "assertionMethod"
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.True(errors.Is(err, ErrDuplicateProof))
+		r.Len(vc.Proofs, 1)
+
+		// the same verification method but other purpose
+		ldpContext.Purpose = "authentication"
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 2)
+
+		// the same verification method and purpose but other signature suite
+		jwsContext := &LinkedDataProofContext{
+			SignatureType:           "JsonWebSignature2020",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   jsonwebsignature2020.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:xyz#key-1",
+		}
+
+		err = vc.AddLinkedDataProof(jwsContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.True(errors.Is(err, ErrDuplicateProof))
+		r.Len(vc.Proofs, 2)
+
+		// duplicate is allowed explicitly
+		jwsContext.AllowDuplicateProof = true
+		err = vc.AddLinkedDataProof(jwsContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 3)
+		r.Equal("JsonWebSignature2020", vc.Proofs[2]["type"])
+
+		ldpContext.AllowDuplicateProof = true
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 4)
+	})
+
+	t.Run("Add Linked Data proof with created truncated to seconds", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		created := time.Date(2020, time.March, 10, 4, 24, 12, 164_000_000, time.UTC)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:xyz#key-1",
+			Created:                 &created,
+			ProofTimePrecision:      time.Second,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 1)
+		r.Equal("2020-03-10T04:24:12Z", vc.Proofs[0]["created"])
+
+		vcBytes, err := json.Marshal(vc)
+		r.NoError(err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		r.NoError(err)
+
+		// the current time is used if created is not defined
+		vc, err = parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:xyz#key-1",
+			ProofTimePrecision:      time.Second,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 1)
+
+		createdStr, ok := vc.Proofs[0]["created"].(string)
+		r.True(ok)
+		r.NotContains(createdStr, ".")
+	})
+
+	t.Run("Add Linked Data proof with created defaulted to current UTC second", func(t *testing.T) {
+		at := time.Date(2021, time.June, 1, 12, 30, 45, 987_000_000, time.FixedZone("UTC+3", 3*60*60))
+		SetClock(ClockFunc(func() time.Time { return at }))
+		defer SetClock(nil)
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:xyz#key-1",
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 1)
+		r.Equal("2021-06-01T09:30:45Z", vc.Proofs[0]["created"])
+
+		vcBytes, err := json.Marshal(vc)
+		r.NoError(err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		r.NoError(err)
+	})
 }
 
 type bbsSigner struct {
@@ -1321,3 +1883,214 @@ func (s *bbsSigner) textToLines(txt string) [][]byte {
 
 	return linesBytes
 }
+
+func TestCredential_WithoutProofs(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	ldpContext.Purpose = "authentication"
+
+	err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+	r.Len(vc.Proofs, 2)
+
+	stripped := vc.WithoutProofs()
+	r.Empty(stripped.Proofs)
+	r.Len(vc.Proofs, 2)
+
+	vcBytes, err := json.Marshal(stripped)
+	r.NoError(err)
+	r.NotContains(string(vcBytes), `"proof"`)
+
+	equal, diffs := CredentialsEqual(vc, stripped)
+	r.True(equal)
+	r.Empty(diffs)
+
+	// re-sign the stripped copy
+	ldpContext.Purpose = ""
+
+	err = stripped.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+	r.Len(stripped.Proofs, 1)
+	r.Len(vc.Proofs, 2)
+
+	vcBytes, err = json.Marshal(stripped)
+	r.NoError(err)
+
+	vcWithLdp, err := parseTestCredential(t, vcBytes,
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	r.NoError(err)
+	r.Equal(stripped, vcWithLdp)
+}
+
+func BenchmarkAddLinkedDataProof(b *testing.B) {
+	const credentialsNum = 1000
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(b, err)
+
+	sigSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(b, err)
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(sigSigner)),
+		VerificationMethod:      "did:example:123456#key1",
+	}
+
+	// addProofWithJSONRoundTrip adds the proof the way AddLinkedDataProof did before the proof was added
+	// to the in-memory document: the credential is marshalled to JSON, unmarshalled by the signer, marshalled
+	// again with the new proof and the proofs are unmarshalled back.
+	addProofWithJSONRoundTrip := func(vc *Credential, opts ...jsonldsig.ProcessorOpts) error {
+		if err := checkDuplicateProof(ldpContext, vc.Proofs, defaultProofPurpose); err != nil {
+			return err
+		}
+
+		vcBytes, err := vc.MarshalJSON()
+		if err != nil {
+			return err
+		}
+
+		signedBytes, err := signer.New(ldpContext.Suite).Sign(mapContext(ldpContext), vcBytes, opts...)
+		if err != nil {
+			return err
+		}
+
+		var rProof struct {
+			Proof json.RawMessage `json:"proof,omitempty"`
+		}
+
+		if err = json.Unmarshal(signedBytes, &rProof); err != nil {
+			return err
+		}
+
+		vc.Proofs, err = parseProof(rProof.Proof)
+
+		return err
+	}
+
+	addProofInMemory := func(vc *Credential, opts ...jsonldsig.ProcessorOpts) error {
+		return vc.AddLinkedDataProof(ldpContext, opts...)
+	}
+
+	// Both code paths sign the same credentials using the same document loader.
+	benchmark := func(addProof func(vc *Credential, opts ...jsonldsig.ProcessorOpts) error) func(b *testing.B) {
+		return func(b *testing.B) {
+			newCredentials := func() []*Credential {
+				vcs := make([]*Credential, credentialsNum)
+
+				for i := range vcs {
+					vc, err := ParseCredential([]byte(validCredential), WithCredentialNoValidation())
+					require.NoError(b, err)
+
+					vcs[i] = vc
+				}
+
+				return vcs
+			}
+
+			// the contexts are loaded prior to the measurement
+			require.NoError(b, addProof(newCredentials()[0], jsonldsig.WithDocumentLoader(loader)))
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				vcs := newCredentials()
+				b.StartTimer()
+
+				for _, vc := range vcs {
+					require.NoError(b, addProof(vc, jsonldsig.WithDocumentLoader(loader)))
+				}
+			}
+		}
+	}
+
+	b.Run("in-memory document", benchmark(addProofInMemory))
+	b.Run("JSON round trip", benchmark(addProofWithJSONRoundTrip))
+}
+
+func TestWithMaxProofs(t *testing.T) {
+	sigSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	newVCWithProofs := func(t *testing.T, n int) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		for i := 0; i < n; i++ {
+			err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				SignatureRepresentation: SignatureJWS,
+				Suite:                   ed25519signature2018.New(suite.WithSigner(sigSigner)),
+				VerificationMethod:      fmt.Sprintf("did:example:123456#key%d", i),
+			}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+			require.NoError(t, err)
+		}
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	parse := func(t *testing.T, vcBytes []byte, opts ...CredentialOpt) error {
+		t.Helper()
+
+		_, err := parseTestCredential(t, vcBytes, append([]CredentialOpt{
+			WithPublicKeyFetcher(SingleKey(sigSigner.PublicKeyBytes(), kms.ED25519)),
+		}, opts...)...)
+
+		return err
+	}
+
+	t.Run("number of proofs within the limit", func(t *testing.T) {
+		vcBytes := newVCWithProofs(t, 3)
+
+		require.NoError(t, parse(t, vcBytes, WithMaxProofs(3)))
+		require.NoError(t, parse(t, vcBytes))
+	})
+
+	t.Run("number of proofs exceeds the limit", func(t *testing.T) {
+		err := parse(t, newVCWithProofs(t, 3), WithMaxProofs(2))
+		require.EqualError(t, err, "decode new credential: check embedded proof: number of proofs 3 exceeds "+
+			"the limit of 2")
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		vcBytes := newVCWithProofs(t, defaultMaxProofs+1)
+
+		err := parse(t, vcBytes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "number of proofs 11 exceeds the limit of 10")
+
+		// the limit is disabled
+		require.NoError(t, parse(t, vcBytes, WithMaxProofs(0)))
+
+		// proofs are not checked
+		require.NoError(t, parse(t, vcBytes, WithDisabledProofCheck()))
+	})
+}
@@ -0,0 +1,172 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	jsonld "github.com/piprate/json-gold/ld"
+)
+
+const (
+	// https://w3c-ccg.github.io/vc-extension-registry/#manualrefreshservice2018
+	manualRefreshService2018 = "ManualRefreshService2018"
+
+	// defaultRefreshTimeout is a timeout of the default HTTP client used to call the refresh service.
+	defaultRefreshTimeout = time.Minute
+
+	// maxRefreshedCredentialSize limits the size of the refresh service response
+	// if no limit is defined by WithMaxDocumentSize.
+	maxRefreshedCredentialSize = 4 << 20
+)
+
+// refreshOpts holds options for the Verifiable Credential refresh.
+type refreshOpts struct {
+	httpClient     *http.Client
+	credentialOpts []CredentialOpt
+}
+
+// RefreshOpt is the Verifiable Credential refresh option.
+type RefreshOpt func(opts *refreshOpts)
+
+// WithRefreshHTTPClient sets HTTP client to be used to call the refresh service.
+// If not defined, the client defined by WithHTTPClient of WithRefreshCredentialOpts is used, if any,
+// otherwise the HTTP client with a timeout of one minute is used.
+func WithRefreshHTTPClient(client *http.Client) RefreshOpt {
+	return func(opts *refreshOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithRefreshJSONLDDocumentLoader defines a JSON-LD document loader used when parsing the refreshed credential.
+func WithRefreshJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) RefreshOpt {
+	return func(opts *refreshOpts) {
+		opts.credentialOpts = append(opts.credentialOpts, WithJSONLDDocumentLoader(documentLoader))
+	}
+}
+
+// WithRefreshCredentialOpts defines options used when parsing the refreshed credential
+// (e.g. public key fetcher to check its proof).
+func WithRefreshCredentialOpts(credentialOpts ...CredentialOpt) RefreshOpt {
+	return func(opts *refreshOpts) {
+		opts.credentialOpts = append(opts.credentialOpts, credentialOpts...)
+	}
+}
+
+// Refresh gets a fresh copy of the Verifiable Credential using its refresh service.
+// Only ManualRefreshService2018 is supported; its endpoint is requested using HTTP GET and
+// the response is parsed using ParseCredential(). The response size is limited by WithMaxDocumentSize
+// of WithRefreshCredentialOpts or by 4 MiB if the limit is not defined.
+// The refreshed credential must have a proof (see WithRequireProof), so the public key fetcher should be
+// defined by WithRefreshCredentialOpts. An error is returned if the subject or the issuer of the refreshed
+// credential differs from the original one.
+func (vc *Credential) Refresh(ctx context.Context, opts ...RefreshOpt) (*Credential, error) {
+	rOpts := &refreshOpts{}
+
+	for _, opt := range opts {
+		opt(rOpts)
+	}
+
+	vcOpts := getCredentialOpts(rOpts.credentialOpts)
+
+	if rOpts.httpClient == nil {
+		rOpts.httpClient = vcOpts.httpClient
+	}
+
+	if rOpts.httpClient == nil {
+		rOpts.httpClient = &http.Client{Timeout: defaultRefreshTimeout}
+	}
+
+	maxSize := vcOpts.maxDocumentSize
+	if maxSize <= 0 {
+		maxSize = maxRefreshedCredentialSize
+	}
+
+	service, err := vc.refreshService()
+	if err != nil {
+		return nil, err
+	}
+
+	vcBytes, err := loadRefreshedCredential(ctx, service.ID, rOpts.httpClient, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshedVC, err := ParseCredential(vcBytes, append(rOpts.credentialOpts, WithRequireProof())...)
+	if err != nil {
+		return nil, fmt.Errorf("parse refreshed credential: %w", err)
+	}
+
+	subjectID, err := SubjectID(vc.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("get subject id of credential: %w", err)
+	}
+
+	refreshedSubjectID, err := SubjectID(refreshedVC.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("get subject id of refreshed credential: %w", err)
+	}
+
+	if subjectID != refreshedSubjectID {
+		return nil, fmt.Errorf("subject id of refreshed credential %s differs from original %s",
+			refreshedSubjectID, subjectID)
+	}
+
+	if refreshedVC.Issuer.ID != vc.Issuer.ID {
+		return nil, fmt.Errorf("issuer id of refreshed credential %s differs from original %s",
+			refreshedVC.Issuer.ID, vc.Issuer.ID)
+	}
+
+	return refreshedVC, nil
+}
+
+func (vc *Credential) refreshService() (*TypedID, error) {
+	if len(vc.RefreshService) == 0 {
+		return nil, errors.New("refresh service is not defined")
+	}
+
+	for i := range vc.RefreshService {
+		if vc.RefreshService[i].Type == manualRefreshService2018 {
+			return &vc.RefreshService[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported refresh service: %s", vc.RefreshService[0].Type)
+}
+
+func loadRefreshedCredential(ctx context.Context, url string, client *http.Client, maxSize int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create refresh service request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call refresh service: %w", err)
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("closing response body failed [%v]", e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("refresh service endpoint HTTP failure [%v]", resp.StatusCode)
+	}
+
+	body, err := readLimited(resp.Body, maxSize)
+	if err != nil {
+		return nil, fmt.Errorf("refresh service: read response body: %w", err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCredential_Refresh(t *testing.T) {
+	newRefreshServer := func(t *testing.T, status int, body []byte) *httptest.Server {
+		t.Helper()
+
+		return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			require.Equal(t, http.MethodGet, req.Method)
+
+			res.WriteHeader(status)
+
+			_, err := res.Write(body)
+			require.NoError(t, err)
+		}))
+	}
+
+	loader := createTestDocumentLoader(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	keyFetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	signVC := func(t *testing.T, vc *Credential) []byte {
+		t.Helper()
+
+		err := vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+		}, jsonld.WithDocumentLoader(loader))
+		require.NoError(t, err)
+
+		return vc.byteJSON(t)
+	}
+
+	t.Run("refresh credential using ManualRefreshService2018", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		refreshedVC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		refreshedVC.ID = "http://example.edu/credentials/1873"
+
+		testServer := newRefreshServer(t, http.StatusOK, signVC(t, refreshedVC))
+		defer testServer.Close()
+
+		vc.RefreshService = []TypedID{{ID: testServer.URL, Type: "ManualRefreshService2018"}}
+
+		vcRefreshed, err := vc.Refresh(context.Background(),
+			WithRefreshHTTPClient(testServer.Client()),
+			WithRefreshJSONLDDocumentLoader(loader),
+			WithRefreshCredentialOpts(keyFetcher))
+		require.NoError(t, err)
+		require.Equal(t, "http://example.edu/credentials/1873", vcRefreshed.ID)
+	})
+
+	t.Run("refreshed credential without proof", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		refreshedVC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		testServer := newRefreshServer(t, http.StatusOK, refreshedVC.byteJSON(t))
+		defer testServer.Close()
+
+		vc.RefreshService = []TypedID{{ID: testServer.URL, Type: "ManualRefreshService2018"}}
+
+		vcRefreshed, err := vc.Refresh(context.Background(),
+			WithRefreshJSONLDDocumentLoader(loader),
+			WithRefreshCredentialOpts(keyFetcher))
+		require.ErrorIs(t, err, ErrProofMissing)
+		require.Nil(t, vcRefreshed)
+
+		// the proof is required even if its check is disabled
+		vcRefreshed, err = vc.Refresh(context.Background(),
+			WithRefreshJSONLDDocumentLoader(loader),
+			WithRefreshCredentialOpts(WithDisabledProofCheck()))
+		require.ErrorIs(t, err, ErrProofMissing)
+		require.Nil(t, vcRefreshed)
+	})
+
+	t.Run("refreshed credential has different issuer", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		refreshedVC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		refreshedVC.Issuer = Issuer{ID: "did:example:c276e12ec21ebfeb1f712ebc6f1"}
+
+		testServer := newRefreshServer(t, http.StatusOK, signVC(t, refreshedVC))
+		defer testServer.Close()
+
+		vc.RefreshService = []TypedID{{ID: testServer.URL, Type: "ManualRefreshService2018"}}
+
+		vcRefreshed, err := vc.Refresh(context.Background(),
+			WithRefreshJSONLDDocumentLoader(loader),
+			WithRefreshCredentialOpts(keyFetcher))
+		require.EqualError(t, err, "issuer id of refreshed credential did:example:c276e12ec21ebfeb1f712ebc6f1 "+
+			"differs from original did:example:76e12ec712ebc6f1c221ebfeb1f")
+		require.Nil(t, vcRefreshed)
+	})
+
+	t.Run("refreshed credential has different subject", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		refreshedVC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		refreshedVC.Subject = "did:example:c276e12ec21ebfeb1f712ebc6f1"
+
+		testServer := newRefreshServer(t, http.StatusOK, signVC(t, refreshedVC))
+		defer testServer.Close()
+
+		vc.RefreshService = []TypedID{{ID: testServer.URL, Type: "ManualRefreshService2018"}}
+
+		vcRefreshed, err := vc.Refresh(context.Background(),
+			WithRefreshCredentialOpts(WithJSONLDDocumentLoader(loader), keyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "subject id of refreshed credential did:example:c276e12ec21ebfeb1f712ebc6f1 "+
+			"differs from original did:example:ebfeb1f712ebc6f1c276e12ec21")
+		require.Nil(t, vcRefreshed)
+	})
+
+	t.Run("refresh service returns invalid credential", func(t *testing.T) {
+		testServer := newRefreshServer(t, http.StatusOK, []byte("not a credential"))
+		defer testServer.Close()
+
+		vc := &Credential{RefreshService: []TypedID{{ID: testServer.URL, Type: "ManualRefreshService2018"}}}
+
+		vcRefreshed, err := vc.Refresh(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse refreshed credential")
+		require.Nil(t, vcRefreshed)
+	})
+
+	t.Run("refresh service response exceeds the limit", func(t *testing.T) {
+		testServer := newRefreshServer(t, http.StatusOK, []byte(validCredential))
+		defer testServer.Close()
+
+		vc := &Credential{RefreshService: []TypedID{{ID: testServer.URL, Type: "ManualRefreshService2018"}}}
+
+		vcRefreshed, err := vc.Refresh(context.Background(), WithRefreshCredentialOpts(WithMaxDocumentSize(100)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "refresh service: read response body: data exceeds the limit of 100 bytes")
+		require.Nil(t, vcRefreshed)
+	})
+
+	t.Run("refresh service returns not OK", func(t *testing.T) {
+		testServer := newRefreshServer(t, http.StatusNotFound, nil)
+		defer testServer.Close()
+
+		vc := &Credential{RefreshService: []TypedID{{ID: testServer.URL, Type: "ManualRefreshService2018"}}}
+
+		vcRefreshed, err := vc.Refresh(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "refresh service endpoint HTTP failure [404]")
+		require.Nil(t, vcRefreshed)
+	})
+
+	t.Run("refresh service is not reachable", func(t *testing.T) {
+		vc := &Credential{RefreshService: []TypedID{{ID: "http://localhost:1", Type: "ManualRefreshService2018"}}}
+
+		vcRefreshed, err := vc.Refresh(context.Background())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "call refresh service")
+		require.Nil(t, vcRefreshed)
+	})
+
+	t.Run("refresh service is not defined", func(t *testing.T) {
+		vcRefreshed, err := (&Credential{}).Refresh(context.Background())
+		require.EqualError(t, err, "refresh service is not defined")
+		require.Nil(t, vcRefreshed)
+	})
+
+	t.Run("unsupported refresh service", func(t *testing.T) {
+		vc := &Credential{RefreshService: []TypedID{{ID: "https://example.edu/refresh/3732", Type: "Unknown"}}}
+
+		vcRefreshed, err := vc.Refresh(context.Background())
+		require.EqualError(t, err, "unsupported refresh service: Unknown")
+		require.Nil(t, vcRefreshed)
+	})
+}
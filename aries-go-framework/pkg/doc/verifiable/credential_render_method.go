@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// renderMethodField is the credential field defining how the credential is displayed
+// (https://w3c-ccg.github.io/vc-render-method/).
+const renderMethodField = "renderMethod"
+
+// RenderMethods returns the rendering hints of the credential defined by "renderMethod" (a single object
+// or an array), e.g. SVG templates or OCA bundles referenced by id. The fields other than id and type
+// (e.g. "name", "css3MediaQuery" or inline "template") are kept in CustomFields of TypedID.
+// "renderMethod" itself stays in CustomFields of the credential, so it's marshalled back unchanged
+// as a part of the signed document.
+func (vc *Credential) RenderMethods() ([]TypedID, error) {
+	renderMethod, ok := vc.CustomFields[renderMethodField]
+	if !ok {
+		return nil, nil
+	}
+
+	renderMethodBytes, err := json.Marshal(renderMethod)
+	if err != nil {
+		return nil, fmt.Errorf("marshal renderMethod: %w", err)
+	}
+
+	renderMethods, err := parseTypedID(renderMethodBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse renderMethod: %w", err)
+	}
+
+	return renderMethods, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_RenderMethods(t *testing.T) {
+	svgRenderMethod := map[string]interface{}{
+		"id":              "https://example.edu/credentials/svg/3732.svg",
+		"type":            "SvgRenderingTemplate2023",
+		"name":            "Portrait Mode",
+		"css3MediaQuery":  "@media (orientation: portrait)",
+		"digestMultibase": "zQmAPdhyxzznFCwYxAp2dRerWC85Wg6wFl9G270iEu5h6JqW",
+	}
+
+	ocaRenderMethod := map[string]interface{}{
+		"id":   "https://example.edu/credentials/oca/bundle.json",
+		"type": "OverlayCaptureBundle",
+	}
+
+	t.Run("several render methods", func(t *testing.T) {
+		vcBytes := credentialWithField(t, renderMethodField, []interface{}{svgRenderMethod, ocaRenderMethod})
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+
+		renderMethods, err := vc.RenderMethods()
+		require.NoError(t, err)
+		require.Len(t, renderMethods, 2)
+
+		require.Equal(t, "https://example.edu/credentials/svg/3732.svg", renderMethods[0].ID)
+		require.Equal(t, "SvgRenderingTemplate2023", renderMethods[0].Type)
+		require.Equal(t, "Portrait Mode", renderMethods[0].CustomFields["name"])
+		require.Equal(t, "@media (orientation: portrait)", renderMethods[0].CustomFields["css3MediaQuery"])
+
+		require.Equal(t, "https://example.edu/credentials/oca/bundle.json", renderMethods[1].ID)
+		require.Equal(t, "OverlayCaptureBundle", renderMethods[1].Type)
+
+		requireSameRenderMethod(t, vcBytes, vc)
+	})
+
+	t.Run("single render method keeps its form", func(t *testing.T) {
+		for _, renderMethod := range []interface{}{svgRenderMethod, []interface{}{svgRenderMethod}} {
+			vcBytes := credentialWithField(t, renderMethodField, renderMethod)
+
+			vc, err := parseTestCredential(t, vcBytes)
+			require.NoError(t, err)
+
+			renderMethods, err := vc.RenderMethods()
+			require.NoError(t, err)
+			require.Len(t, renderMethods, 1)
+			require.Equal(t, "SvgRenderingTemplate2023", renderMethods[0].Type)
+
+			requireSameRenderMethod(t, vcBytes, vc)
+		}
+	})
+
+	t.Run("no render method", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		renderMethods, err := vc.RenderMethods()
+		require.NoError(t, err)
+		require.Empty(t, renderMethods)
+	})
+
+	t.Run("invalid render method", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.CustomFields = CustomFields{renderMethodField: "https://example.edu/credentials/svg/3732.svg"}
+
+		_, err = vc.RenderMethods()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse renderMethod")
+	})
+}
+
+// requireSameRenderMethod checks that renderMethod of the credential is marshalled back unchanged.
+func requireSameRenderMethod(t *testing.T, vcBytes []byte, vc *Credential) {
+	t.Helper()
+
+	vcMap, err := toMap(vcBytes)
+	require.NoError(t, err)
+
+	vcJSON, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	vcJSONMap, err := toMap(vcJSON)
+	require.NoError(t, err)
+
+	require.Equal(t, vcMap[renderMethodField], vcJSONMap[renderMethodField])
+}
+
+func credentialWithField(t *testing.T, field string, value interface{}) []byte {
+	t.Helper()
+
+	vcMap, err := toMap(validCredential)
+	require.NoError(t, err)
+
+	vcMap[field] = value
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	return vcBytes
+}
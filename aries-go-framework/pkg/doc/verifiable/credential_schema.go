@@ -0,0 +1,320 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+// credentialSubjectIRI is the IRI of credentialSubject term of the base context.
+const credentialSubjectIRI = "https://www.w3.org/2018/credentials#credentialSubject"
+
+// SchemaValidationError describes a violation of the credential schema.
+type SchemaValidationError struct {
+	// Field is a path of the subject field which violates the schema.
+	Field string
+
+	// Description is a human-readable description of the violation.
+	Description string
+}
+
+// SchemaValidationErrors is returned by ParseCredential if the credential subject does not conform to
+// its credential schemas (see WithSchemaValidation).
+type SchemaValidationErrors struct {
+	// SchemaID and SchemaType identify the credential schema which is violated.
+	SchemaID   string
+	SchemaType string
+
+	Errors []SchemaValidationError
+}
+
+// Error returns description of all the violations.
+func (e *SchemaValidationErrors) Error() string {
+	descriptions := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		descriptions[i] = fmt.Sprintf("%s: %s", err.Field, err.Description)
+	}
+
+	return fmt.Sprintf("credential subject does not conform to %s schema %s: %s",
+		e.SchemaType, e.SchemaID, strings.Join(descriptions, "; "))
+}
+
+// SchemaValidator validates the credential subject against the credential schema of a particular type.
+type SchemaValidator interface {
+	// Validate validates the subject against the schema. The subject is in JSON-LD expanded form, i.e. it's
+	// an array of the subject node objects whose terms are expanded to IRIs (e.g. "degree" of the examples
+	// context is "https://example.org/examples#degree").
+	// It returns the violations of the schema, if any, or an error if validation cannot be made
+	// (e.g. the schema cannot be loaded).
+	Validate(schema TypedID, subject interface{}) ([]SchemaValidationError, error)
+}
+
+//nolint:gochecknoglobals
+var schemaValidators = struct {
+	sync.RWMutex
+	validators map[string]SchemaValidator
+}{
+	validators: map[string]SchemaValidator{
+		jsonSchema2018Type:       &jsonSchemaValidator{},
+		zkpExampleSchema2018Type: &zkpSchemaValidator{},
+	},
+}
+
+// documentSchemaValidator is implemented by the built-in validators which check the credential
+// in compact form (as it was parsed) and load the schemas using the schema loader of the options.
+type documentSchemaValidator interface {
+	validateDocument(schema TypedID, vcBytes []byte, opts *credentialOpts) ([]SchemaValidationError, error)
+}
+
+// RegisterSchemaValidator registers the validator of credential schemas of the given type
+// (credentialSchema.type). The validator registered for the type before is replaced.
+// The validators of JsonSchemaValidator2018 and ZkpExampleSchema2018 are registered by default.
+// Unlike the custom validators, they check the credential in compact form: the JSON Schema describes
+// the whole credential and the ZKP schema lists the attributes ("attrNames") of the credential subject.
+func RegisterSchemaValidator(schemaType string, v SchemaValidator) {
+	schemaValidators.Lock()
+	defer schemaValidators.Unlock()
+
+	schemaValidators.validators[schemaType] = v
+}
+
+func getSchemaValidator(schemaType string) (SchemaValidator, bool) {
+	schemaValidators.RLock()
+	defer schemaValidators.RUnlock()
+
+	v, ok := schemaValidators.validators[schemaType]
+
+	return v, ok
+}
+
+// validateSubjectSchemas validates the credential against all the credential schemas using the registered
+// validators. The custom validators get the expanded subject, the built-in ones check the credential bytes.
+func (vc *Credential) validateSubjectSchemas(vcBytes []byte, opts *credentialOpts) error {
+	var (
+		subject  interface{}
+		expanded bool
+	)
+
+	for _, schema := range vc.Schemas {
+		validator, ok := getSchemaValidator(schema.Type)
+		if !ok {
+			return fmt.Errorf("no schema validator registered for type %s", schema.Type)
+		}
+
+		var (
+			violations []SchemaValidationError
+			err        error
+		)
+
+		if dv, builtIn := validator.(documentSchemaValidator); builtIn {
+			violations, err = dv.validateDocument(schema, vcBytes, opts)
+		} else {
+			if !expanded {
+				subject, err = vc.expandedSubject(opts)
+				if err != nil {
+					return fmt.Errorf("validate credential schema: %w", err)
+				}
+
+				expanded = true
+			}
+
+			violations, err = validator.Validate(schema, subject)
+		}
+
+		if err != nil {
+			return fmt.Errorf("validate credential schema %s: %w", schema.ID, err)
+		}
+
+		if len(violations) > 0 {
+			return &SchemaValidationErrors{SchemaID: schema.ID, SchemaType: schema.Type, Errors: violations}
+		}
+	}
+
+	return nil
+}
+
+// expandedSubject returns the credential subject in JSON-LD expanded form.
+func (vc *Credential) expandedSubject(opts *credentialOpts) (interface{}, error) {
+	vcCopy := *vc
+	vcCopy.Proofs = nil
+
+	vcMap, err := toMap(&vcCopy)
+	if err != nil {
+		return nil, fmt.Errorf("convert credential to map: %w", err)
+	}
+
+	processorOpts := append(mapJSONLDProcessorOpts(&opts.jsonldCredentialOpts),
+		jsonld.WithExternalContext(opts.externalContext...))
+
+	expanded, err := jsonld.Default().Expand(vcMap, processorOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("expand credential: %w", err)
+	}
+
+	if len(expanded) != 1 {
+		return nil, errors.New("expanded credential is not a single node")
+	}
+
+	node, ok := expanded[0].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("expanded credential is not a node object")
+	}
+
+	return node[credentialSubjectIRI], nil
+}
+
+// jsonSchemaValidator is the default validator of JSON Schema (JsonSchemaValidator2018).
+type jsonSchemaValidator struct{}
+
+// Validate validates the value against JSON Schema loaded by the default schema loader.
+func (v *jsonSchemaValidator) Validate(schema TypedID, value interface{}) ([]SchemaValidationError, error) {
+	return validateJSONSchemaValue(schema, gojsonschema.NewGoLoader(value),
+		&credentialOpts{schemaLoader: newDefaultSchemaLoader(nil)})
+}
+
+func (v *jsonSchemaValidator) validateDocument(schema TypedID, vcBytes []byte,
+	opts *credentialOpts) ([]SchemaValidationError, error) {
+	return validateJSONSchemaValue(schema, gojsonschema.NewBytesLoader(vcBytes), opts)
+}
+
+func validateJSONSchemaValue(schema TypedID, value gojsonschema.JSONLoader,
+	opts *credentialOpts) ([]SchemaValidationError, error) {
+	schemaBytes, err := getVerifiedJSONSchema(schema, opts)
+	if err != nil {
+		return nil, fmt.Errorf("load of custom credential schema from %s: %w", schema.ID, err)
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schemaBytes), value)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]SchemaValidationError, len(result.Errors()))
+
+	for i, e := range result.Errors() {
+		violations[i] = SchemaValidationError{Field: e.Field(), Description: e.Description()}
+	}
+
+	return violations, nil
+}
+
+// zkpSchema is the schema of ZkpExampleSchema2018 type, it lists the attributes of the credential subject
+// like the schema of AnonCreds credential.
+type zkpSchema struct {
+	AttrNames []string `json:"attrNames"`
+}
+
+// zkpSchemaValidator is the default validator of ZkpExampleSchema2018 schema. It checks that every subject
+// defines all the attributes of the schema and no other attributes (except "id").
+type zkpSchemaValidator struct{}
+
+// Validate validates the subject (an object or an array of objects in compact form) against the schema loaded
+// by the default schema loader.
+func (v *zkpSchemaValidator) Validate(schema TypedID, subject interface{}) ([]SchemaValidationError, error) {
+	return validateZKPSchemaSubject(schema, subject, &credentialOpts{schemaLoader: newDefaultSchemaLoader(nil)})
+}
+
+func (v *zkpSchemaValidator) validateDocument(schema TypedID, vcBytes []byte,
+	opts *credentialOpts) ([]SchemaValidationError, error) {
+	var raw map[string]interface{}
+
+	if err := json.Unmarshal(vcBytes, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	return validateZKPSchemaSubject(schema, raw[credentialSubjectField], opts)
+}
+
+func validateZKPSchemaSubject(schema TypedID, subject interface{},
+	opts *credentialOpts) ([]SchemaValidationError, error) {
+	schemaBytes, err := getJSONSchema(schema.ID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("load of ZKP credential schema from %s: %w", schema.ID, err)
+	}
+
+	var zs zkpSchema
+
+	if err = json.Unmarshal(schemaBytes, &zs); err != nil {
+		return nil, fmt.Errorf("decode ZKP credential schema: %w", err)
+	}
+
+	if len(zs.AttrNames) == 0 {
+		return nil, errors.New("ZKP credential schema defines no attributes")
+	}
+
+	subjects, ok := subject.([]interface{})
+	if !ok {
+		subjects = []interface{}{subject}
+	}
+
+	var violations []SchemaValidationError
+
+	for i, s := range subjects {
+		attrs, ok := s.(map[string]interface{})
+		if !ok {
+			violations = append(violations, SchemaValidationError{
+				Field: subjectField(i, len(subjects), ""), Description: "subject must be an object",
+			})
+
+			continue
+		}
+
+		known := map[string]bool{"id": true}
+
+		for _, name := range zs.AttrNames {
+			known[name] = true
+
+			if _, ok := attrs[name]; !ok {
+				violations = append(violations, SchemaValidationError{
+					Field: subjectField(i, len(subjects), name), Description: "attribute is required by the schema",
+				})
+			}
+		}
+
+		var unknown []string
+
+		for name := range attrs {
+			if !known[name] {
+				unknown = append(unknown, name)
+			}
+		}
+
+		sort.Strings(unknown)
+
+		for _, name := range unknown {
+			violations = append(violations, SchemaValidationError{
+				Field: subjectField(i, len(subjects), name), Description: "attribute is not defined by the schema",
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// subjectField returns the path of the subject field, the subject index is included if there are several subjects.
+func subjectField(i, n int, name string) string {
+	field := credentialSubjectField
+
+	if n > 1 {
+		field += fmt.Sprintf(".%d", i)
+	}
+
+	if name != "" {
+		field += "." + name
+	}
+
+	return field
+}
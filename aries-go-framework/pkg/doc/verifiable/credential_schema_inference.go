@@ -0,0 +1,219 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// jsonSchemaDraft07 is an identifier of the JSON Schema version produced by InferSubjectSchema.
+const jsonSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// JSON Schema types.
+const (
+	jsonSchemaObject  = "object"
+	jsonSchemaArray   = "array"
+	jsonSchemaString  = "string"
+	jsonSchemaNumber  = "number"
+	jsonSchemaInteger = "integer"
+	jsonSchemaBoolean = "boolean"
+)
+
+// JSONSchema is a (subset of) JSON Schema describing the shape of the credential subject.
+type JSONSchema struct {
+	Schema string `json:"$schema,omitempty"`
+
+	// Type is a JSON type of the value. It's empty if the value has different types in different credentials.
+	Type string `json:"type,omitempty"`
+
+	// Properties and Required are defined for the object. The property is required if it's present
+	// (and is not null) in every credential.
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+
+	// Items is defined for the non-empty array and describes all its elements.
+	Items *JSONSchema `json:"items,omitempty"`
+}
+
+// InferSubjectSchema examines the subjects of the credentials sharing a type and produces JSON Schema
+// describing their common shape, e.g. to generate a typed subject struct. Every subject of the credential
+// having several subjects is treated as a separate sample.
+func InferSubjectSchema(creds []*Credential) (JSONSchema, error) {
+	if len(creds) == 0 {
+		return JSONSchema{}, errors.New("infer subject schema: no credentials")
+	}
+
+	if !shareCredentialType(creds) {
+		return JSONSchema{}, errors.New("infer subject schema: credentials do not share a type")
+	}
+
+	var subjects []interface{}
+
+	for i, vc := range creds {
+		s, err := subjectSamples(vc.Subject)
+		if err != nil {
+			return JSONSchema{}, fmt.Errorf("infer subject schema: subject of credential #%d: %w", i, err)
+		}
+
+		subjects = append(subjects, s...)
+	}
+
+	schema := inferJSONSchema(subjects)
+	schema.Schema = jsonSchemaDraft07
+
+	return *schema, nil
+}
+
+// shareCredentialType checks that the credentials have a common type besides VerifiableCredential.
+func shareCredentialType(creds []*Credential) bool {
+	for _, t := range creds[0].Types {
+		if t == vcType {
+			continue
+		}
+
+		shared := true
+
+		for _, vc := range creds[1:] {
+			if !containsString(vc.Types, t) {
+				shared = false
+
+				break
+			}
+		}
+
+		if shared {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subjectSamples returns the subjects of the credential as generic JSON objects.
+func subjectSamples(subject interface{}) ([]interface{}, error) {
+	subjectBytes, err := subjectToBytes(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if subjectBytes == nil {
+		return nil, nil
+	}
+
+	var s interface{}
+
+	if err = json.Unmarshal(subjectBytes, &s); err != nil {
+		return nil, err
+	}
+
+	switch s := s.(type) {
+	case []interface{}:
+		return s, nil
+	case string:
+		// subject defined by ID only
+		return []interface{}{map[string]interface{}{"id": s}}, nil
+	default:
+		return []interface{}{s}, nil
+	}
+}
+
+// inferJSONSchema produces JSON Schema which all the values conform to. Null values are ignored.
+func inferJSONSchema(values []interface{}) *JSONSchema {
+	var (
+		objects []map[string]interface{}
+		items   []interface{}
+		types   = make(map[string]bool)
+	)
+
+	for _, v := range values {
+		switch value := v.(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			objects = append(objects, value)
+			types[jsonSchemaObject] = true
+		case []interface{}:
+			items = append(items, value...)
+			types[jsonSchemaArray] = true
+		case string:
+			types[jsonSchemaString] = true
+		case bool:
+			types[jsonSchemaBoolean] = true
+		case float64:
+			if value == math.Trunc(value) {
+				types[jsonSchemaInteger] = true
+			} else {
+				types[jsonSchemaNumber] = true
+			}
+		}
+	}
+
+	if types[jsonSchemaInteger] && types[jsonSchemaNumber] {
+		delete(types, jsonSchemaInteger)
+	}
+
+	if len(types) != 1 {
+		// values of different types (or no values at all)
+		return &JSONSchema{}
+	}
+
+	schema := &JSONSchema{}
+
+	for t := range types {
+		schema.Type = t
+	}
+
+	switch schema.Type {
+	case jsonSchemaObject:
+		inferObjectSchema(schema, objects)
+	case jsonSchemaArray:
+		if len(items) > 0 {
+			schema.Items = inferJSONSchema(items)
+		}
+	}
+
+	return schema
+}
+
+func inferObjectSchema(schema *JSONSchema, objects []map[string]interface{}) {
+	propertyValues := make(map[string][]interface{})
+
+	for _, o := range objects {
+		for k, v := range o {
+			if v != nil {
+				propertyValues[k] = append(propertyValues[k], v)
+			} else if _, ok := propertyValues[k]; !ok {
+				propertyValues[k] = nil
+			}
+		}
+	}
+
+	schema.Properties = make(map[string]*JSONSchema, len(propertyValues))
+
+	for k, values := range propertyValues {
+		schema.Properties[k] = inferJSONSchema(values)
+
+		if len(values) == len(objects) {
+			schema.Required = append(schema.Required, k)
+		}
+	}
+
+	sort.Strings(schema.Required)
+}
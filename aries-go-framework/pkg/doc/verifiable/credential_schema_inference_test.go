@@ -0,0 +1,128 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferSubjectSchema(t *testing.T) {
+	newCredential := func(subject interface{}) *Credential {
+		return &Credential{
+			Context: []string{baseContext},
+			Types:   []string{vcType, "UniversityDegreeCredential"},
+			Subject: subject,
+		}
+	}
+
+	t.Run("infer schema of subjects", func(t *testing.T) {
+		creds := []*Credential{
+			newCredential(map[string]interface{}{
+				"id":   "did:example:ebfeb1f712ebc6f1c276e12ec21",
+				"name": "Jayden Doe",
+				"age":  21,
+				"degree": map[string]interface{}{
+					"type":       "BachelorDegree",
+					"university": "MIT",
+				},
+				"courses": []interface{}{"math", "physics"},
+				"gpa":     4,
+			}),
+			newCredential([]Subject{{
+				ID: "did:example:c276e12ec21ebfeb1f712ebc6f1",
+				CustomFields: CustomFields{
+					"name": "Morgan Doe",
+					"age":  nil,
+					"degree": map[string]interface{}{
+						"type": "MasterDegree",
+					},
+					"courses": []interface{}{},
+					"gpa":     3.7,
+					"alumni":  true,
+				},
+			}}),
+		}
+
+		schema, err := InferSubjectSchema(creds)
+		require.NoError(t, err)
+
+		expected := JSONSchema{
+			Schema: "http://json-schema.org/draft-07/schema#",
+			Type:   "object",
+			Properties: map[string]*JSONSchema{
+				"id":   {Type: "string"},
+				"name": {Type: "string"},
+				"age":  {Type: "integer"},
+				"degree": {
+					Type: "object",
+					Properties: map[string]*JSONSchema{
+						"type":       {Type: "string"},
+						"university": {Type: "string"},
+					},
+					Required: []string{"type"},
+				},
+				"courses": {Type: "array", Items: &JSONSchema{Type: "string"}},
+				"gpa":     {Type: "number"},
+				"alumni":  {Type: "boolean"},
+			},
+			Required: []string{"courses", "degree", "gpa", "id", "name"},
+		}
+
+		require.Equal(t, expected, schema)
+
+		schemaBytes, err := json.Marshal(schema)
+		require.NoError(t, err)
+		require.Contains(t, string(schemaBytes), `"$schema":"http://json-schema.org/draft-07/schema#"`)
+	})
+
+	t.Run("values of different types", func(t *testing.T) {
+		schema, err := InferSubjectSchema([]*Credential{
+			newCredential(map[string]interface{}{"id": "did:example:1", "score": 10}),
+			newCredential(map[string]interface{}{"id": "did:example:2", "score": "A"}),
+		})
+		require.NoError(t, err)
+		require.Equal(t, &JSONSchema{}, schema.Properties["score"])
+		require.Equal(t, []string{"id", "score"}, schema.Required)
+	})
+
+	t.Run("several subjects and subject ID", func(t *testing.T) {
+		schema, err := InferSubjectSchema([]*Credential{
+			newCredential([]map[string]interface{}{
+				{"id": "did:example:1", "name": "Jayden Doe"},
+				{"id": "did:example:2"},
+			}),
+			newCredential("did:example:3"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"id"}, schema.Required)
+		require.Equal(t, &JSONSchema{Type: "string"}, schema.Properties["name"])
+	})
+
+	t.Run("no credentials", func(t *testing.T) {
+		_, err := InferSubjectSchema(nil)
+		require.EqualError(t, err, "infer subject schema: no credentials")
+	})
+
+	t.Run("credentials do not share a type", func(t *testing.T) {
+		other := newCredential(map[string]interface{}{"id": "did:example:1"})
+		other.Types = []string{vcType, "DriversLicenseCredential"}
+
+		_, err := InferSubjectSchema([]*Credential{
+			newCredential(map[string]interface{}{"id": "did:example:2"}),
+			other,
+		})
+		require.EqualError(t, err, "infer subject schema: credentials do not share a type")
+	})
+
+	t.Run("invalid subject", func(t *testing.T) {
+		_, err := InferSubjectSchema([]*Credential{newCredential(map[string]interface{}{"name": make(chan int)})})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "infer subject schema: subject of credential #0")
+	})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// digestSRIField is a field of the credential schema which holds subresource integrity metadata
+// (https://www.w3.org/TR/SRI/#integrity-metadata) of the schema, e.g. "sha384-<base64 digest>".
+const digestSRIField = "digestSRI"
+
+// sriHashParts is a number of parts of the hash in SRI metadata, i.e. "<algorithm>-<base64 digest>".
+const sriHashParts = 2
+
+// ErrSchemaDigestMismatch is returned when the fetched credential schema doesn't match its declared digestSRI.
+var ErrSchemaDigestMismatch = errors.New("credential schema does not match digestSRI")
+
+// sriAlgorithms are the hash algorithms supported in digestSRI ordered from the weakest to the strongest one.
+var sriAlgorithms = []struct { //nolint:gochecknoglobals
+	name string
+	sum  func(data []byte) []byte
+}{
+	{"sha256", func(data []byte) []byte { d := sha256.Sum256(data); return d[:] }},
+	{"sha384", func(data []byte) []byte { d := sha512.Sum384(data); return d[:] }},
+	{"sha512", func(data []byte) []byte { d := sha512.Sum512(data); return d[:] }},
+}
+
+// getVerifiedJSONSchema loads the JSON schema and checks its integrity if digestSRI of the schema is defined.
+func getVerifiedJSONSchema(schema TypedID, opts *credentialOpts) ([]byte, error) {
+	schemaBytes, err := getJSONSchema(schema.ID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	digestSRI, ok := schema.CustomFields[digestSRIField]
+	if !ok {
+		return schemaBytes, nil
+	}
+
+	digestSRIStr, ok := digestSRI.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s of credential schema must be a string", digestSRIField)
+	}
+
+	if err := checkSRI(schemaBytes, digestSRIStr); err != nil {
+		return nil, err
+	}
+
+	return schemaBytes, nil
+}
+
+// checkSRI checks the data against subresource integrity metadata. As defined by SRI, the metadata may contain
+// several whitespace separated hashes, only the hashes of the strongest algorithm are used and the data must
+// match any of them. The options of the hashes (after "?") are ignored.
+func checkSRI(data []byte, metadata string) error {
+	digests := make(map[int][]string)
+	strongest := -1
+
+	for _, token := range strings.Fields(metadata) {
+		hashExpr := strings.SplitN(token, "?", sriHashParts)[0]
+
+		parts := strings.SplitN(hashExpr, "-", sriHashParts)
+		if len(parts) != sriHashParts {
+			continue
+		}
+
+		for i, alg := range sriAlgorithms {
+			if alg.name == parts[0] {
+				digests[i] = append(digests[i], parts[1])
+
+				if i > strongest {
+					strongest = i
+				}
+			}
+		}
+	}
+
+	if strongest < 0 {
+		return fmt.Errorf("no supported hash algorithm in %s of credential schema: %q", digestSRIField, metadata)
+	}
+
+	actual := base64.StdEncoding.EncodeToString(sriAlgorithms[strongest].sum(data))
+
+	for _, expected := range digests[strongest] {
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) == 1 {
+			return nil
+		}
+	}
+
+	return ErrSchemaDigestMismatch
+}
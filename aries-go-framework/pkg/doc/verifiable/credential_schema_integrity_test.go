@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSRI(t *testing.T) {
+	data := []byte(credentialJSONSchema)
+
+	sha256Digest := sha256.Sum256(data)
+	sha384Digest := sha512.Sum384(data)
+	sha256SRI := "sha256-" + base64.StdEncoding.EncodeToString(sha256Digest[:])
+	sha384SRI := "sha384-" + base64.StdEncoding.EncodeToString(sha384Digest[:])
+
+	t.Run("matching digest", func(t *testing.T) {
+		require.NoError(t, checkSRI(data, sha256SRI))
+		require.NoError(t, checkSRI(data, sha384SRI))
+		require.NoError(t, checkSRI(data, sha384SRI+"?ct=application/schema+json"))
+		require.NoError(t, checkSRI(data, "sha384-invalid "+sha384SRI))
+	})
+
+	t.Run("strongest algorithm is used", func(t *testing.T) {
+		require.NoError(t, checkSRI(data, "sha256-invalid "+sha384SRI))
+		require.True(t, errors.Is(checkSRI(data, sha256SRI+" sha384-invalid"), ErrSchemaDigestMismatch))
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		err := checkSRI([]byte("substituted schema"), sha384SRI)
+		require.True(t, errors.Is(err, ErrSchemaDigestMismatch))
+	})
+
+	t.Run("no supported algorithm", func(t *testing.T) {
+		err := checkSRI(data, "md5-abc sha1-abc")
+		require.EqualError(t, err, `no supported hash algorithm in digestSRI of credential schema: "md5-abc sha1-abc"`)
+
+		require.Error(t, checkSRI(data, ""))
+	})
+}
+
+func TestCredentialSchemaDigestSRI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(credentialJSONSchema))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	digest := sha512.Sum384([]byte(credentialJSONSchema))
+	validSRI := "sha384-" + base64.StdEncoding.EncodeToString(digest[:])
+
+	newVCBytes := func(digestSRI interface{}) []byte {
+		var vcMap map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(credentialWithLangStrings), &vcMap))
+
+		vcMap["credentialSchema"] = map[string]interface{}{
+			"id":        server.URL,
+			"type":      jsonSchema2018Type,
+			"digestSRI": digestSRI,
+		}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	t.Run("schema matches digestSRI", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(validSRI), WithSchemaValidation())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("schema does not match digestSRI", func(t *testing.T) {
+		invalidSRI := "sha384-" + base64.StdEncoding.EncodeToString(make([]byte, sha512.Size384))
+
+		_, err := parseTestCredential(t, newVCBytes(invalidSRI), WithSchemaValidation())
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrSchemaDigestMismatch))
+		require.Contains(t, err.Error(), "load of custom credential schema from "+server.URL)
+	})
+
+	t.Run("digestSRI is not a string", func(t *testing.T) {
+		_, err := parseTestCredential(t, newVCBytes(42), WithSchemaValidation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "digestSRI of credential schema must be a string")
+	})
+}
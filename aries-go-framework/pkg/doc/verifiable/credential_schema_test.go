@@ -0,0 +1,226 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const credentialJSONSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "credentialSubject": {
+      "type": "object",
+      "properties": {
+        "id": {"type": "string"},
+        "degree": {
+          "type": "object",
+          "properties": {
+            "type": {"type": "string", "enum": ["BachelorDegree", "MasterDegree"]}
+          },
+          "required": ["type"]
+        }
+      },
+      "required": ["id", "degree"]
+    }
+  },
+  "required": ["credentialSubject"]
+}`
+
+type testSchemaValidator struct {
+	schemas  []TypedID
+	subjects []interface{}
+}
+
+func (v *testSchemaValidator) Validate(schema TypedID, subject interface{}) ([]SchemaValidationError, error) {
+	v.schemas = append(v.schemas, schema)
+	v.subjects = append(v.subjects, subject)
+
+	degreeTypes, ok := subject.([]interface{})[0].(map[string]interface{})["https://example.org/examples#degree"]
+	if !ok {
+		return []SchemaValidationError{{Field: "degree", Description: "degree is required"}}, nil
+	}
+
+	degreeType := degreeTypes.([]interface{})[0].(map[string]interface{})["@type"].([]interface{})[0]
+	if degreeType != "https://example.org/examples#BachelorDegree" {
+		return []SchemaValidationError{{Field: "degree.type", Description: "unsupported degree type"}}, nil
+	}
+
+	return nil, nil
+}
+
+func TestWithSchemaValidation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(credentialJSONSchema))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	newVCBytes := func(schemaType string, degreeType interface{}) []byte {
+		var vcMap map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(credentialWithLangStrings), &vcMap))
+
+		vcMap["credentialSchema"] = map[string]interface{}{"id": server.URL, "type": schemaType}
+		vcMap["credentialSubject"].(map[string]interface{})["degree"].(map[string]interface{})["type"] = degreeType
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	t.Run("JSON Schema of credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(jsonSchema2018Type, "BachelorDegree"), WithSchemaValidation())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+
+		// the schema describes the whole credential as without the option
+		for _, opts := range [][]CredentialOpt{nil, {WithSchemaValidation()}} {
+			vc, err = parseTestCredential(t, newVCBytes(jsonSchema2018Type, "PhD"), opts...)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "credentialSubject.degree.type")
+			require.Nil(t, vc)
+		}
+	})
+
+	t.Run("JSON Schema violation is reported", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(jsonSchema2018Type, "PhD"), WithSchemaValidation())
+		require.Nil(t, vc)
+
+		var schemaErr *SchemaValidationErrors
+		require.True(t, errors.As(err, &schemaErr))
+		require.Equal(t, server.URL, schemaErr.SchemaID)
+		require.Equal(t, jsonSchema2018Type, schemaErr.SchemaType)
+		require.Len(t, schemaErr.Errors, 1)
+		require.Equal(t, "credentialSubject.degree.type", schemaErr.Errors[0].Field)
+
+		// the validator is used directly
+		violations, err := (&jsonSchemaValidator{}).Validate(TypedID{ID: server.URL, Type: jsonSchema2018Type},
+			map[string]interface{}{"credentialSubject": map[string]interface{}{"id": "did:example:123"}})
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		require.Equal(t, "credentialSubject", violations[0].Field)
+	})
+
+	t.Run("custom schema validator", func(t *testing.T) {
+		validator := &testSchemaValidator{}
+
+		builtIn, _ := getSchemaValidator("ZkpExampleSchema2018")
+
+		RegisterSchemaValidator("ZkpExampleSchema2018", validator)
+		defer RegisterSchemaValidator("ZkpExampleSchema2018", builtIn)
+
+		vc, err := parseTestCredential(t, newVCBytes("ZkpExampleSchema2018", "BachelorDegree"),
+			WithSchemaValidation())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+		require.Len(t, validator.schemas, 1)
+		require.Equal(t, server.URL, validator.schemas[0].ID)
+		require.Equal(t, "ZkpExampleSchema2018", validator.schemas[0].Type)
+
+		// the subject is expanded
+		subject := validator.subjects[0].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subject["@id"])
+
+		vc, err = parseTestCredential(t, newVCBytes("ZkpExampleSchema2018", "PhD"), WithSchemaValidation())
+		require.Nil(t, vc)
+
+		var schemaErr *SchemaValidationErrors
+		require.True(t, errors.As(err, &schemaErr))
+		require.Equal(t, server.URL, schemaErr.SchemaID)
+		require.Equal(t, "ZkpExampleSchema2018", schemaErr.SchemaType)
+		require.Equal(t, []SchemaValidationError{{Field: "degree.type", Description: "unsupported degree type"}},
+			schemaErr.Errors)
+		require.EqualError(t, err, "credential subject does not conform to ZkpExampleSchema2018 schema "+
+			server.URL+": degree.type: unsupported degree type")
+	})
+
+	t.Run("no validator registered", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes("UnknownSchema2021", "BachelorDegree"), WithSchemaValidation())
+		require.EqualError(t, err, "no schema validator registered for type UnknownSchema2021")
+		require.Nil(t, vc)
+
+		// schema validation is not enabled
+		vc, err = parseTestCredential(t, newVCBytes("UnknownSchema2021", "BachelorDegree"))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("schema cannot be loaded", func(t *testing.T) {
+		var vcMap map[string]interface{}
+		require.NoError(t, json.Unmarshal(newVCBytes(jsonSchema2018Type, "BachelorDegree"), &vcMap))
+
+		vcMap["credentialSchema"].(map[string]interface{})["id"] = "http://localhost:1/schema.json"
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithSchemaValidation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "load of custom credential schema from http://localhost:1/schema.json")
+	})
+}
+
+func TestZKPSchemaValidator(t *testing.T) {
+	schemas := map[string]string{
+		"/degree":      `{"attrNames": ["degree"]}`,
+		"/degree-name": `{"attrNames": ["degree", "name"]}`,
+		"/name":        `{"attrNames": ["name"]}`,
+		"/empty":       `{}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(schemas[r.URL.Path]))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	newVCBytes := func(schemaID string) []byte {
+		var vcMap map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(credentialWithLangStrings), &vcMap))
+
+		vcMap["credentialSchema"] = map[string]interface{}{"id": schemaID, "type": "ZkpExampleSchema2018"}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	vc, err := parseTestCredential(t, newVCBytes(server.URL+"/degree"), WithSchemaValidation())
+	require.NoError(t, err)
+	require.NotNil(t, vc)
+
+	vc, err = parseTestCredential(t, newVCBytes(server.URL+"/degree-name"), WithSchemaValidation())
+	require.Nil(t, vc)
+
+	var schemaErr *SchemaValidationErrors
+	require.True(t, errors.As(err, &schemaErr))
+	require.Equal(t, "ZkpExampleSchema2018", schemaErr.SchemaType)
+	require.Equal(t, []SchemaValidationError{
+		{Field: "credentialSubject.name", Description: "attribute is required by the schema"},
+	}, schemaErr.Errors)
+
+	violations, err := (&zkpSchemaValidator{}).Validate(TypedID{ID: server.URL + "/name"},
+		[]interface{}{
+			map[string]interface{}{"id": "did:example:1", "name": "Jayden"},
+			map[string]interface{}{"name": "Jayden", "degree": "BachelorDegree"},
+		})
+	require.NoError(t, err)
+	require.Equal(t, []SchemaValidationError{
+		{Field: "credentialSubject.1.degree", Description: "attribute is not defined by the schema"},
+	}, violations)
+
+	_, err = (&zkpSchemaValidator{}).Validate(TypedID{ID: server.URL + "/empty"}, map[string]interface{}{})
+	require.EqualError(t, err, "ZKP credential schema defines no attributes")
+}
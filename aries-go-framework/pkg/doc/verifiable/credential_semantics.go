@@ -0,0 +1,247 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+// SemanticValidationError describes the credential subject property which is not defined by the contexts
+// of the credential and its types.
+type SemanticValidationError struct {
+	// Property is a name of the subject property.
+	Property string
+
+	// IRI is an IRI the property expands to by the default vocabulary ("@vocab"), empty if the property is
+	// not expanded at all (i.e. it's dropped by JSON-LD processing).
+	IRI string
+}
+
+// SemanticValidationErrors is returned by Credential.ValidateSemantics if some of the credential subject
+// properties are not defined by the credential types.
+type SemanticValidationErrors struct {
+	Errors []SemanticValidationError
+}
+
+// Error returns description of all the undefined properties.
+func (e *SemanticValidationErrors) Error() string {
+	descriptions := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		if err.IRI != "" {
+			descriptions[i] = fmt.Sprintf("%s: expanded to default vocabulary (%s)", err.Property, err.IRI)
+		} else {
+			descriptions[i] = fmt.Sprintf("%s: undefined term", err.Property)
+		}
+	}
+
+	return "credential subject properties are not defined by credential types: " + strings.Join(descriptions, "; ")
+}
+
+// ValidateSemantics checks that every property of the credential subject resolves to an IRI defined by
+// a term of the credential contexts (including the contexts scoped to the credentialSubject property and
+// to the subject types). Unlike strict validation, the properties expanded by the default vocabulary
+// ("@vocab") are reported as well. *SemanticValidationErrors is returned if there are such properties.
+//
+// The top-level properties of the subject are checked only. WithJSONLDDocumentLoader and
+// WithExternalJSONLDContext options are applied.
+func (vc *Credential) ValidateSemantics(opts ...CredentialOpt) error {
+	vcOpts := getCredentialOpts(opts)
+
+	vcBytes, err := vc.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("validate semantics: %w", err)
+	}
+
+	var doc map[string]interface{}
+
+	if err = json.Unmarshal(vcBytes, &doc); err != nil {
+		return fmt.Errorf("validate semantics: %w", err)
+	}
+
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.ProcessingMode = ld.JsonLd_1_1
+
+	if vcOpts.jsonldDocumentLoader != nil {
+		ldOptions.DocumentLoader = vcOpts.jsonldDocumentLoader
+	}
+
+	docContext := doc["@context"]
+	if len(vcOpts.externalContext) > 0 {
+		docContext = jsonld.AppendExternalContexts(docContext, vcOpts.externalContext...)
+	}
+
+	activeContext, err := ld.NewContext(nil, ldOptions).Parse(docContext)
+	if err != nil {
+		return fmt.Errorf("validate semantics: parse credential context: %w", err)
+	}
+
+	subjectContext, err := credentialSubjectContext(activeContext, vc.Types)
+	if err != nil {
+		return fmt.Errorf("validate semantics: %w", err)
+	}
+
+	var semanticErrors []SemanticValidationError
+
+	reported := make(map[string]bool)
+
+	for _, subject := range subjectObjects(doc[credentialSubjectField]) {
+		ctx, err := nodeContext(subjectContext, subject)
+		if err != nil {
+			return fmt.Errorf("validate semantics: credential subject context: %w", err)
+		}
+
+		properties := make([]string, 0, len(subject))
+
+		for property := range subject {
+			properties = append(properties, property)
+		}
+
+		sort.Strings(properties)
+
+		for _, property := range properties {
+			if reported[property] {
+				continue
+			}
+
+			if iri, defined := checkTermDefined(ctx, property); !defined {
+				semanticErrors = append(semanticErrors, SemanticValidationError{Property: property, IRI: iri})
+				reported[property] = true
+			}
+		}
+	}
+
+	if len(semanticErrors) > 0 {
+		return &SemanticValidationErrors{Errors: semanticErrors}
+	}
+
+	return nil
+}
+
+// credentialSubjectContext returns the active context for the credential subject: the credentialSubject term
+// may be defined by the context scoped to the credential type (e.g. VerifiableCredential) and may have its own
+// scoped context. The contexts scoped to the credential types are not propagated to the subject.
+func credentialSubjectContext(activeContext *ld.Context, types []string) (*ld.Context, error) {
+	credentialContext, err := applyTypeScopedContexts(activeContext, types)
+	if err != nil {
+		return nil, err
+	}
+
+	termDefinition := credentialContext.GetTermDefinition(credentialSubjectField)
+	if scoped, ok := termDefinition["@context"]; ok && scoped != nil {
+		return activeContext.Parse(scoped)
+	}
+
+	return activeContext, nil
+}
+
+// nodeContext applies the embedded context of the node and the contexts scoped to its types.
+func nodeContext(activeContext *ld.Context, node map[string]interface{}) (*ld.Context, error) {
+	ctx := activeContext
+
+	if embedded, ok := node["@context"]; ok {
+		var err error
+
+		ctx, err = ctx.Parse(embedded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var types []string
+
+	for _, typeProperty := range []string{"type", "@type"} {
+		switch t := node[typeProperty].(type) {
+		case string:
+			types = append(types, t)
+		case []interface{}:
+			for _, v := range t {
+				if s, ok := v.(string); ok {
+					types = append(types, s)
+				}
+			}
+		}
+	}
+
+	return applyTypeScopedContexts(ctx, types)
+}
+
+func applyTypeScopedContexts(activeContext *ld.Context, types []string) (*ld.Context, error) {
+	ctx := activeContext
+
+	for _, t := range types {
+		termDefinition := activeContext.GetTermDefinition(t)
+
+		scoped, ok := termDefinition["@context"]
+		if !ok || scoped == nil {
+			continue
+		}
+
+		var err error
+
+		ctx, err = ctx.Parse(scoped)
+		if err != nil {
+			return nil, fmt.Errorf("parse context scoped to type %s: %w", t, err)
+		}
+	}
+
+	return ctx, nil
+}
+
+// checkTermDefined checks that the property is a term defined by the active context (or an absolute/compact IRI).
+// The IRI the property expands to by the default vocabulary is returned for undefined property, if any.
+func checkTermDefined(activeContext *ld.Context, property string) (string, bool) {
+	if ld.IsKeyword(property) {
+		return "", true
+	}
+
+	if termDefinition := activeContext.GetTermDefinition(property); termDefinition != nil {
+		return "", termDefinition["@id"] != nil
+	}
+
+	iri, err := activeContext.ExpandIri(property, false, true, nil, nil)
+	if err != nil || iri == "" {
+		return "", false
+	}
+
+	if strings.Contains(property, ":") {
+		// compact IRI or absolute IRI
+		return "", ld.IsAbsoluteIri(iri)
+	}
+
+	if iri != property {
+		// expanded by @vocab
+		return iri, false
+	}
+
+	return "", false
+}
+
+func subjectObjects(subject interface{}) []map[string]interface{} {
+	switch s := subject.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{s}
+	case []interface{}:
+		var objects []map[string]interface{}
+
+		for _, item := range s {
+			if o, ok := item.(map[string]interface{}); ok {
+				objects = append(objects, o)
+			}
+		}
+
+		return objects
+	default:
+		return nil
+	}
+}
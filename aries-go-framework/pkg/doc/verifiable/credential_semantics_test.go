@@ -0,0 +1,105 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_ValidateSemantics(t *testing.T) {
+	newCredential := func(context []string, customContext []interface{}, subject CustomFields) *Credential {
+		return &Credential{
+			Context:       context,
+			CustomContext: customContext,
+			Types:         []string{"VerifiableCredential", "UniversityDegreeCredential"},
+			Issuer:        Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Subject: Subject{
+				ID:           "did:example:ebfeb1f712ebc6f1c276e12ec21",
+				CustomFields: subject,
+			},
+		}
+	}
+
+	contexts := []string{
+		"https://www.w3.org/2018/credentials/v1",
+		"https://www.w3.org/2018/credentials/examples/v1",
+	}
+
+	t.Run("all subject properties are defined", func(t *testing.T) {
+		vc := newCredential(contexts, nil, CustomFields{
+			"degree": map[string]interface{}{
+				"type": "BachelorDegree",
+				"name": "Bachelor of Science and Arts",
+			},
+			"http://schema.org/alumniOf": "Example University",
+		})
+
+		require.NoError(t, vc.ValidateSemantics(WithJSONLDDocumentLoader(createTestDocumentLoader(t))))
+	})
+
+	t.Run("subject properties expanded by default vocabulary", func(t *testing.T) {
+		vc := newCredential(contexts, []interface{}{map[string]interface{}{"@vocab": "https://example.com/vocab#"}},
+			CustomFields{
+				"degree":        map[string]interface{}{"type": "BachelorDegree"},
+				"favoriteColor": "blue",
+			})
+
+		// strict validation accepts the terms expanded by @vocab
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithStrictValidation(), WithJSONLDValidation())
+		require.NoError(t, err)
+
+		err = vc.ValidateSemantics(WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.EqualError(t, err, "credential subject properties are not defined by credential types: "+
+			"favoriteColor: expanded to default vocabulary (https://example.com/vocab#favoriteColor)")
+
+		var semanticErrors *SemanticValidationErrors
+
+		require.True(t, errors.As(err, &semanticErrors))
+		require.Equal(t, []SemanticValidationError{{
+			Property: "favoriteColor",
+			IRI:      "https://example.com/vocab#favoriteColor",
+		}}, semanticErrors.Errors)
+	})
+
+	t.Run("undefined subject properties", func(t *testing.T) {
+		vc := newCredential(contexts, nil, CustomFields{
+			"degree":     map[string]interface{}{"type": "BachelorDegree"},
+			"university": "MIT",
+		})
+
+		err := vc.ValidateSemantics(WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.EqualError(t, err,
+			"credential subject properties are not defined by credential types: university: undefined term")
+	})
+
+	t.Run("subject property defined by external context", func(t *testing.T) {
+		vc := newCredential(contexts[:1], nil, CustomFields{
+			"degree": map[string]interface{}{"type": "BachelorDegree"},
+		})
+
+		err := vc.ValidateSemantics(WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.EqualError(t, err,
+			"credential subject properties are not defined by credential types: degree: undefined term")
+
+		err = vc.ValidateSemantics(WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithExternalJSONLDContext("https://www.w3.org/2018/credentials/examples/v1"))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid context", func(t *testing.T) {
+		vc := newCredential(contexts, []interface{}{map[string]interface{}{"@vocab": 1}}, nil)
+
+		err := vc.ValidateSemantics(WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validate semantics: parse credential context")
+	})
+}
@@ -0,0 +1,483 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// https://w3c-ccg.github.io/vc-status-list-2021/#statuslist2021entry
+	statusList2021Entry = "StatusList2021Entry"
+	statusList2021Type  = "StatusList2021"
+
+	statusListIndexField      = "statusListIndex"
+	statusListCredentialField = "statusListCredential"
+
+	statusPurposeField      = "statusPurpose"
+	statusPurposeRevocation = "revocation"
+
+	// https://w3c-ccg.github.io/vc-status-rl-2020/#revocationlist2020status
+	revocationList2020Status = "RevocationList2020Status"
+	revocationList2020Type   = "RevocationList2020"
+
+	revocationListIndexField      = "revocationListIndex"
+	revocationListCredentialField = "revocationListCredential"
+
+	encodedListField = "encodedList"
+	validUntilField  = "validUntil"
+
+	bitsPerByte = 8
+
+	// maxStatusListCredentialSize limits the size of the status list credential loaded by StatusChecker.
+	maxStatusListCredentialSize = 4 << 20
+	// maxStatusListSize limits the size of the decompressed status list bitstring (about 134M entries),
+	// so the bitstring compressed with a high ratio can't exhaust the memory.
+	maxStatusListSize = 16 << 20
+)
+
+// StatusListFormat describes a credentialStatus type which refers to a bit of the status list (base64 encoded
+// GZIP-compressed bitstring in "encodedList" of the status list credential), e.g. StatusList2021Entry
+// or RevocationList2020Status.
+type StatusListFormat struct {
+	// IndexField is the field of credentialStatus with the index of the bit (e.g. "statusListIndex").
+	IndexField string
+	// ListCredentialField is the field of credentialStatus with URL of the status list credential
+	// (e.g. "statusListCredential").
+	ListCredentialField string
+	// ListType is the type of the subject of the status list credential (e.g. "StatusList2021").
+	ListType string
+}
+
+// defaultStatusListFormats returns the credentialStatus types supported by StatusChecker by default.
+func defaultStatusListFormats() map[string]StatusListFormat {
+	return map[string]StatusListFormat{
+		statusList2021Entry: {
+			IndexField:          statusListIndexField,
+			ListCredentialField: statusListCredentialField,
+			ListType:            statusList2021Type,
+		},
+		revocationList2020Status: {
+			IndexField:          revocationListIndexField,
+			ListCredentialField: revocationListCredentialField,
+			ListType:            revocationList2020Type,
+		},
+	}
+}
+
+// StatusResult is a result of the revocation check of the Verifiable Credential.
+// It is the same for all the supported credentialStatus types.
+type StatusResult struct {
+	Credential *Credential
+	// StatusType is the type of credentialStatus of the credential (e.g. "StatusList2021Entry").
+	StatusType string
+	Revoked    bool
+	Err        error
+}
+
+// StatusCheckerOpt is the StatusChecker option.
+type StatusCheckerOpt func(checker *StatusChecker)
+
+// WithStatusHTTPClient sets HTTP client to be used to load status list credentials.
+// If not defined, the client defined by WithHTTPClient of WithStatusCredentialOpts is used, if any,
+// otherwise the default HTTP client is used.
+func WithStatusHTTPClient(client *http.Client) StatusCheckerOpt {
+	return func(checker *StatusChecker) {
+		checker.httpClient = client
+	}
+}
+
+// WithStatusCredentialOpts defines options used when parsing the status list credentials
+// (e.g. JSON-LD document loader or public key fetcher to check their proofs).
+// The status list credential without proof is rejected whatever the options are (see WithRequireProof).
+func WithStatusCredentialOpts(credentialOpts ...CredentialOpt) StatusCheckerOpt {
+	return func(checker *StatusChecker) {
+		checker.credentialOpts = append(checker.credentialOpts, credentialOpts...)
+	}
+}
+
+// WithStatusListFormat registers the credentialStatus type using a status list, so the credentials with
+// such status are checked too. The formats of StatusList2021Entry and RevocationList2020Status
+// are registered by default.
+func WithStatusListFormat(statusType string, format StatusListFormat) StatusCheckerOpt {
+	return func(checker *StatusChecker) {
+		checker.formats[statusType] = format
+	}
+}
+
+// StatusChecker checks revocation status of the Verifiable Credentials using StatusList2021
+// (https://w3c-ccg.github.io/vc-status-list-2021/) or RevocationList2020
+// (https://w3c-ccg.github.io/vc-status-rl-2020/). The supported credentialStatus types are kept
+// in a registry keyed by the type (see WithStatusListFormat).
+//
+// Decoded status lists are cached by URL of the status list credential, so the credentials sharing
+// the same status list are checked without loading it again. A cached status list is reloaded
+// after expiration (expirationDate or validUntil) of its credential. The status lists are loaded without blocking
+// the checks using other lists, and a status list is loaded once for the concurrent checks sharing it.
+// StatusChecker is safe for concurrent use.
+type StatusChecker struct {
+	httpClient     *http.Client
+	credentialOpts []CredentialOpt
+	formats        map[string]StatusListFormat
+
+	mu    sync.Mutex
+	lists map[string]*statusList
+	loads map[string]*statusListLoad
+}
+
+// statusListLoad is the status list credential being loaded; the concurrent checks of the credentials sharing
+// the status list wait for the single load to be done.
+type statusListLoad struct {
+	done chan struct{}
+	list *statusList
+	err  error
+}
+
+type statusList struct {
+	listType string
+	issuerID string
+	purpose  string
+	bits     []byte
+	expires  *time.Time
+}
+
+// NewStatusChecker creates a new instance of StatusChecker.
+func NewStatusChecker(opts ...StatusCheckerOpt) *StatusChecker {
+	checker := &StatusChecker{
+		formats: defaultStatusListFormats(),
+		lists:   make(map[string]*statusList),
+		loads:   make(map[string]*statusListLoad),
+	}
+
+	for _, opt := range opts {
+		opt(checker)
+	}
+
+	if checker.httpClient == nil {
+		checker.httpClient = getCredentialOpts(checker.credentialOpts).httpClient
+	}
+
+	if checker.httpClient == nil {
+		checker.httpClient = &http.Client{}
+	}
+
+	// The status list credential is loaded from the URL defined by the checked credential,
+	// so it's accepted only if it's signed.
+	checker.credentialOpts = append(checker.credentialOpts, WithRequireProof())
+
+	return checker
+}
+
+// Check checks if the Verifiable Credential is revoked according to its credentialStatus.
+func (c *StatusChecker) Check(vc *Credential) (bool, error) {
+	if vc.Status == nil {
+		return false, errors.New("credential status is not defined")
+	}
+
+	format, ok := c.formats[vc.Status.Type]
+	if !ok {
+		return false, fmt.Errorf("unsupported credential status type: %s", vc.Status.Type)
+	}
+
+	index, err := statusListIndex(vc.Status, format.IndexField)
+	if err != nil {
+		return false, err
+	}
+
+	listURL, ok := vc.Status.CustomFields[format.ListCredentialField].(string)
+	if !ok || listURL == "" {
+		return false, fmt.Errorf("%s of credential status is not defined", format.ListCredentialField)
+	}
+
+	list, err := c.statusList(listURL, format)
+	if err != nil {
+		return false, err
+	}
+
+	if err = list.checkIssuer(vc.Issuer.ID); err != nil {
+		return false, err
+	}
+
+	if err = list.checkPurpose(safeStatusPurpose(vc.Status)); err != nil {
+		return false, err
+	}
+
+	if index >= len(list.bits)*bitsPerByte {
+		return false, fmt.Errorf("%s %d is out of status list range", format.IndexField, index)
+	}
+
+	// The first index is the left-most bit of the bitstring.
+	return list.bits[index/bitsPerByte]&(1<<(bitsPerByte-1-index%bitsPerByte)) != 0, nil
+}
+
+// CheckBatch checks revocation status of every Verifiable Credential. The results are returned
+// in the same order as the credentials; a failure to check one credential is reported in its result only.
+func (c *StatusChecker) CheckBatch(creds []*Credential) []StatusResult {
+	results := make([]StatusResult, len(creds))
+
+	for i, vc := range creds {
+		revoked, err := c.Check(vc)
+
+		results[i] = StatusResult{
+			Credential: vc,
+			Revoked:    revoked,
+			Err:        err,
+		}
+
+		if vc.Status != nil {
+			results[i].StatusType = vc.Status.Type
+		}
+	}
+
+	return results
+}
+
+func (c *StatusChecker) statusList(url string, format StatusListFormat) (*statusList, error) {
+	list, load, owner := c.cachedStatusList(url, format)
+	if list != nil {
+		return list, nil
+	}
+
+	if !owner {
+		<-load.done
+
+		return load.list, load.err
+	}
+
+	// The list is loaded without holding the lock, so a slow endpoint doesn't block the checks of other lists.
+	load.list, load.err = c.loadStatusList(url, format)
+
+	c.finishLoad(url, format, load)
+
+	return load.list, load.err
+}
+
+// cachedStatusList returns the cached status list if it's not expired. Otherwise, it returns the load
+// of the status list in progress, or starts a new one (owner is true) which must be finished by finishLoad.
+func (c *StatusChecker) cachedStatusList(url string, format StatusListFormat) (*statusList, *statusListLoad, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The list of other type is loaded again to be rejected as usual.
+	if list, ok := c.lists[url]; ok && list.listType == format.ListType {
+		if list.expires == nil || now().Before(*list.expires) {
+			return list, nil, false
+		}
+
+		delete(c.lists, url)
+	}
+
+	key := statusListLoadKey(url, format)
+
+	if load, ok := c.loads[key]; ok {
+		return nil, load, false
+	}
+
+	load := &statusListLoad{done: make(chan struct{})}
+	c.loads[key] = load
+
+	return nil, load, true
+}
+
+func (c *StatusChecker) finishLoad(url string, format StatusListFormat, load *statusListLoad) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.loads, statusListLoadKey(url, format))
+
+	if load.err == nil {
+		c.lists[url] = load.list
+	}
+
+	close(load.done)
+}
+
+// statusListLoadKey is the key of the status list load; the list of the same URL is loaded separately
+// for every type, as it's rejected if the type doesn't match.
+func statusListLoadKey(url string, format StatusListFormat) string {
+	return format.ListType + " " + url
+}
+
+func (c *StatusChecker) loadStatusList(url string, format StatusListFormat) (*statusList, error) {
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("load status list credential: %w", err)
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("closing response body failed [%v]", e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status list credential endpoint HTTP failure [%v]", resp.StatusCode)
+	}
+
+	vcBytes, err := readLimited(resp.Body, maxStatusListCredentialSize)
+	if err != nil {
+		return nil, fmt.Errorf("status list credential: read response body: %w", err)
+	}
+
+	listVC, err := ParseCredential(vcBytes, c.credentialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse status list credential: %w", err)
+	}
+
+	return newStatusList(listVC, format)
+}
+
+func newStatusList(listVC *Credential, format StatusListFormat) (*statusList, error) {
+	subject, err := listVC.subjectMap()
+	if err != nil {
+		return nil, fmt.Errorf("status list credential: %w", err)
+	}
+
+	if subject["type"] != format.ListType {
+		return nil, fmt.Errorf("status list credential: unsupported subject type: %v", subject["type"])
+	}
+
+	encodedList, ok := subject[encodedListField].(string)
+	if !ok {
+		return nil, fmt.Errorf("status list credential: %s is not defined", encodedListField)
+	}
+
+	bits, err := decodeStatusList(encodedList)
+	if err != nil {
+		return nil, fmt.Errorf("status list credential: %w", err)
+	}
+
+	expires, err := statusListExpiration(listVC)
+	if err != nil {
+		return nil, fmt.Errorf("status list credential: %w", err)
+	}
+
+	purpose, ok := subject[statusPurposeField].(string)
+	if !ok && subject[statusPurposeField] != nil {
+		return nil, fmt.Errorf("status list credential: invalid %s", statusPurposeField)
+	}
+
+	return &statusList{
+		listType: format.ListType,
+		issuerID: listVC.Issuer.ID,
+		purpose:  purpose,
+		bits:     bits,
+		expires:  expires,
+	}, nil
+}
+
+// checkIssuer checks that the status list is issued by the issuer of the credential. As the proof
+// of the status list credential is required and checked, the status of the credential can't be published
+// by someone else unless the proof check is disabled (see WithStatusCredentialOpts).
+func (l *statusList) checkIssuer(issuerID string) error {
+	if l.issuerID != issuerID {
+		return fmt.Errorf("issuer %s of status list credential doesn't match issuer %s of credential",
+			l.issuerID, issuerID)
+	}
+
+	return nil
+}
+
+// checkPurpose checks that statusPurpose of credentialStatus matches the one of the status list.
+// If credentialStatus doesn't define the purpose, the status list must be the revocation one (or have
+// no purpose at all, e.g. RevocationList2020).
+func (l *statusList) checkPurpose(purpose string) error {
+	if purpose == "" && (l.purpose == "" || l.purpose == statusPurposeRevocation) {
+		return nil
+	}
+
+	if purpose != l.purpose {
+		return fmt.Errorf("%s %s of credential status doesn't match %s %s of status list credential",
+			statusPurposeField, purpose, statusPurposeField, l.purpose)
+	}
+
+	return nil
+}
+
+func safeStatusPurpose(status *TypedID) string {
+	purpose, _ := status.CustomFields[statusPurposeField].(string)
+
+	return purpose
+}
+
+// decodeStatusList decodes base64 encoded GZIP-compressed bitstring.
+func decodeStatusList(encodedList string) ([]byte, error) {
+	encodedList = strings.TrimRight(encodedList, "=")
+
+	compressed, err := base64.RawURLEncoding.DecodeString(encodedList)
+	if err != nil {
+		compressed, err = base64.RawStdEncoding.DecodeString(encodedList)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", encodedListField, err)
+		}
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress %s: %w", encodedListField, err)
+	}
+
+	bits, err := readLimited(reader, maxStatusListSize)
+	if err != nil {
+		return nil, fmt.Errorf("decompress %s: %w", encodedListField, err)
+	}
+
+	return bits, nil
+}
+
+// statusListExpiration returns the earliest of expirationDate and validUntil of the status list credential.
+func statusListExpiration(listVC *Credential) (*time.Time, error) {
+	var expires *time.Time
+
+	if listVC.Expired != nil {
+		expires = &listVC.Expired.Time
+	}
+
+	if validUntil, ok := listVC.CustomFields[validUntilField].(string); ok {
+		t, err := time.Parse(time.RFC3339, validUntil)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", validUntilField, err)
+		}
+
+		if expires == nil || t.Before(*expires) {
+			expires = &t
+		}
+	}
+
+	return expires, nil
+}
+
+func statusListIndex(status *TypedID, indexField string) (int, error) {
+	switch index := status.CustomFields[indexField].(type) {
+	case string:
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 {
+			return 0, fmt.Errorf("invalid %s of credential status: %s", indexField, index)
+		}
+
+		return i, nil
+
+	case float64:
+		if index < 0 || index != float64(int(index)) {
+			return 0, fmt.Errorf("invalid %s of credential status: %v", indexField, index)
+		}
+
+		return int(index), nil
+
+	default:
+		return 0, fmt.Errorf("%s of credential status is not defined", indexField)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+)
+
+const (
+	// https://w3c-ccg.github.io/vc-status-list-2021/#statuslist2021credential
+	statusList2021Context        = "https://w3id.org/vc/status-list/2021/v1"
+	statusList2021CredentialType = "StatusList2021Credential"
+)
+
+// StatusList is a StatusList2021 bitstring (https://w3c-ccg.github.io/vc-status-list-2021/) maintained
+// by the issuer to publish revocation status of the issued credentials. The credential is revoked by setting
+// the bit of its statusListIndex (see SetRevoked), then the status list credential is issued again
+// (see ToCredential) and published at URL of statusListCredential. The published list can be restored
+// by ParseStatusList. StatusList is safe for concurrent use.
+type StatusList struct {
+	mu   sync.Mutex
+	bits []byte
+	size int
+}
+
+// NewStatusList creates a new StatusList of the given number of entries, none of them is revoked.
+// The specification recommends at least 131072 entries (16KB bitstring), so the revoked credentials
+// can't be told by their index.
+func NewStatusList(size int) (*StatusList, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid size of status list: %d", size)
+	}
+
+	return &StatusList{
+		bits: make([]byte, (size+bitsPerByte-1)/bitsPerByte),
+		size: size,
+	}, nil
+}
+
+// ParseStatusList restores the StatusList from GZIP-compressed base64 encoded bitstring, i.e. "encodedList"
+// of the previously issued status list credential, so the issuer can continue to maintain it.
+// The size of the restored list is the length of the bitstring in bits.
+func ParseStatusList(encodedList string) (*StatusList, error) {
+	bits, err := decodeStatusList(encodedList)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bits) == 0 {
+		return nil, fmt.Errorf("%s is empty", encodedListField)
+	}
+
+	return &StatusList{
+		bits: bits,
+		size: len(bits) * bitsPerByte,
+	}, nil
+}
+
+// SetRevoked marks the credential with the given statusListIndex as revoked.
+func (l *StatusList) SetRevoked(index int) error {
+	if index < 0 || index >= l.size {
+		return fmt.Errorf("%s %d is out of status list range", statusListIndexField, index)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The first index is the left-most bit of the bitstring.
+	l.bits[index/bitsPerByte] |= 1 << (bitsPerByte - 1 - index%bitsPerByte)
+
+	return nil
+}
+
+// ToCredential issues the StatusList2021Credential with the current state of the status list as its
+// GZIP-compressed base64url encoded "encodedList". The id of the credential is URL where it is published
+// (statusListCredential of the issued credentials); the issuance date is set to the current time.
+//
+// The credential is signed with linked data proof defined by ldpContext. The proof is created with
+// SafeCanonicalization, so the JSON-LD document loader must provide StatusList2021 context
+// (https://w3id.org/vc/status-list/2021/v1), otherwise encodedList would not be signed.
+func (l *StatusList) ToCredential(id string, issuer Issuer, ldpContext *LinkedDataProofContext,
+	jsonldOpts ...jsonld.ProcessorOpts) (*Credential, error) {
+	if id == "" {
+		return nil, errors.New("id of status list credential is not defined")
+	}
+
+	if ldpContext == nil {
+		return nil, errors.New("linked data proof context is not defined")
+	}
+
+	encodedList, err := l.encode()
+	if err != nil {
+		return nil, fmt.Errorf("encode status list: %w", err)
+	}
+
+	vc := &Credential{
+		Context: []string{baseContext, statusList2021Context},
+		ID:      id,
+		Types:   []string{vcType, statusList2021CredentialType},
+		Issuer:  issuer,
+		Issued:  util.NewTime(now()),
+		Subject: []Subject{{
+			ID: id + "#list",
+			CustomFields: CustomFields{
+				"type":             statusList2021Type,
+				statusPurposeField: statusPurposeRevocation,
+				encodedListField:   encodedList,
+			},
+		}},
+	}
+
+	safeContext := *ldpContext
+	safeContext.SafeCanonicalization = true
+
+	if err = vc.AddLinkedDataProof(&safeContext, jsonldOpts...); err != nil {
+		return nil, fmt.Errorf("sign status list credential: %w", err)
+	}
+
+	return vc, nil
+}
+
+// encode returns base64url encoded GZIP-compressed bitstring (see decodeStatusList).
+func (l *StatusList) encode() (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write(l.bits); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	_ "embed"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+//go:embed testdata/context/status_list_2021_v1.jsonld
+var statusList2021V1 []byte //nolint:gochecknoglobals
+
+func TestNewStatusList_InvalidSize(t *testing.T) {
+	_, err := NewStatusList(0)
+	require.EqualError(t, err, "invalid size of status list: 0")
+}
+
+func TestStatusList_SetRevoked(t *testing.T) {
+	list, err := NewStatusList(20)
+	require.NoError(t, err)
+	require.Len(t, list.bits, 3)
+
+	require.NoError(t, list.SetRevoked(0))
+	require.NoError(t, list.SetRevoked(9))
+	require.NoError(t, list.SetRevoked(19))
+	require.Equal(t, []byte{0x80, 0x40, 0x10}, list.bits)
+
+	require.EqualError(t, list.SetRevoked(20), "statusListIndex 20 is out of status list range")
+	require.EqualError(t, list.SetRevoked(-1), "statusListIndex -1 is out of status list range")
+}
+
+func TestParseStatusList(t *testing.T) {
+	list, err := NewStatusList(20)
+	require.NoError(t, err)
+
+	require.NoError(t, list.SetRevoked(9))
+
+	encodedList, err := list.encode()
+	require.NoError(t, err)
+
+	restored, err := ParseStatusList(encodedList)
+	require.NoError(t, err)
+	require.Equal(t, list.bits, restored.bits)
+	require.Equal(t, 24, restored.size)
+
+	require.NoError(t, restored.SetRevoked(23))
+	require.Equal(t, []byte{0, 0x40, 0x01}, restored.bits)
+
+	t.Run("invalid encoded list", func(t *testing.T) {
+		_, err := ParseStatusList("!")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode encodedList")
+	})
+
+	t.Run("empty encoded list", func(t *testing.T) {
+		_, err := ParseStatusList(encodeStatusList(t, nil))
+		require.EqualError(t, err, "encodedList is empty")
+	})
+}
+
+func TestStatusList_ToCredential(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	loader := createTestDocumentLoader(t, ldcontext.Document{
+		URL:     "https://w3id.org/vc/status-list/2021/v1",
+		Content: statusList2021V1,
+	})
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:12345#key1",
+	}
+
+	list, err := NewStatusList(16 * 1024 * bitsPerByte)
+	require.NoError(t, err)
+
+	require.NoError(t, list.SetRevoked(3))
+
+	var listVCBytes []byte
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		_, err := res.Write(listVCBytes)
+		require.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	listVC, err := list.ToCredential(testServer.URL, Issuer{ID: "did:example:12345"}, ldpContext,
+		jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+	require.Equal(t, []string{"VerifiableCredential", "StatusList2021Credential"}, listVC.Types)
+	require.Len(t, listVC.Proofs, 1)
+	require.False(t, ldpContext.SafeCanonicalization)
+
+	listVCBytes, err = listVC.MarshalJSON()
+	require.NoError(t, err)
+
+	checker := NewStatusChecker(
+		WithStatusHTTPClient(testServer.Client()),
+		WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))))
+
+	newCredential := func(index string) *Credential {
+		return &Credential{
+			Issuer: Issuer{ID: "did:example:12345"},
+			Status: &TypedID{
+				Type: "StatusList2021Entry",
+				CustomFields: CustomFields{
+					"statusListIndex":      index,
+					"statusListCredential": testServer.URL,
+				},
+			},
+		}
+	}
+
+	results := checker.CheckBatch([]*Credential{newCredential("3"), newCredential("4")})
+	require.NoError(t, results[0].Err)
+	require.True(t, results[0].Revoked)
+	require.NoError(t, results[1].Err)
+	require.False(t, results[1].Revoked)
+
+	// the status list credential is issued again after revocation
+	require.NoError(t, list.SetRevoked(4))
+
+	listVC, err = list.ToCredential(testServer.URL, Issuer{ID: "did:example:12345"}, ldpContext,
+		jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	listVCBytes, err = listVC.MarshalJSON()
+	require.NoError(t, err)
+
+	revoked, err := NewStatusChecker(
+		WithStatusHTTPClient(testServer.Client()),
+		WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))).Check(newCredential("4"))
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	t.Run("id is not defined", func(t *testing.T) {
+		_, err := list.ToCredential("", Issuer{ID: "did:example:12345"}, ldpContext)
+		require.EqualError(t, err, "id of status list credential is not defined")
+	})
+
+	t.Run("linked data proof context is not defined", func(t *testing.T) {
+		_, err := list.ToCredential(testServer.URL, Issuer{ID: "did:example:12345"}, nil)
+		require.EqualError(t, err, "linked data proof context is not defined")
+	})
+}
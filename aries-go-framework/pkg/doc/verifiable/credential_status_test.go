@@ -0,0 +1,607 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestStatusChecker_CheckBatch(t *testing.T) {
+	loader := createStatusListDocumentLoader(t)
+
+	newStatusListServer := func(t *testing.T, listVC []byte, calls *int32) *httptest.Server {
+		t.Helper()
+
+		return httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(calls, 1)
+
+			_, err := res.Write(listVC)
+			require.NoError(t, err)
+		}))
+	}
+
+	newCredential := func(listURL string, index interface{}) *Credential {
+		return &Credential{
+			ID:     "http://example.edu/credentials/1872",
+			Issuer: Issuer{ID: "did:example:12345"},
+			Status: &TypedID{
+				ID:   listURL + "#94567",
+				Type: "StatusList2021Entry",
+				CustomFields: CustomFields{
+					"statusPurpose":        "revocation",
+					"statusListIndex":      index,
+					"statusListCredential": listURL,
+				},
+			},
+		}
+	}
+
+	t.Run("status list is loaded once", func(t *testing.T) {
+		var calls int32
+
+		testServer := newStatusListServer(t, createStatusListCredential(t, nil, 3, 17), &calls)
+		defer testServer.Close()
+
+		checker := NewStatusChecker(
+			WithStatusHTTPClient(testServer.Client()),
+			WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), withStatusListKey()))
+
+		creds := []*Credential{
+			newCredential(testServer.URL, "3"),
+			newCredential(testServer.URL, "4"),
+			newCredential(testServer.URL, 17.0),
+			newCredential(testServer.URL, "0"),
+		}
+
+		results := checker.CheckBatch(creds)
+		require.Len(t, results, len(creds))
+
+		for i, result := range results {
+			require.NoError(t, result.Err)
+			require.Equal(t, creds[i], result.Credential)
+		}
+
+		require.True(t, results[0].Revoked)
+		require.False(t, results[1].Revoked)
+		require.True(t, results[2].Revoked)
+		require.False(t, results[3].Revoked)
+
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("expired status list is loaded again", func(t *testing.T) {
+		var calls int32
+
+		expired := map[string]interface{}{
+			"expirationDate": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			"validUntil":     time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+		}
+
+		testServer := newStatusListServer(t, createStatusListCredential(t, expired, 1), &calls)
+		defer testServer.Close()
+
+		checker := NewStatusChecker(
+			WithStatusHTTPClient(testServer.Client()),
+			WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), withStatusListKey()))
+
+		results := checker.CheckBatch([]*Credential{
+			newCredential(testServer.URL, "1"),
+			newCredential(testServer.URL, "2"),
+		})
+		require.NoError(t, results[0].Err)
+		require.True(t, results[0].Revoked)
+		require.NoError(t, results[1].Err)
+		require.False(t, results[1].Revoked)
+
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("slow status list doesn't block other lists", func(t *testing.T) {
+		var slowCalls, calls int32
+
+		release := make(chan struct{})
+		listVC := createStatusListCredential(t, nil, 3)
+
+		slowServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&slowCalls, 1)
+			<-release
+
+			_, err := res.Write(listVC)
+			require.NoError(t, err)
+		}))
+		defer slowServer.Close()
+
+		// the handlers must be released for the server to be closed if the test fails
+		var releaseOnce sync.Once
+
+		releaseAll := func() { releaseOnce.Do(func() { close(release) }) }
+		defer releaseAll()
+
+		testServer := newStatusListServer(t, listVC, &calls)
+		defer testServer.Close()
+
+		checker := NewStatusChecker(WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), withStatusListKey()))
+
+		const slowChecks = 3
+
+		slowResults := make(chan error, slowChecks)
+
+		for i := 0; i < slowChecks; i++ {
+			go func() {
+				_, err := checker.Check(newCredential(slowServer.URL, "3"))
+				slowResults <- err
+			}()
+		}
+
+		require.Eventually(t, func() bool {
+			return atomic.LoadInt32(&slowCalls) == 1
+		}, time.Second, time.Millisecond)
+
+		// the slow status list is being loaded
+		revoked, err := checker.Check(newCredential(testServer.URL, "3"))
+		require.NoError(t, err)
+		require.True(t, revoked)
+
+		releaseAll()
+
+		for i := 0; i < slowChecks; i++ {
+			require.NoError(t, <-slowResults)
+		}
+
+		// the concurrent checks share the single load
+		require.EqualValues(t, 1, atomic.LoadInt32(&slowCalls))
+	})
+
+	t.Run("per-credential errors", func(t *testing.T) {
+		var calls int32
+
+		testServer := newStatusListServer(t, createStatusListCredential(t, nil, 1), &calls)
+		defer testServer.Close()
+
+		checker := NewStatusChecker(
+			WithStatusHTTPClient(testServer.Client()),
+			WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), withStatusListKey()))
+
+		results := checker.CheckBatch([]*Credential{
+			{},
+			{Status: &TypedID{Type: "CredentialStatusList2017"}},
+			newCredential(testServer.URL, "-1"),
+			newCredential(testServer.URL, 1.5),
+			newCredential(testServer.URL, nil),
+			newCredential("", "1"),
+			newCredential(testServer.URL, "100000000"),
+			newCredential(testServer.URL, "1"),
+		})
+
+		require.EqualError(t, results[0].Err, "credential status is not defined")
+		require.EqualError(t, results[1].Err, "unsupported credential status type: CredentialStatusList2017")
+		require.EqualError(t, results[2].Err, "invalid statusListIndex of credential status: -1")
+		require.EqualError(t, results[3].Err, "invalid statusListIndex of credential status: 1.5")
+		require.EqualError(t, results[4].Err, "statusListIndex of credential status is not defined")
+		require.EqualError(t, results[5].Err, "statusListCredential of credential status is not defined")
+		require.EqualError(t, results[6].Err, "statusListIndex 100000000 is out of status list range")
+		require.NoError(t, results[7].Err)
+		require.True(t, results[7].Revoked)
+	})
+
+	t.Run("status list of other issuer or purpose", func(t *testing.T) {
+		var calls int32
+
+		testServer := newStatusListServer(t, createStatusListCredential(t, nil, 1), &calls)
+		defer testServer.Close()
+
+		checker := NewStatusChecker(
+			WithStatusHTTPClient(testServer.Client()),
+			WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), withStatusListKey()))
+
+		otherIssuer := newCredential(testServer.URL, "1")
+		otherIssuer.Issuer.ID = "did:example:other"
+
+		suspension := newCredential(testServer.URL, "1")
+		suspension.Status.CustomFields["statusPurpose"] = "suspension"
+
+		noPurpose := newCredential(testServer.URL, "1")
+		delete(noPurpose.Status.CustomFields, "statusPurpose")
+
+		results := checker.CheckBatch([]*Credential{otherIssuer, suspension, noPurpose})
+
+		require.EqualError(t, results[0].Err,
+			"issuer did:example:12345 of status list credential doesn't match issuer did:example:other of credential")
+		require.False(t, results[0].Revoked)
+		require.EqualError(t, results[1].Err,
+			"statusPurpose suspension of credential status doesn't match statusPurpose revocation of status list credential")
+		require.False(t, results[1].Revoked)
+
+		// the revocation purpose is implied
+		require.NoError(t, results[2].Err)
+		require.True(t, results[2].Revoked)
+	})
+
+	t.Run("status list cannot be loaded", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.WriteHeader(http.StatusNotFound)
+		}))
+		defer testServer.Close()
+
+		revoked, err := NewStatusChecker().Check(newCredential(testServer.URL, "1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status list credential endpoint HTTP failure [404]")
+		require.False(t, revoked)
+
+		revoked, err = NewStatusChecker().Check(newCredential("http://localhost:1", "1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "load status list credential")
+		require.False(t, revoked)
+	})
+
+	t.Run("invalid status list credential", func(t *testing.T) {
+		var calls int32
+
+		testServer := newStatusListServer(t, []byte("not a credential"), &calls)
+		defer testServer.Close()
+
+		revoked, err := NewStatusChecker().Check(newCredential(testServer.URL, "1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse status list credential")
+		require.False(t, revoked)
+	})
+
+	t.Run("unsigned status list credential", func(t *testing.T) {
+		var listVC map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(createStatusListCredential(t, nil, 1), &listVC))
+		delete(listVC, "proof")
+
+		unsignedListVC, err := json.Marshal(listVC)
+		require.NoError(t, err)
+
+		var calls int32
+
+		testServer := newStatusListServer(t, unsignedListVC, &calls)
+		defer testServer.Close()
+
+		revoked, err := NewStatusChecker(
+			WithStatusHTTPClient(testServer.Client()),
+			WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), withStatusListKey()),
+		).Check(newCredential(testServer.URL, "1"))
+		require.ErrorIs(t, err, ErrProofMissing)
+		require.False(t, revoked)
+
+		// the proof is required without the options too
+		revoked, err = NewStatusChecker(WithStatusHTTPClient(testServer.Client())).Check(newCredential(testServer.URL, "1"))
+		require.ErrorIs(t, err, ErrProofMissing)
+		require.False(t, revoked)
+
+		// the proof is required even if its check is disabled
+		revoked, err = NewStatusChecker(
+			WithStatusHTTPClient(testServer.Client()),
+			WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), WithDisabledProofCheck()),
+		).Check(newCredential(testServer.URL, "1"))
+		require.ErrorIs(t, err, ErrProofMissing)
+		require.False(t, revoked)
+	})
+
+	t.Run("status list credential signed by other key", func(t *testing.T) {
+		var calls int32
+
+		testServer := newStatusListServer(t, createStatusListCredential(t, nil, 1), &calls)
+		defer testServer.Close()
+
+		otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		revoked, err := NewStatusChecker(
+			WithStatusHTTPClient(testServer.Client()),
+			WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader),
+				WithPublicKeyFetcher(SingleKey(otherKey, kms.ED25519))),
+		).Check(newCredential(testServer.URL, "1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+		require.False(t, revoked)
+	})
+
+	t.Run("status list credential is too large", func(t *testing.T) {
+		var calls int32
+
+		testServer := newStatusListServer(t, make([]byte, maxStatusListCredentialSize+1), &calls)
+		defer testServer.Close()
+
+		revoked, err := NewStatusChecker().Check(newCredential(testServer.URL, "1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status list credential: read response body: "+
+			"data exceeds the limit of 4194304 bytes")
+		require.False(t, revoked)
+	})
+}
+
+func TestStatusChecker_RevocationList2020(t *testing.T) {
+	loader := createStatusListDocumentLoader(t)
+
+	listVC := createStatusListCredential(t, nil, 5)
+
+	revocationListVC := createStatusListCredential(t, map[string]interface{}{
+		"@context": []string{"https://www.w3.org/2018/credentials/v1", "https://w3id.org/vc-revocation-list-2020/v1"},
+		"type":     []string{"VerifiableCredential", "RevocationList2020Credential"},
+		"credentialSubject": map[string]interface{}{
+			"id":          "https://example.com/status/3#list",
+			"type":        "RevocationList2020",
+			"encodedList": encodeStatusList(t, statusListBits(5)),
+		},
+	})
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/status-list-2021" {
+			_, err := res.Write(listVC)
+			require.NoError(t, err)
+
+			return
+		}
+
+		_, err := res.Write(revocationListVC)
+		require.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	newCredential := func(listURL, index string) *Credential {
+		return &Credential{
+			ID:     "http://example.edu/credentials/1872",
+			Issuer: Issuer{ID: "did:example:12345"},
+			Status: &TypedID{
+				ID:   listURL + "#" + index,
+				Type: "RevocationList2020Status",
+				CustomFields: CustomFields{
+					"revocationListIndex":      index,
+					"revocationListCredential": listURL,
+				},
+			},
+		}
+	}
+
+	checker := NewStatusChecker(
+		WithStatusHTTPClient(testServer.Client()),
+		WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), withStatusListKey()))
+
+	statusList2021VC := &Credential{
+		Issuer: Issuer{ID: "did:example:12345"},
+		Status: &TypedID{
+			Type: "StatusList2021Entry",
+			CustomFields: CustomFields{
+				"statusListIndex":      "5",
+				"statusListCredential": testServer.URL + "/status-list-2021",
+			},
+		},
+	}
+
+	results := checker.CheckBatch([]*Credential{
+		newCredential(testServer.URL, "5"),
+		newCredential(testServer.URL, "6"),
+		statusList2021VC,
+		{Status: &TypedID{Type: "RevocationList2020Status", CustomFields: CustomFields{"revocationListIndex": "1"}}},
+		newCredential(testServer.URL+"/status-list-2021", "5"),
+	})
+
+	require.NoError(t, results[0].Err)
+	require.True(t, results[0].Revoked)
+	require.Equal(t, "RevocationList2020Status", results[0].StatusType)
+
+	require.NoError(t, results[1].Err)
+	require.False(t, results[1].Revoked)
+
+	// both status formats coexist
+	require.NoError(t, results[2].Err)
+	require.True(t, results[2].Revoked)
+	require.Equal(t, "StatusList2021Entry", results[2].StatusType)
+
+	require.EqualError(t, results[3].Err, "revocationListCredential of credential status is not defined")
+
+	// StatusList2021 credential is not accepted as RevocationList2020 one, even if it is cached
+	require.Error(t, results[4].Err)
+	require.Contains(t, results[4].Err.Error(), "unsupported subject type: StatusList2021")
+
+	t.Run("custom status list format", func(t *testing.T) {
+		checker := NewStatusChecker(
+			WithStatusHTTPClient(testServer.Client()),
+			WithStatusCredentialOpts(WithJSONLDDocumentLoader(loader), withStatusListKey()),
+			WithStatusListFormat("CustomStatus", StatusListFormat{
+				IndexField:          "index",
+				ListCredentialField: "list",
+				ListType:            "RevocationList2020",
+			}))
+
+		revoked, err := checker.Check(&Credential{
+			Issuer: Issuer{ID: "did:example:12345"},
+			Status: &TypedID{
+				Type:         "CustomStatus",
+				CustomFields: CustomFields{"index": "5", "list": testServer.URL},
+			},
+		})
+		require.NoError(t, err)
+		require.True(t, revoked)
+	})
+}
+
+func TestNewStatusList(t *testing.T) {
+	statusList2021Format := defaultStatusListFormats()["StatusList2021Entry"]
+
+	t.Run("invalid subject", func(t *testing.T) {
+		_, err := newStatusList(&Credential{Subject: map[string]interface{}{"type": "Unknown"}}, statusList2021Format)
+		require.EqualError(t, err, "status list credential: unsupported subject type: Unknown")
+
+		_, err = newStatusList(&Credential{Subject: map[string]interface{}{"type": "StatusList2021"}}, statusList2021Format)
+		require.EqualError(t, err, "status list credential: encodedList is not defined")
+
+		_, err = newStatusList(&Credential{Subject: map[string]interface{}{
+			"type":        "StatusList2021",
+			"encodedList": "!!!",
+		}}, statusList2021Format)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status list credential: decode encodedList")
+
+		_, err = newStatusList(&Credential{Subject: map[string]interface{}{
+			"type":        "StatusList2021",
+			"encodedList": base64.RawURLEncoding.EncodeToString([]byte("not gzip")),
+		}}, statusList2021Format)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status list credential: decompress encodedList")
+
+		_, err = newStatusList(&Credential{}, statusList2021Format)
+		require.EqualError(t, err, "status list credential: no subject is defined")
+	})
+
+	t.Run("invalid validUntil", func(t *testing.T) {
+		_, err := newStatusList(&Credential{
+			Subject: map[string]interface{}{
+				"type":        "StatusList2021",
+				"encodedList": encodeStatusList(t, make([]byte, 16)),
+			},
+			CustomFields: CustomFields{"validUntil": "tomorrow"},
+		}, statusList2021Format)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "status list credential: parse validUntil")
+	})
+
+	t.Run("padded base64 encoding", func(t *testing.T) {
+		list, err := newStatusList(&Credential{
+			Subject: map[string]interface{}{
+				"type":        "StatusList2021",
+				"encodedList": base64.StdEncoding.EncodeToString(gzipBytes(t, []byte{0x80})),
+			},
+		}, statusList2021Format)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x80}, list.bits)
+		require.Nil(t, list.expires)
+	})
+
+	t.Run("decompressed status list is too large", func(t *testing.T) {
+		_, err := newStatusList(&Credential{
+			Subject: map[string]interface{}{
+				"type":        "StatusList2021",
+				"encodedList": encodeStatusList(t, make([]byte, maxStatusListSize+1)),
+			},
+		}, statusList2021Format)
+		require.EqualError(t, err, "status list credential: decompress encodedList: "+
+			"data exceeds the limit of 16777216 bytes")
+	})
+}
+
+// statusListKey is the key of the issuer of the test status list credentials.
+var statusListKey = ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize)) //nolint:gochecknoglobals
+
+// withStatusListKey is the credential option to check the proofs of the test status list credentials.
+func withStatusListKey() CredentialOpt {
+	return WithPublicKeyFetcher(SingleKey(statusListKey.Public().(ed25519.PublicKey), kms.ED25519))
+}
+
+func createStatusListDocumentLoader(t *testing.T) *ld.DocumentLoader {
+	t.Helper()
+
+	return createTestDocumentLoader(t, ldcontext.Document{
+		URL:     "https://w3id.org/vc/status-list/2021/v1",
+		Content: statusList2021V1,
+	})
+}
+
+// createStatusListCredential creates StatusList2021Credential with the revoked indexes which is signed
+// by statusListKey. The fields override the ones of the credential before it's signed.
+func createStatusListCredential(t *testing.T, fields map[string]interface{}, revoked ...int) []byte {
+	t.Helper()
+
+	listVC := map[string]interface{}{
+		"@context":     []string{"https://www.w3.org/2018/credentials/v1", "https://w3id.org/vc/status-list/2021/v1"},
+		"id":           "https://example.com/credentials/status/3",
+		"type":         []string{"VerifiableCredential", "StatusList2021Credential"},
+		"issuer":       "did:example:12345",
+		"issuanceDate": "2021-04-05T14:27:40Z",
+		"credentialSubject": map[string]interface{}{
+			"id":            "https://example.com/status/3#list",
+			"type":          "StatusList2021",
+			"statusPurpose": "revocation",
+			"encodedList":   encodeStatusList(t, statusListBits(revoked...)),
+		},
+	}
+
+	for k, v := range fields {
+		listVC[k] = v
+	}
+
+	return signStatusListCredential(t, listVC)
+}
+
+func signStatusListCredential(t *testing.T, listVC map[string]interface{}) []byte {
+	t.Helper()
+
+	listVCBytes, err := json.Marshal(listVC)
+	require.NoError(t, err)
+
+	loader := createStatusListDocumentLoader(t)
+
+	vc, err := ParseCredential(listVCBytes, WithJSONLDDocumentLoader(loader), WithDisabledProofCheck())
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite: ed25519signature2018.New(suite.WithSigner(
+			signature.GetEd25519Signer(statusListKey, statusListKey.Public().(ed25519.PublicKey)))),
+		VerificationMethod: "did:example:12345#key1",
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	listVCBytes, err = vc.MarshalJSON()
+	require.NoError(t, err)
+
+	return listVCBytes
+}
+
+func statusListBits(revoked ...int) []byte {
+	bits := make([]byte, 16*1024)
+	for _, i := range revoked {
+		bits[i/8] |= 1 << (7 - i%8)
+	}
+
+	return bits
+}
+
+func encodeStatusList(t *testing.T, bits []byte) string {
+	t.Helper()
+
+	return base64.RawURLEncoding.EncodeToString(gzipBytes(t, bits))
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+)
+
+// subjectIDPolicy defines whether the credential subjects must or must not have id.
+type subjectIDPolicy int
+
+const (
+	// subjectIDOptional does not check ids of the credential subjects (default).
+	subjectIDOptional subjectIDPolicy = iota
+
+	// subjectIDRequired requires every credential subject to have id, e.g. to bind the credential to the holder.
+	subjectIDRequired
+
+	// subjectIDForbidden requires credential subjects to have no id, e.g. for bearer credentials.
+	subjectIDForbidden
+)
+
+// WithRequireSubjectID option rejects the credential if it has no credentialSubject or any of its subjects
+// has no id (e.g. the credential is bound to the holder by the subject id). It overrides WithForbidSubjectID.
+// The check is skipped if validation is disabled (see WithCredentialNoValidation).
+func WithRequireSubjectID() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.subjectIDPolicy = subjectIDRequired
+	}
+}
+
+// WithForbidSubjectID option rejects the credential if any of its subjects has id (e.g. bearer credential).
+// It overrides WithRequireSubjectID.
+// The check is skipped if validation is disabled (see WithCredentialNoValidation).
+func WithForbidSubjectID() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.subjectIDPolicy = subjectIDForbidden
+	}
+}
+
+// checkSubjectIDs checks ids of the credential subjects according to the policy.
+func (vc *Credential) checkSubjectIDs(policy subjectIDPolicy) error {
+	if policy == subjectIDOptional {
+		return nil
+	}
+
+	ids, err := subjectIDs(vc.Subject)
+	if err != nil {
+		return err
+	}
+
+	if policy == subjectIDRequired && len(ids) == 0 {
+		return errors.New("credentialSubject with id is required")
+	}
+
+	for i, id := range ids {
+		switch {
+		case policy == subjectIDRequired && id == "":
+			return fmt.Errorf("credentialSubject[%d] id is required", i)
+		case policy == subjectIDForbidden && id != "":
+			return fmt.Errorf("credentialSubject[%d] id is not allowed: %s", i, id)
+		}
+	}
+
+	return nil
+}
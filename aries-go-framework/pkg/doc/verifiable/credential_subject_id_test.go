@@ -0,0 +1,87 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequireSubjectID(t *testing.T) {
+	t.Run("subject has id", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential), WithRequireSubjectID())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("subject has no id", func(t *testing.T) {
+		vcBytes := credentialWithSubject(t, []interface{}{
+			map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+			map[string]interface{}{"name": "Jayden Doe"},
+		})
+
+		_, err := parseTestCredential(t, vcBytes, WithRequireSubjectID())
+		require.EqualError(t, err, "credentialSubject[1] id is required")
+
+		// the check is made during validation only
+		_, err = parseTestCredential(t, vcBytes, WithRequireSubjectID(), WithCredentialNoValidation())
+		require.NoError(t, err)
+	})
+
+	t.Run("subject is not defined", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Subject = nil
+
+		require.EqualError(t, vc.checkSubjectIDs(subjectIDRequired), "credentialSubject with id is required")
+	})
+
+	t.Run("overridden by WithForbidSubjectID", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential), WithRequireSubjectID(), WithForbidSubjectID())
+		require.EqualError(t, err,
+			"credentialSubject[0] id is not allowed: did:example:ebfeb1f712ebc6f1c276e12ec21")
+	})
+}
+
+func TestWithForbidSubjectID(t *testing.T) {
+	t.Run("subject has no id", func(t *testing.T) {
+		vcBytes := credentialWithSubject(t, map[string]interface{}{"name": "Jayden Doe"})
+
+		vc, err := parseTestCredential(t, vcBytes, WithForbidSubjectID())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("subject has id", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential), WithForbidSubjectID())
+		require.EqualError(t, err,
+			"credentialSubject[0] id is not allowed: did:example:ebfeb1f712ebc6f1c276e12ec21")
+	})
+
+	t.Run("overridden by WithRequireSubjectID", func(t *testing.T) {
+		vcBytes := credentialWithSubject(t, map[string]interface{}{"name": "Jayden Doe"})
+
+		_, err := parseTestCredential(t, vcBytes, WithForbidSubjectID(), WithRequireSubjectID())
+		require.EqualError(t, err, "credentialSubject[0] id is required")
+	})
+}
+
+func credentialWithSubject(t *testing.T, subject interface{}) []byte {
+	t.Helper()
+
+	vcMap, err := toMap(validCredential)
+	require.NoError(t, err)
+
+	vcMap["credentialSubject"] = subject
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	return vcBytes
+}
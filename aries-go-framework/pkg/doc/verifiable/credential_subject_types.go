@@ -0,0 +1,96 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+//nolint:gochecknoglobals
+var subjectTypes = struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}{
+	types: map[string]reflect.Type{},
+}
+
+// RegisterSubjectType registers the Go type of the credential subject for the credentials of the given type
+// (e.g. "UniversityDegreeCredential"). proto is a value of the type or a pointer to it (e.g. DegreeSubject{}
+// or &DegreeSubject{}). The subject of the credential of this type is unmarshalled into a new value of the type
+// by ParseCredential (see Credential.TypedSubject). The type registered for the credential type before is
+// replaced, nil proto removes the registration.
+func RegisterSubjectType(credentialType string, proto interface{}) {
+	subjectTypes.Lock()
+	defer subjectTypes.Unlock()
+
+	if proto == nil {
+		delete(subjectTypes.types, credentialType)
+
+		return
+	}
+
+	t := reflect.TypeOf(proto)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	subjectTypes.types[credentialType] = t
+}
+
+func getSubjectType(credentialTypes []string) (reflect.Type, bool) {
+	subjectTypes.RLock()
+	defer subjectTypes.RUnlock()
+
+	for _, credentialType := range credentialTypes {
+		if t, ok := subjectTypes.types[credentialType]; ok {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+// TypedSubject returns the subject unmarshalled into the Go type registered by RegisterSubjectType for the type
+// of the credential (the first of the credential types having the registered Go type is used). It's a pointer
+// to the value of the registered type, or a slice of the values if the credential has several subjects.
+// nil is returned if no Go type is registered for the credential types. The typed subject is created when
+// the credential is parsed, so it's not updated if Subject is changed.
+func (vc *Credential) TypedSubject() interface{} {
+	return vc.typedSubject
+}
+
+// decodeTypedSubject unmarshals the subject into the Go type registered for the credential types.
+// The subject defined as a string (subject ID) is not unmarshalled.
+func decodeTypedSubject(credentialTypes []string, subjectBytes json.RawMessage) (interface{}, error) {
+	if len(subjectBytes) == 0 || (subjectBytes[0] != '{' && subjectBytes[0] != '[') {
+		return nil, nil
+	}
+
+	t, ok := getSubjectType(credentialTypes)
+	if !ok {
+		return nil, nil
+	}
+
+	isArray := subjectBytes[0] == '['
+
+	typedSubject := reflect.New(t)
+	if isArray {
+		typedSubject = reflect.New(reflect.SliceOf(t))
+	}
+
+	if err := json.Unmarshal(subjectBytes, typedSubject.Interface()); err != nil {
+		return nil, fmt.Errorf("unmarshal subject into %s: %w", t, err)
+	}
+
+	if isArray {
+		return typedSubject.Elem().Interface(), nil
+	}
+
+	return typedSubject.Interface(), nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type degreeSubject struct {
+	ID     string `json:"id"`
+	Degree struct {
+		Type string `json:"type"`
+	} `json:"degree"`
+}
+
+func TestRegisterSubjectType(t *testing.T) {
+	newVCBytes := func(t *testing.T, subject interface{}) []byte {
+		t.Helper()
+
+		var vcMap map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(credentialWithLangStrings), &vcMap))
+
+		if subject != nil {
+			vcMap["credentialSubject"] = subject
+		}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	t.Run("no subject type registered", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, nil))
+		require.NoError(t, err)
+		require.Nil(t, vc.TypedSubject())
+	})
+
+	RegisterSubjectType("UniversityDegreeCredential", degreeSubject{})
+	defer RegisterSubjectType("UniversityDegreeCredential", nil)
+
+	t.Run("single subject", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, nil))
+		require.NoError(t, err)
+
+		subject, ok := vc.TypedSubject().(*degreeSubject)
+		require.True(t, ok)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subject.ID)
+		require.Equal(t, "BachelorDegree", subject.Degree.Type)
+	})
+
+	t.Run("several subjects", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, []interface{}{
+			map[string]interface{}{"id": "did:example:1", "degree": map[string]interface{}{"type": "BachelorDegree"}},
+			map[string]interface{}{"id": "did:example:2", "degree": map[string]interface{}{"type": "MasterDegree"}},
+		}))
+		require.NoError(t, err)
+
+		subjects, ok := vc.TypedSubject().([]degreeSubject)
+		require.True(t, ok)
+		require.Len(t, subjects, 2)
+		require.Equal(t, "did:example:2", subjects[1].ID)
+		require.Equal(t, "MasterDegree", subjects[1].Degree.Type)
+	})
+
+	t.Run("subject ID only", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, "did:example:ebfeb1f712ebc6f1c276e12ec21"),
+			WithDisabledProofCheck(), WithNoCustomSchemaCheck(), WithJSONLDValidation())
+		require.NoError(t, err)
+		require.Nil(t, vc.TypedSubject())
+	})
+
+	t.Run("subject does not match the registered type", func(t *testing.T) {
+		_, err := parseTestCredential(t, newVCBytes(t, map[string]interface{}{
+			"id":     "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"degree": "BachelorDegree",
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "build new credential: unmarshal subject into verifiable.degreeSubject")
+	})
+
+	t.Run("registered as a pointer", func(t *testing.T) {
+		RegisterSubjectType("UniversityDegreeCredential", &degreeSubject{})
+
+		vc, err := parseTestCredential(t, newVCBytes(t, nil))
+		require.NoError(t, err)
+		require.IsType(t, &degreeSubject{}, vc.TypedSubject())
+	})
+}
@@ -0,0 +1,175 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+)
+
+// PlaceholderType defines a type of the value substituted for the placeholder of SubjectTemplate.
+type PlaceholderType string
+
+const (
+	// PlaceholderString is a type of string placeholder value.
+	PlaceholderString PlaceholderType = "string"
+	// PlaceholderNumber is a type of numeric placeholder value.
+	PlaceholderNumber PlaceholderType = "number"
+	// PlaceholderBoolean is a type of boolean placeholder value.
+	PlaceholderBoolean PlaceholderType = "boolean"
+	// PlaceholderObject is a type of object (map or struct) placeholder value.
+	PlaceholderObject PlaceholderType = "object"
+	// PlaceholderArray is a type of array placeholder value.
+	PlaceholderArray PlaceholderType = "array"
+)
+
+// SubjectTemplate is a template of the Verifiable Credentials which differ only in the subject values
+// (not to be confused with CredentialTemplate factory method).
+// The fixed parts of the credential (contexts, types, issuer, schemas) are defined by the template,
+// the subject values are substituted for the placeholders of the subject template by Issue.
+type SubjectTemplate struct {
+	Context       []string
+	CustomContext []interface{}
+	Types         []string
+	Issuer        Issuer
+	Schemas       []TypedID
+
+	// Subject is a template of the credential subject. The string value of form "{{name}}" is a placeholder
+	// which is replaced by the subject value of the same name (the value can be of any type).
+	Subject map[string]interface{}
+
+	// Placeholders defines the types of the placeholder values. The placeholder which is not defined here
+	// accepts a value of any type.
+	Placeholders map[string]PlaceholderType
+
+	// ValidityPeriod defines expiration date of the issued credential (relative to the issuance date), optional.
+	ValidityPeriod time.Duration
+}
+
+// Issue creates a new Verifiable Credential from the template, substituting the subject values for the
+// placeholders. Every placeholder of the subject template must have a value of the declared type.
+// The issuance date of the credential is set to the current time.
+func (tmpl *SubjectTemplate) Issue(subjectValues map[string]interface{}) (*Credential, error) {
+	for name, value := range subjectValues {
+		if err := checkPlaceholderValue(tmpl.Placeholders[name], value); err != nil {
+			return nil, fmt.Errorf("subject value %s: %w", name, err)
+		}
+	}
+
+	subject, err := expandTemplateValue(tmpl.Subject, subjectValues)
+	if err != nil {
+		return nil, fmt.Errorf("expand credential subject template: %w", err)
+	}
+
+	issued := now()
+
+	vc := &Credential{
+		Context:       append([]string(nil), tmpl.Context...),
+		CustomContext: append([]interface{}(nil), tmpl.CustomContext...),
+		Types:         append([]string(nil), tmpl.Types...),
+		Subject:       subject,
+		Issuer:        tmpl.Issuer,
+		Issued:        util.NewTime(issued),
+		Schemas:       append([]TypedID(nil), tmpl.Schemas...),
+	}
+
+	if tmpl.ValidityPeriod > 0 {
+		vc.Expired = util.NewTime(issued.Add(tmpl.ValidityPeriod))
+	}
+
+	return vc, nil
+}
+
+// expandTemplateValue makes a copy of the template value replacing placeholders by the subject values.
+func expandTemplateValue(v interface{}, values map[string]interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(value))
+
+		for k, item := range value {
+			expandedItem, err := expandTemplateValue(item, values)
+			if err != nil {
+				return nil, err
+			}
+
+			expanded[k] = expandedItem
+		}
+
+		return expanded, nil
+
+	case []interface{}:
+		expanded := make([]interface{}, len(value))
+
+		for i, item := range value {
+			expandedItem, err := expandTemplateValue(item, values)
+			if err != nil {
+				return nil, err
+			}
+
+			expanded[i] = expandedItem
+		}
+
+		return expanded, nil
+
+	case string:
+		name, ok := placeholderName(value)
+		if !ok {
+			return value, nil
+		}
+
+		subjectValue, ok := values[name]
+		if !ok {
+			return nil, fmt.Errorf("value of placeholder %s is not defined", name)
+		}
+
+		return subjectValue, nil
+
+	default:
+		return value, nil
+	}
+}
+
+func placeholderName(s string) (string, bool) {
+	if !strings.HasPrefix(s, "{{") || !strings.HasSuffix(s, "}}") {
+		return "", false
+	}
+
+	return strings.TrimSpace(s[2 : len(s)-2]), true
+}
+
+func checkPlaceholderValue(t PlaceholderType, value interface{}) error {
+	if t == "" {
+		return nil
+	}
+
+	var kindMatches bool
+
+	switch kind := reflect.ValueOf(value).Kind(); t {
+	case PlaceholderString:
+		kindMatches = kind == reflect.String
+	case PlaceholderNumber:
+		kindMatches = kind >= reflect.Int && kind <= reflect.Float64 && kind != reflect.Uintptr
+	case PlaceholderBoolean:
+		kindMatches = kind == reflect.Bool
+	case PlaceholderObject:
+		kindMatches = kind == reflect.Map || kind == reflect.Struct ||
+			(kind == reflect.Ptr && reflect.ValueOf(value).Elem().Kind() == reflect.Struct)
+	case PlaceholderArray:
+		kindMatches = kind == reflect.Slice || kind == reflect.Array
+	default:
+		return fmt.Errorf("unsupported placeholder type %s", t)
+	}
+
+	if !kindMatches {
+		return fmt.Errorf("%T is not of %s type", value, t)
+	}
+
+	return nil
+}
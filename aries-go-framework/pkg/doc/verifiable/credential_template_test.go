@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubjectTemplate_Issue(t *testing.T) {
+	tmpl := &SubjectTemplate{
+		Context: []string{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://www.w3.org/2018/credentials/examples/v1",
+		},
+		Types:  []string{"VerifiableCredential", "UniversityDegreeCredential"},
+		Issuer: Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Subject: map[string]interface{}{
+			"id": "{{id}}",
+			"degree": map[string]interface{}{
+				"type":       "BachelorDegree",
+				"degreeType": "{{ degreeType }}",
+			},
+			"alumniOf": []interface{}{"{{college}}"},
+		},
+		Placeholders: map[string]PlaceholderType{
+			"id":         PlaceholderString,
+			"degreeType": PlaceholderString,
+		},
+		ValidityPeriod: 24 * time.Hour,
+	}
+
+	t.Run("issue credentials", func(t *testing.T) {
+		vc, err := tmpl.Issue(map[string]interface{}{
+			"id":         "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"degreeType": "Bachelor of Science and Arts",
+			"college":    map[string]interface{}{"id": "did:example:c276e12ec21ebfeb1f712ebc6f1"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, tmpl.Context, vc.Context)
+		require.Equal(t, tmpl.Types, vc.Types)
+		require.Equal(t, tmpl.Issuer, vc.Issuer)
+		require.NotNil(t, vc.Issued)
+		require.Equal(t, vc.Issued.Time.Add(24*time.Hour), vc.Expired.Time)
+
+		degreeType, err := vc.GetSubjectValue("/degree/degreeType")
+		require.NoError(t, err)
+		require.Equal(t, "Bachelor of Science and Arts", degreeType)
+
+		collegeID, err := vc.GetSubjectValue("/alumniOf/0/id")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:c276e12ec21ebfeb1f712ebc6f1", collegeID)
+
+		// the issued credential is valid
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+
+		// the template is not changed
+		require.Equal(t, "{{ degreeType }}", tmpl.Subject["degree"].(map[string]interface{})["degreeType"])
+
+		other, err := tmpl.Issue(map[string]interface{}{
+			"id":         "did:example:other",
+			"degreeType": "Bachelor of Laws",
+			"college":    "did:example:college",
+		})
+		require.NoError(t, err)
+
+		degreeType, err = other.GetSubjectValue("/degree/degreeType")
+		require.NoError(t, err)
+		require.Equal(t, "Bachelor of Laws", degreeType)
+	})
+
+	t.Run("subject value is not defined", func(t *testing.T) {
+		vc, err := tmpl.Issue(map[string]interface{}{
+			"id":      "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"college": "did:example:college",
+		})
+		require.EqualError(t, err, "expand credential subject template: value of placeholder degreeType is not defined")
+		require.Nil(t, vc)
+	})
+
+	t.Run("subject value of invalid type", func(t *testing.T) {
+		vc, err := tmpl.Issue(map[string]interface{}{
+			"id":         42,
+			"degreeType": "Bachelor of Laws",
+			"college":    "did:example:college",
+		})
+		require.EqualError(t, err, "subject value id: int is not of string type")
+		require.Nil(t, vc)
+	})
+}
+
+func TestCheckPlaceholderValue(t *testing.T) {
+	require.NoError(t, checkPlaceholderValue("", nil))
+	require.NoError(t, checkPlaceholderValue(PlaceholderString, "value"))
+	require.NoError(t, checkPlaceholderValue(PlaceholderNumber, 42))
+	require.NoError(t, checkPlaceholderValue(PlaceholderNumber, 4.2))
+	require.NoError(t, checkPlaceholderValue(PlaceholderBoolean, true))
+	require.NoError(t, checkPlaceholderValue(PlaceholderObject, map[string]interface{}{}))
+	require.NoError(t, checkPlaceholderValue(PlaceholderObject, &TypedID{}))
+	require.NoError(t, checkPlaceholderValue(PlaceholderArray, []string{"value"}))
+
+	require.EqualError(t, checkPlaceholderValue(PlaceholderNumber, "42"), "string is not of number type")
+	require.EqualError(t, checkPlaceholderValue(PlaceholderBoolean, nil), "<nil> is not of boolean type")
+	require.EqualError(t, checkPlaceholderValue("date", "2010-01-01"), "unsupported placeholder type date")
+}
@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -17,10 +18,14 @@ import (
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/embed"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -100,7 +105,14 @@ func TestParseCredential(t *testing.T) {
 		require.Equal(t, "https://example.edu/refresh/3732", vc.RefreshService[0].ID)
 		require.Equal(t, "ManualRefreshService2018", vc.RefreshService[0].Type)
 
-		require.NotNil(t, vc.Evidence)
+		// check evidence
+		require.Len(t, vc.Evidence, 2)
+		require.Equal(t, "https://example.edu/evidence/f2aeec97-fc0d-42bf-8ca7-0548192d4231", vc.Evidence[0].ID)
+		require.Equal(t, "DocumentVerification", vc.Evidence[0].Type)
+		require.Equal(t, "https://example.edu/issuers/14", vc.Evidence[0].CustomFields["verifier"])
+		require.Equal(t, "DriversLicense", vc.Evidence[0].CustomFields["evidenceDocument"])
+		require.Equal(t, "Physical", vc.Evidence[0].CustomFields["documentPresence"])
+		require.Equal(t, "Digital", vc.Evidence[1].CustomFields["subjectPresence"])
 
 		require.NotNil(t, vc.TermsOfUse)
 		require.Len(t, vc.TermsOfUse, 1)
@@ -763,6 +775,85 @@ func TestWithDisabledProofCheck(t *testing.T) {
 	require.True(t, opts.disabledProofCheck)
 }
 
+func TestWithCredentialNoValidation(t *testing.T) {
+	credentialOpt := WithCredentialNoValidation()
+	require.NotNil(t, credentialOpt)
+
+	opts := &credentialOpts{}
+	credentialOpt(opts)
+	require.True(t, opts.noValidation)
+	require.True(t, opts.disabledProofCheck)
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(validCredential), &raw))
+
+	// invalid against JSON Schema and has an invalid proof
+	delete(raw, "issuanceDate")
+	raw["proof"] = map[string]interface{}{
+		"type":               "Ed25519Signature2018",
+		"created":            "2020-01-01T00:00:00Z",
+		"verificationMethod": "did:example:123456#key1",
+		"proofValue":         "invalid",
+	}
+
+	vcBytes, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	_, err = parseTestCredential(t, vcBytes, WithDisabledProofCheck())
+	require.Error(t, err)
+
+	vc, err := ParseCredential(vcBytes, WithCredentialNoValidation())
+	require.NoError(t, err)
+	require.Equal(t, raw["id"], vc.ID)
+	require.Nil(t, vc.Issued)
+	require.Len(t, vc.Proofs, 1)
+}
+
+func TestWithMaxDocumentSize(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential), WithMaxDocumentSize(len(validCredential)))
+	require.NoError(t, err)
+	require.NotNil(t, vc)
+
+	_, err = parseTestCredential(t, []byte(validCredential), WithMaxDocumentSize(len(validCredential)-1))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode new credential: document size")
+	require.Contains(t, err.Error(), "exceeds the limit")
+
+	// credential in JWT form
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	vcFromJWT, err := parseTestCredential(t, []byte(vcJWT), WithMaxDocumentSize(len(vcJWT)), WithAllowUnsecuredJWT())
+	require.NoError(t, err)
+	require.Equal(t, vc.ID, vcFromJWT.ID)
+
+	_, err = parseTestCredential(t, []byte(vcJWT), WithMaxDocumentSize(len(vcJWT)/2), WithAllowUnsecuredJWT())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds the limit")
+}
+
+func BenchmarkParseCredential_NoValidation(b *testing.B) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(b, err)
+
+	b.Run("full validation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := ParseCredential([]byte(validCredential), WithJSONLDDocumentLoader(loader))
+			require.NoError(b, err)
+		}
+	})
+
+	b.Run("no validation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := ParseCredential([]byte(validCredential), WithCredentialNoValidation())
+			require.NoError(b, err)
+		}
+	})
+}
+
 func TestWithCredentialSchemaLoader(t *testing.T) {
 	httpClient := &http.Client{}
 	jsonSchemaLoader := gojsonschema.NewStringLoader(DefaultSchema)
@@ -862,6 +953,345 @@ func TestWithStrictValidation(t *testing.T) {
 	require.True(t, opts.strictValidation)
 }
 
+func TestWithExpectedCredentialTypes(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vc.Types = []string{"VerifiableCredential", "UniversityDegreeCredential"}
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	t.Run("all expected types are present", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithExpectedCredentialTypes("UniversityDegreeCredential"),
+			WithExpectedCredentialTypes("VerifiableCredential"))
+		require.NoError(t, err)
+	})
+
+	t.Run("missing types are listed", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithExpectedCredentialTypes("UniversityDegreeCredential", "AlumniCredential", "PermanentResidentCard"))
+		require.EqualError(t, err,
+			"credential does not have the expected types: AlumniCredential, PermanentResidentCard")
+	})
+
+	t.Run("checked without validation", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, WithCredentialNoValidation(),
+			WithExpectedCredentialTypes("AlumniCredential", "AlumniCredential"))
+		require.EqualError(t, err, "credential does not have the expected types: AlumniCredential")
+	})
+
+	t.Run("checked before proof verification", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1")
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(vcJWS),
+			WithPublicKeyFetcher(func(string, string) (*verifier.PublicKey, error) {
+				require.FailNow(t, "public key must not be fetched")
+
+				return nil, nil
+			}),
+			WithExpectedCredentialTypes("AlumniCredential"))
+		require.EqualError(t, err, "credential does not have the expected types: AlumniCredential")
+	})
+}
+
+func TestWithIssuerAsObject(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vc.Issuer = Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"}
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+	}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	parsedVC, err := parseTestCredential(t, vcBytes, WithIssuerAsObject(), fetcher)
+	require.NoError(t, err)
+
+	displayBytes, err := parsedVC.MarshalDisplayJSON()
+	require.NoError(t, err)
+
+	var display map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(displayBytes, &display))
+	require.Equal(t, map[string]interface{}{"id": "did:example:76e12ec712ebc6f1c221ebfeb1f"}, display["issuer"])
+
+	// the signed form is kept
+	signedBytes, err := parsedVC.MarshalJSON()
+	require.NoError(t, err)
+
+	var signed map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(signedBytes, &signed))
+	require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", signed["issuer"])
+
+	_, err = parseTestCredential(t, signedBytes, fetcher)
+	require.NoError(t, err)
+
+	// the issuer is marshalled as is without the option
+	parsedVC, err = parseTestCredential(t, vcBytes, fetcher)
+	require.NoError(t, err)
+
+	displayBytes, err = parsedVC.MarshalDisplayJSON()
+	require.NoError(t, err)
+	require.Equal(t, signedBytes, displayBytes)
+}
+
+func TestWithCompactMarshaling(t *testing.T) {
+	vcMap, err := toMap(validCredential)
+	require.NoError(t, err)
+
+	vcMap["credentialSchema"] = []interface{}{}
+	vcMap["refreshService"] = map[string]interface{}{}
+	vcMap["tags"] = []interface{}{}
+	vcMap["credentialSubject"].(map[string]interface{})["degree"] = map[string]interface{}{
+		"type":    "BachelorDegree",
+		"minors":  []interface{}{},
+		"details": map[string]interface{}{"notes": []interface{}{}},
+	}
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, vcBytes, WithCompactMarshaling(), WithCredentialNoValidation())
+	require.NoError(t, err)
+
+	displayBytes, err := vc.MarshalDisplayJSON()
+	require.NoError(t, err)
+
+	display, err := toMap(displayBytes)
+	require.NoError(t, err)
+
+	require.NotContains(t, display, "credentialSchema")
+	require.NotContains(t, display, "refreshService")
+	require.NotContains(t, display, "tags")
+	require.Equal(t, map[string]interface{}{"type": "BachelorDegree"},
+		display["credentialSubject"].(map[string]interface{})["degree"])
+	require.Equal(t, vcMap["issuanceDate"], display["issuanceDate"])
+
+	// the empty fields are kept in the form which can be signed
+	signed, err := toMap(vc)
+	require.NoError(t, err)
+
+	require.Equal(t, []interface{}{}, signed["tags"])
+	require.Equal(t, map[string]interface{}{}, signed["refreshService"])
+
+	// the empty fields are kept without the option
+	vc, err = parseTestCredential(t, vcBytes, WithCredentialNoValidation())
+	require.NoError(t, err)
+
+	displayBytes, err = vc.MarshalDisplayJSON()
+	require.NoError(t, err)
+
+	display, err = toMap(displayBytes)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{}, display["tags"])
+}
+
+func TestCredential_EmptySchemas(t *testing.T) {
+	vcMap, err := toMap(validCredential)
+	require.NoError(t, err)
+
+	delete(vcMap, "credentialSchema")
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	t.Run("absent schemas are omitted", func(t *testing.T) {
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Nil(t, vc.Schemas)
+
+		vc.Schemas = []TypedID{}
+
+		marshalled, err := toMap(vc)
+		require.NoError(t, err)
+		require.NotContains(t, marshalled, "credentialSchema")
+	})
+
+	t.Run("empty array of schemas is kept", func(t *testing.T) {
+		vcMap["credentialSchema"] = []interface{}{}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.NotNil(t, vc.Schemas)
+		require.Empty(t, vc.Schemas)
+
+		marshalled, err := toMap(vc)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{}, marshalled["credentialSchema"])
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		marshalled, err = toMap(jwtClaims.VC)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{}, marshalled["credentialSchema"])
+
+		vc.Schemas = nil
+
+		marshalled, err = toMap(vc)
+		require.NoError(t, err)
+		require.NotContains(t, marshalled, "credentialSchema")
+	})
+}
+
+func TestCredentialEvidenceRoundTrip(t *testing.T) {
+	vcMap, err := toMap(validCredential)
+	require.NoError(t, err)
+
+	evidence := map[string]interface{}{
+		"id":               "https://example.edu/evidence/f2aeec97-fc0d-42bf-8ca7-0548192d4231",
+		"type":             []interface{}{"DocumentVerification"},
+		"verifier":         "https://example.edu/issuers/14",
+		"evidenceDocument": "DriversLicense",
+	}
+
+	for _, rawEvidence := range []interface{}{evidence, []interface{}{evidence}} {
+		vcMap["evidence"] = rawEvidence
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Len(t, vc.Evidence, 1)
+		require.Equal(t, "DocumentVerification", vc.Evidence[0].Type)
+
+		marshalled, err := toMap(vc)
+		require.NoError(t, err)
+		require.Equal(t, rawEvidence, marshalled["evidence"])
+	}
+}
+
+func TestWithEvidenceVerifier(t *testing.T) {
+	t.Run("evidence is verified", func(t *testing.T) {
+		var verified []TypedID
+
+		vc, err := parseTestCredential(t, []byte(validCredential), WithEvidenceVerifier(func(evidence []TypedID) error {
+			verified = evidence
+
+			return nil
+		}))
+		require.NoError(t, err)
+		require.Len(t, verified, 2)
+		require.Equal(t, vc.Evidence, verified)
+		require.Equal(t, "https://example.edu/evidence/f2aeec97-fc0d-42bf-8ca7-0548192d4231", verified[0].ID)
+	})
+
+	t.Run("evidence verification fails", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential), WithEvidenceVerifier(func([]TypedID) error {
+			return errors.New("evidence is revoked")
+		}))
+		require.EqualError(t, err, "verify credential evidence: evidence is revoked")
+		require.Nil(t, vc)
+	})
+
+	t.Run("credential without evidence", func(t *testing.T) {
+		var vcMap map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		delete(vcMap, "evidence")
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithEvidenceVerifier(func([]TypedID) error {
+			return errors.New("must not be called")
+		}))
+		require.NoError(t, err)
+	})
+}
+
+func TestWithRequireProof(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	t.Run("credential without proof is rejected", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential), WithRequireProof())
+		require.ErrorIs(t, err, ErrProofMissing)
+
+		_, err = parseTestCredential(t, []byte(validCredential), WithRequireProof(), WithDisabledProofCheck())
+		require.ErrorIs(t, err, ErrProofMissing)
+
+		_, err = parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+		require.NoError(t, err)
+	})
+
+	t.Run("unsecured JWT without embedded proof is rejected", func(t *testing.T) {
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		unsecuredJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(unsecuredJWT), WithRequireProof(), WithAllowUnsecuredJWT())
+		require.ErrorIs(t, err, ErrProofMissing)
+	})
+
+	t.Run("JWS is accepted", func(t *testing.T) {
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1")
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(vcJWS), WithRequireProof(), fetcher)
+		require.NoError(t, err)
+	})
+
+	t.Run("credential with embedded proof is accepted", func(t *testing.T) {
+		signedVC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = signedVC.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vcBytes, err := signedVC.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithRequireProof(), fetcher)
+		require.NoError(t, err)
+
+		// the proof is only required to be present
+		_, err = parseTestCredential(t, vcBytes, WithRequireProof(), WithDisabledProofCheck())
+		require.NoError(t, err)
+	})
+}
+
 func TestWithEmbeddedSignatureSuites(t *testing.T) {
 	ss := ed25519signature2018.New()
 
@@ -873,6 +1303,99 @@ func TestWithEmbeddedSignatureSuites(t *testing.T) {
 	require.Equal(t, []verifier.SignatureSuite{ss}, opts.ldpSuites)
 }
 
+func TestWithVerifyProofByVerificationMethod(t *testing.T) {
+	credentialOpt := WithVerifyProofByVerificationMethod("did:example:123456#key1")
+	require.NotNil(t, credentialOpt)
+
+	opts := &credentialOpts{}
+	credentialOpt(opts)
+	require.Equal(t, "did:example:123456#key1", opts.verificationMethod)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	const schema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "credentialSubject": {"required": ["name"]}
+  }
+}`
+
+	const context = `{
+  "@context": {
+    "@version": 1.1,
+    "name": "http://schema.org/name"
+  }
+}`
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/schema":
+			_, err := res.Write([]byte(schema))
+			require.NoError(t, err)
+		case "/context":
+			res.Header().Set("Content-Type", "application/ld+json")
+			_, err := res.Write([]byte(context))
+			require.NoError(t, err)
+		default:
+			res.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	vc := &Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1", testServer.URL + "/context"},
+		Types:   []string{"VerifiableCredential"},
+		Issuer:  Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		Issued:  util.NewTime(time.Now()),
+		Subject: Subject{
+			ID:           "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: CustomFields{"name": "Jayden Doe"},
+		},
+		Schemas: []TypedID{{ID: testServer.URL + "/schema", Type: "JsonSchemaValidator2018"}},
+	}
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	t.Run("schema is downloaded using the client", func(t *testing.T) {
+		transport := &countingTransport{}
+
+		_, err := ParseCredential(vcBytes, WithHTTPClient(transport.client()), WithSchemaValidation(),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t, ldcontext.Document{
+				URL:     testServer.URL + "/context",
+				Content: []byte(context),
+			})))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, transport.count())
+	})
+
+	t.Run("JSON-LD context is loaded using the client", func(t *testing.T) {
+		transport := &countingTransport{documents: map[string][]byte{
+			"https://www.w3.org/2018/credentials/v1": embed.Contexts[0].Content,
+		}}
+
+		_, err := ParseCredential(vcBytes, WithHTTPClient(transport.client()), WithJSONLDValidation(),
+			WithNoCustomSchemaCheck())
+		require.NoError(t, err)
+		require.Positive(t, transport.count())
+	})
+
+	t.Run("option", func(t *testing.T) {
+		client := &http.Client{}
+
+		opts := getCredentialOpts([]CredentialOpt{WithHTTPClient(client)})
+		require.Equal(t, client, opts.httpClient)
+		require.Equal(t, client, opts.schemaLoader.schemaDownloadClient)
+		require.NotNil(t, opts.jsonldDocumentLoader)
+
+		loader := createTestDocumentLoader(t)
+
+		opts = getCredentialOpts([]CredentialOpt{WithHTTPClient(client), WithJSONLDDocumentLoader(loader)})
+		require.Equal(t, loader, opts.jsonldDocumentLoader)
+	})
+}
+
 func TestCustomCredentialJsonSchemaValidator2018(t *testing.T) {
 	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 		rawMap := make(map[string]interface{})
@@ -990,7 +1513,7 @@ func TestDownloadCustomSchema(t *testing.T) {
 
 	httpClient := &http.Client{}
 
-	noCacheOpts := &credentialOpts{schemaLoader: newDefaultSchemaLoader()}
+	noCacheOpts := &credentialOpts{schemaLoader: newDefaultSchemaLoader(nil)}
 	withCacheOpts := &credentialOpts{schemaLoader: &CredentialSchemaLoader{
 		schemaDownloadClient: httpClient,
 		jsonLoader:           gojsonschema.NewStringLoader(DefaultSchema),
@@ -1399,6 +1922,53 @@ func TestParseSubject(t *testing.T) {
 	})
 }
 
+func TestCredential_IssuerID(t *testing.T) {
+	t.Run("issuer defined as string", func(t *testing.T) {
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+		vcMap["issuer"] = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", vc.IssuerID())
+
+		vcBytes, err = vc.MarshalJSON()
+		require.NoError(t, err)
+
+		vcMap = map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+		require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", vcMap["issuer"])
+	})
+
+	t.Run("issuer defined as object", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+		require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", vc.IssuerID())
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+		require.Equal(t, map[string]interface{}{
+			"id":    "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			"name":  "Example University",
+			"image": "data:image/png;base64,iVBOR",
+		}, vcMap["issuer"])
+	})
+
+	t.Run("issuer marshalled by value", func(t *testing.T) {
+		issuerBytes, err := json.Marshal(map[string]interface{}{
+			"issuer": Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f"}`, string(issuerBytes))
+	})
+}
+
 func TestMarshalIssuer(t *testing.T) {
 	t.Run("Marshal Issuer with ID defined only", func(t *testing.T) {
 		issuer := Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"}
@@ -1822,6 +2392,19 @@ func TestCredential_raw(t *testing.T) {
 		require.Nil(t, vcRaw)
 	})
 
+	t.Run("Serialize with invalid evidence", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Evidence = []TypedID{{CustomFields: map[string]interface{}{
+			"invalidField": make(chan int),
+		}}}
+
+		vcRaw, err := vc.raw()
+		require.Error(t, err)
+		require.Nil(t, vcRaw)
+	})
+
 	t.Run("Serialize with invalid proof", func(t *testing.T) {
 		vc, err := parseTestCredential(t, []byte(validCredential))
 		require.NoError(t, err)
@@ -1857,7 +2440,7 @@ func TestParseUnverifiedCredential(t *testing.T) {
 			WithDisabledProofCheck())
 		require.NoError(t, err)
 		require.NotNil(t, vcUnverified)
-		require.Equal(t, vc, vcUnverified)
+		require.Equal(t, vc, withoutJWT(vcUnverified))
 	})
 
 	t.Run("ParseUnverifiedCredential() for Linked Data proof", func(t *testing.T) {
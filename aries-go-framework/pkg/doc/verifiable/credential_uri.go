@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+//nolint:gochecknoglobals
+var (
+	// didRegexp matches DID syntax: "did:" method-name ":" method-specific-id.
+	didRegexp = regexp.MustCompile(`^did:[a-z0-9]+:([A-Za-z0-9._%-]*:)*[A-Za-z0-9._%-]+([/?#].*)?$`)
+
+	// urnRegexp matches URN syntax: "urn:" NID ":" NSS.
+	urnRegexp = regexp.MustCompile(`^(?i:urn):[A-Za-z0-9][A-Za-z0-9-]{0,31}:.+$`)
+)
+
+// validateIDs checks that the credential id and the ids of the credential subjects are valid URIs.
+func (vc *Credential) validateIDs() error {
+	if vc.ID != "" {
+		if err := validateURI(vc.ID); err != nil {
+			return fmt.Errorf("invalid credential id %q: %w", vc.ID, err)
+		}
+	}
+
+	ids, err := subjectIDs(vc.Subject)
+	if err != nil {
+		return err
+	}
+
+	for i, id := range ids {
+		if id == "" {
+			continue
+		}
+
+		if err := validateURI(id); err != nil {
+			return fmt.Errorf("invalid credentialSubject[%d] id %q: %w", i, id, err)
+		}
+	}
+
+	return nil
+}
+
+// subjectIDs returns ids of all the credential subjects (an empty string for the subject without id).
+func subjectIDs(subject interface{}) ([]string, error) {
+	switch s := subject.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{s}, nil
+	case Subject:
+		return []string{s.ID}, nil
+	case []Subject:
+		ids := make([]string, len(s))
+
+		for i := range s {
+			ids[i] = s[i].ID
+		}
+
+		return ids, nil
+	case map[string]interface{}:
+		return subjectIDs([]map[string]interface{}{s})
+	case []map[string]interface{}:
+		ids := make([]string, len(s))
+
+		for i := range s {
+			id, ok := s[i]["id"]
+			if !ok {
+				continue
+			}
+
+			if ids[i], ok = id.(string); !ok {
+				return nil, fmt.Errorf("credentialSubject[%d] id is not a string", i)
+			}
+		}
+
+		return ids, nil
+	default:
+		return nil, fmt.Errorf("unsupported credentialSubject type %T", subject)
+	}
+}
+
+// validateURI checks that the value is a syntactically valid absolute URI (RFC 3986). DIDs, URNs and
+// HTTP(S) URLs are additionally checked against the syntax of their schemes.
+func validateURI(value string) error {
+	if err := checkURICharacters(value); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return errors.New("not a URI")
+	}
+
+	if u.Scheme == "" {
+		return errors.New("not an absolute URI: scheme is missing")
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "did":
+		if !didRegexp.MatchString(value) {
+			return errors.New("not a valid DID")
+		}
+	case "urn":
+		if !urnRegexp.MatchString(value) {
+			return errors.New("not a valid URN")
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return errors.New("not a valid URL: host is missing")
+		}
+	}
+
+	return nil
+}
+
+// checkURICharacters checks that the value contains only the characters allowed in URI
+// (unreserved, reserved and percent-encoded ones).
+func checkURICharacters(value string) error {
+	const allowed = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-._~:/?#[]@!$&'()*+,;="
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if c == '%' {
+			if i+2 >= len(value) || !isHex(value[i+1]) || !isHex(value[i+2]) {
+				return errors.New("invalid percent-encoding")
+			}
+
+			i += 2
+
+			continue
+		}
+
+		if strings.IndexByte(allowed, c) < 0 {
+			r, _ := utf8.DecodeRuneInString(value[i:])
+
+			return fmt.Errorf("invalid character %q", r)
+		}
+	}
+
+	return nil
+}
+
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
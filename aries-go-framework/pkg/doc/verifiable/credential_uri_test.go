@@ -0,0 +1,107 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateURI(t *testing.T) {
+	for _, uri := range []string{
+		"did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"did:web:example.com%3A8443:user:alice",
+		"did:example:123#key-1",
+		"urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5",
+		"URN:ISBN:0-486-27557-4",
+		"http://example.edu/credentials/1872",
+		"https://example.com/path?query=value#fragment",
+		"mailto:alice@example.com",
+	} {
+		require.NoError(t, validateURI(uri), uri)
+	}
+
+	for uri, expectedErr := range map[string]string{
+		"":                            "not an absolute URI: scheme is missing",
+		"credential-1":                "not an absolute URI: scheme is missing",
+		"/credentials/1":              "not an absolute URI: scheme is missing",
+		"did:example":                 "not a valid DID",
+		"did:Example:123":             "not a valid DID",
+		"did:example:":                "not a valid DID",
+		"urn:uuid":                    "not a valid URN",
+		"https:///credentials/1":      "not a valid URL: host is missing",
+		"http://example.edu/a b":      "invalid character ' '",
+		"did:example:123\n":           "invalid character '\\n'",
+		"https://example.com/%4":      "invalid percent-encoding",
+		"https://example.com/%zz":     "invalid percent-encoding",
+		"https://example.com/é":       "invalid character 'é'",
+		"1http://example.edu/creds/1": "not a URI",
+	} {
+		require.EqualError(t, validateURI(uri), expectedErr, uri)
+	}
+}
+
+func TestParseCredential_StrictValidationOfIDs(t *testing.T) {
+	newCredentialBytes := func(id string, subject interface{}) []byte {
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		vcMap["id"] = id
+		vcMap["credentialSubject"] = subject
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	t.Run("valid ids", func(t *testing.T) {
+		_, err := parseTestCredential(t, newCredentialBytes("urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5",
+			[]interface{}{
+				map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+				map[string]interface{}{"name": "Jayden Doe"},
+			}), WithStrictValidation(), WithJSONLDValidation())
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid credential id", func(t *testing.T) {
+		vcBytes := newCredentialBytes("credential 1872",
+			map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"})
+
+		// not validated by default
+		_, err := parseTestCredential(t, vcBytes, WithJSONLDValidation())
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithStrictValidation(), WithJSONLDValidation())
+		require.EqualError(t, err, `invalid credential id "credential 1872": invalid character ' '`)
+	})
+
+	t.Run("invalid subject id", func(t *testing.T) {
+		_, err := parseTestCredential(t, newCredentialBytes("http://example.edu/credentials/1872",
+			[]interface{}{
+				map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"},
+				map[string]interface{}{"id": "subject-2"},
+			}), WithStrictValidation(), WithJSONLDValidation())
+		require.EqualError(t, err,
+			`invalid credentialSubject[1] id "subject-2": not an absolute URI: scheme is missing`)
+	})
+
+	t.Run("subject defined by id", func(t *testing.T) {
+		vc := &Credential{ID: "http://example.edu/credentials/1872", Subject: "did:example"}
+		require.EqualError(t, vc.validateIDs(), `invalid credentialSubject[0] id "did:example": not a valid DID`)
+
+		vc.Subject = Subject{ID: "did:example:ebfeb1f712ebc6f1c276e12ec21"}
+		require.NoError(t, vc.validateIDs())
+
+		vc.Subject = map[string]interface{}{"id": 1}
+		require.EqualError(t, vc.validateIDs(), "credentialSubject[0] id is not a string")
+
+		vc.Subject = 1
+		require.EqualError(t, vc.validateIDs(), "unsupported credentialSubject type int")
+	})
+}
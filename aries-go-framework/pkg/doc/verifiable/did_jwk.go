@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const (
+	// didJWKPrefix is a prefix of did:jwk DID (https://github.com/quartzjer/did-jwk) embedding the public key.
+	didJWKPrefix = "did:jwk:"
+
+	// didJWKKeyID is the key id of the only verification method of did:jwk DID document.
+	didJWKKeyID = "0"
+)
+
+// resolveDIDJWK decodes the public key embedded into did:jwk DID as base64url encoded JWK, so no DID resolution
+// is needed. The key id must be either "0" (the key id of did:jwk DID document) or the JWK thumbprint (RFC 7638).
+func resolveDIDJWK(did, keyID string) (*verifier.PublicKey, error) {
+	jwkBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(did, didJWKPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("decode JWK of DID %s: %w", did, err)
+	}
+
+	var j jwk.JWK
+
+	if err = json.Unmarshal(jwkBytes, &j); err != nil {
+		return nil, fmt.Errorf("unmarshal JWK of DID %s: %w", did, err)
+	}
+
+	if !j.IsPublic() {
+		return nil, fmt.Errorf("JWK of DID %s is not a public key", did)
+	}
+
+	if kid := strings.TrimPrefix(keyID, "#"); kid != didJWKKeyID {
+		thumbprint, err := j.Thumbprint(crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("compute thumbprint of JWK of DID %s: %w", did, err)
+		}
+
+		if kid != base64.RawURLEncoding.EncodeToString(thumbprint) {
+			return nil, fmt.Errorf("key ID %s does not match JWK thumbprint of DID %s", keyID, did)
+		}
+	}
+
+	pubKeyBytes, err := j.PublicKeyBytes()
+	if err != nil {
+		return nil, fmt.Errorf("get public key of DID %s: %w", did, err)
+	}
+
+	return &verifier.PublicKey{
+		Type:  "JsonWebKey2020",
+		Value: pubKeyBytes,
+		JWK:   &j,
+	}, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+)
+
+func TestVDRKeyResolver_DIDJWK(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	did, thumbprint := newDIDJWK(t, signer.PublicKey())
+
+	// did:jwk is not resolved by VDR
+	fetcher := NewVDRKeyResolver(vdr.New()).PublicKeyFetcher()
+
+	t.Run("verify linked data proof", func(t *testing.T) {
+		sigSuite := jsonwebsignature2020.New(
+			suite.WithSigner(signer),
+			suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier()))
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "JsonWebSignature2020",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   sigSuite,
+			VerificationMethod:      did + "#0",
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		vcWithLdp, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+		require.Equal(t, vc, vcWithLdp)
+	})
+
+	t.Run("key ID is JWK thumbprint", func(t *testing.T) {
+		pubKey, err := fetcher(did, "#"+thumbprint)
+		require.NoError(t, err)
+		require.Equal(t, "JsonWebKey2020", pubKey.Type)
+		require.Equal(t, signer.PublicKeyBytes(), pubKey.Value)
+		require.NotNil(t, pubKey.JWK)
+	})
+
+	t.Run("key ID does not match JWK thumbprint", func(t *testing.T) {
+		_, err := fetcher(did, "#key1")
+		require.EqualError(t, err, "key ID #key1 does not match JWK thumbprint of DID "+did)
+	})
+
+	t.Run("invalid JWK encoding", func(t *testing.T) {
+		_, err := fetcher("did:jwk:invalid!", "#0")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode JWK of DID did:jwk:invalid!")
+
+		_, err = fetcher("did:jwk:"+base64.RawURLEncoding.EncodeToString([]byte("{")), "#0")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal JWK of DID")
+	})
+
+	t.Run("JWK is a private key", func(t *testing.T) {
+		_, privKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		j, err := jwksupport.JWKFromKey(privKey)
+		require.NoError(t, err)
+
+		jwkBytes, err := j.MarshalJSON()
+		require.NoError(t, err)
+
+		privDID := "did:jwk:" + base64.RawURLEncoding.EncodeToString(jwkBytes)
+
+		_, err = fetcher(privDID, "#0")
+		require.EqualError(t, err, "JWK of DID "+privDID+" is not a public key")
+	})
+}
+
+// newDIDJWK creates did:jwk DID of the public key and returns it together with the JWK thumbprint.
+func newDIDJWK(t *testing.T, pubKey interface{}) (string, string) {
+	t.Helper()
+
+	j, err := jwksupport.JWKFromKey(pubKey)
+	require.NoError(t, err)
+
+	jwkBytes, err := j.MarshalJSON()
+	require.NoError(t, err)
+
+	thumbprint, err := j.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	return "did:jwk:" + base64.RawURLEncoding.EncodeToString(jwkBytes),
+		base64.RawURLEncoding.EncodeToString(thumbprint)
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
@@ -17,16 +18,23 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 const (
 	ed25519Signature2018        = "Ed25519Signature2018"
+	ed25519Signature2020        = "Ed25519Signature2020"
 	jsonWebSignature2020        = "JsonWebSignature2020"
 	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
 	bbsBlsSignature2020         = "BbsBlsSignature2020"
 	bbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
+
+	// proofExpirationClockSkew is a tolerance of the check of proof expiration time ("expires").
+	proofExpirationClockSkew = 5 * time.Minute
 )
 
 func getProofType(proofMap map[string]interface{}) (string, error) {
@@ -37,7 +45,7 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 
 	proofTypeStr := safeStringValue(proofType)
 	switch proofTypeStr {
-	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
+	case ed25519Signature2018, ed25519Signature2020, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
 		bbsBlsSignature2020, bbsBlsSignatureProof2020:
 		return proofTypeStr, nil
 	default:
@@ -49,13 +57,38 @@ type embeddedProofCheckOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
 	disabledProofCheck bool
 
-	ldpSuites []verifier.SignatureSuite
+	ldpSuites  []verifier.SignatureSuite
+	autoSuites bool
+
+	// verificationMethod, if defined, restricts the check to the proofs with this verification method.
+	verificationMethod string
+
+	// vmAuthorizationVDR, if defined, is used to check that the verification method of proof
+	// is authorized for the proof purpose.
+	vmAuthorizationVDR vdrapi.Registry
+
+	// controllerProofs, if true, rejects the proofs whose verification method doesn't belong to the issuer
+	// of the credential or the holder of the presentation (see WithTrustedIssuers and WithPresTrustedHolders).
+	controllerProofs bool
+
+	// requireProof, if true, rejects the document without proof (even if the proof check is disabled).
+	requireProof bool
+
+	// maxProofs, if positive, limits the number of proofs of the document to be checked.
+	maxProofs int
 
 	jsonldCredentialOpts
 }
 
+// ErrProofMissing is returned when the proof is required (see WithRequireProof) but the credential
+// has neither embedded proof nor JWS signature.
+var ErrProofMissing = errors.New("proof is required but not defined")
+
+// defaultMaxProofs is a default max number of embedded proofs of the document (see WithMaxProofs).
+const defaultMaxProofs = 10
+
 func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte, error) {
-	if opts.disabledProofCheck {
+	if opts.disabledProofCheck && !opts.requireProof {
 		return docBytes, nil
 	}
 
@@ -67,15 +100,51 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 
 	proofElement, ok := jsonldDoc["proof"]
 	if !ok || proofElement == nil {
+		if opts.requireProof {
+			return nil, fmt.Errorf("check embedded proof: %w", ErrProofMissing)
+		}
+
 		// do not make a check if there is no proof defined as proof presence is not mandatory
 		return docBytes, nil
 	}
 
+	if opts.disabledProofCheck {
+		return docBytes, nil
+	}
+
 	proofs, err := getProofs(proofElement)
 	if err != nil {
 		return nil, fmt.Errorf("check embedded proof: %w", err)
 	}
 
+	if opts.maxProofs > 0 && len(proofs) > opts.maxProofs {
+		return nil, fmt.Errorf("check embedded proof: number of proofs %d exceeds the limit of %d",
+			len(proofs), opts.maxProofs)
+	}
+
+	if opts.verificationMethod != "" {
+		proofs, err = filterProofsByVerificationMethod(proofs, opts.verificationMethod)
+		if err != nil {
+			return nil, fmt.Errorf("check embedded proof: %w", err)
+		}
+	}
+
+	if err = checkProofsExpiration(proofs); err != nil {
+		return nil, fmt.Errorf("check embedded proof: %w", err)
+	}
+
+	if opts.controllerProofs {
+		if err = checkProofsController(proofs, proofController(jsonldDoc)); err != nil {
+			return nil, fmt.Errorf("check embedded proof: %w", err)
+		}
+	}
+
+	if opts.vmAuthorizationVDR != nil {
+		if err = checkProofsAuthorization(proofs, proofController(jsonldDoc), opts.vmAuthorizationVDR); err != nil {
+			return nil, fmt.Errorf("check embedded proof: %w", err)
+		}
+	}
+
 	ldpSuites, err := getSuites(proofs, opts)
 	if err != nil {
 		return nil, err
@@ -90,6 +159,14 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 	if len(opts.externalContext) > 0 {
 		// Use external contexts for check of the linked data proofs to enrich JSON-LD context vocabulary.
 		jsonldDoc["@context"] = jsonld.AppendExternalContexts(jsonldDoc["@context"], opts.externalContext...)
+	}
+
+	if opts.verificationMethod != "" {
+		// Leave only the proofs to be checked, the other ones are ignored.
+		jsonldDoc["proof"] = proofsToInterface(proofs)
+	}
+
+	if len(opts.externalContext) > 0 || opts.verificationMethod != "" {
 		checkedDoc, _ = json.Marshal(jsonldDoc) //nolint:errcheck
 	}
 
@@ -98,11 +175,58 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 		return nil, fmt.Errorf("check embedded proof: %w", err)
 	}
 
+	if opts.blankNodeTermsCheck {
+		// the blank node terms are not covered by the proofs, so they could be changed without breaking them
+		err = checkBlankNodePredicates(jsonldDoc, mapJSONLDProcessorOpts(&opts.jsonldCredentialOpts)...)
+		if err != nil {
+			return nil, fmt.Errorf("check embedded proof: %w", err)
+		}
+	}
+
 	return docBytes, nil
 }
 
+// checkProofsExpiration checks that none of the proofs has expired ("expires" is in the past).
+func checkProofsExpiration(proofs []map[string]interface{}) error {
+	for _, p := range proofs {
+		expiresStr := safeStringValue(p["expires"])
+		if expiresStr == "" {
+			continue
+		}
+
+		expires, err := util.ParseTimeWrapper(expiresStr)
+		if err != nil {
+			return fmt.Errorf("invalid proof expiration time: %w", err)
+		}
+
+		if now().After(expires.Time.Add(proofExpirationClockSkew)) {
+			return fmt.Errorf("proof expired at %s", expiresStr)
+		}
+	}
+
+	return nil
+}
+
+// hasEmbeddedProof checks if the JSON document has "proof" defined.
+func hasEmbeddedProof(docBytes []byte) bool {
+	var doc struct {
+		Proof json.RawMessage `json:"proof"`
+	}
+
+	if err := json.Unmarshal(docBytes, &doc); err != nil {
+		return false
+	}
+
+	return len(doc.Proof) > 0 && string(doc.Proof) != "null"
+}
+
 func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([]verifier.SignatureSuite, error) {
-	ldpSuites := opts.ldpSuites
+	if opts.autoSuites {
+		return getAutoSuites(proofs, opts.ldpSuites)
+	}
+
+	// the suites passed in options are copied as the options may be shared by concurrent calls
+	ldpSuites := append([]verifier.SignatureSuite{}, opts.ldpSuites...)
 
 	for i := range proofs {
 		t, err := getProofType(proofs[i])
@@ -111,27 +235,13 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 		}
 
 		if len(opts.ldpSuites) == 0 {
-			switch t {
-			case ed25519Signature2018:
-				ldpSuites = append(ldpSuites, ed25519signature2018.New(
-					suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())))
-			case jsonWebSignature2020:
-				ldpSuites = append(ldpSuites, jsonwebsignature2020.New(
-					suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier())))
-			case ecdsaSecp256k1Signature2019:
-				ldpSuites = append(ldpSuites, ecdsasecp256k1signature2019.New(
-					suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier())))
-			case bbsBlsSignature2020:
-				ldpSuites = append(ldpSuites, bbsblssignature2020.New(
-					suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier())))
-			case bbsBlsSignatureProof2020:
-				nonce, err := getNonce(proofs[i])
-				if err != nil {
-					return nil, err
-				}
-
-				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
-					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
+			s, err := defaultSuite(t, proofs[i])
+			if err != nil {
+				return nil, err
+			}
+
+			if s != nil {
+				ldpSuites = append(ldpSuites, s)
 			}
 		}
 	}
@@ -139,6 +249,78 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 	return ldpSuites, nil
 }
 
+// getAutoSuites selects a suite for every proof by its type. The suites passed explicitly take precedence,
+// the default ones are used for the remaining proof types.
+func getAutoSuites(proofs []map[string]interface{},
+	ldpSuites []verifier.SignatureSuite) ([]verifier.SignatureSuite, error) {
+	suites := append([]verifier.SignatureSuite{}, ldpSuites...)
+
+	for i := range proofs {
+		t := safeStringValue(proofs[i]["type"])
+		if t == "" {
+			return nil, errors.New("check embedded proof: proof type is missing")
+		}
+
+		if suiteAccepts(suites, t) {
+			continue
+		}
+
+		s, err := defaultSuite(t, proofs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if s == nil {
+			if cryptosuite := safeStringValue(proofs[i]["cryptosuite"]); cryptosuite != "" {
+				t = fmt.Sprintf("%s (cryptosuite %s)", t, cryptosuite)
+			}
+
+			return nil, fmt.Errorf("check embedded proof: no suite registered for type %s", t)
+		}
+
+		suites = append(suites, s)
+	}
+
+	return suites, nil
+}
+
+func suiteAccepts(suites []verifier.SignatureSuite, proofType string) bool {
+	for _, s := range suites {
+		if s.Accept(proofType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// defaultSuite returns the default suite for the proof type or nil if there is no such suite.
+func defaultSuite(proofType string, proof map[string]interface{}) (verifier.SignatureSuite, error) {
+	switch proofType {
+	case ed25519Signature2018:
+		return ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())), nil
+	case ed25519Signature2020:
+		return ed25519signature2020.New(suite.WithVerifier(ed25519signature2020.NewPublicKeyVerifier())), nil
+	case jsonWebSignature2020:
+		return jsonwebsignature2020.New(suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier())), nil
+	case ecdsaSecp256k1Signature2019:
+		return ecdsasecp256k1signature2019.New(
+			suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier())), nil
+	case bbsBlsSignature2020:
+		return bbsblssignature2020.New(suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier())), nil
+	case bbsBlsSignatureProof2020:
+		nonce, err := getNonce(proof)
+		if err != nil {
+			return nil, err
+		}
+
+		return bbsblssignatureproof2020.New(
+			suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))), nil
+	default:
+		return nil, nil
+	}
+}
+
 func getNonce(proof map[string]interface{}) ([]byte, error) {
 	if nonce, ok := proof["nonce"]; ok {
 		n, err := base64.StdEncoding.DecodeString(nonce.(string))
@@ -152,6 +334,38 @@ func getNonce(proof map[string]interface{}) ([]byte, error) {
 	return []byte{}, nil
 }
 
+// filterProofsByVerificationMethod returns the proofs with the given verification method.
+func filterProofsByVerificationMethod(proofs []map[string]interface{},
+	verificationMethod string) ([]map[string]interface{}, error) {
+	var filtered []map[string]interface{}
+
+	for _, p := range proofs {
+		if safeStringValue(p["verificationMethod"]) == verificationMethod {
+			filtered = append(filtered, p)
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("no proof with verification method %s", verificationMethod)
+	}
+
+	return filtered, nil
+}
+
+func proofsToInterface(proofs []map[string]interface{}) interface{} {
+	if len(proofs) == 1 {
+		return proofs[0]
+	}
+
+	proofsI := make([]interface{}, len(proofs))
+
+	for i := range proofs {
+		proofsI[i] = proofs[i]
+	}
+
+	return proofsI
+}
+
 func getProofs(proofElement interface{}) ([]map[string]interface{}, error) {
 	switch p := proofElement.(type) {
 	case map[string]interface{}:
@@ -6,16 +6,26 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	_ "embed"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
+//go:embed testdata/context/ed25519_signature_2020_v1.jsonld
+var ed25519Signature2020V1 []byte //nolint:gochecknoglobals
+
 func Test_parseEmbeddedProof(t *testing.T) {
 	t.Run("parse linked data proof with \"Ed25519Signature2018\" proof type", func(t *testing.T) {
 		s, err := getProofType(map[string]interface{}{
@@ -24,6 +34,12 @@ func Test_parseEmbeddedProof(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, ed25519Signature2018, s)
 
+		s, err = getProofType(map[string]interface{}{
+			"type": ed25519Signature2020,
+		})
+		require.NoError(t, err)
+		require.Equal(t, ed25519Signature2020, s)
+
 		s, err = getProofType(map[string]interface{}{
 			"type": jsonWebSignature2020,
 		})
@@ -210,6 +226,7 @@ func Test_getSuites(t *testing.T) {
 
 	proofs := []map[string]interface{}{
 		createProofOfTypeFunc(ed25519Signature2018),
+		createProofOfTypeFunc(ed25519Signature2020),
 		createProofOfTypeFunc(jsonWebSignature2020),
 		createProofOfTypeFunc(ecdsaSecp256k1Signature2019),
 		createProofOfTypeFunc(bbsBlsSignature2020),
@@ -217,5 +234,138 @@ func Test_getSuites(t *testing.T) {
 
 	suites, err := getSuites(proofs, &embeddedProofCheckOpts{})
 	require.NoError(t, err)
-	require.Len(t, suites, 4)
+	require.Len(t, suites, 5)
+}
+
+func Test_getAutoSuites(t *testing.T) {
+	proofs := []map[string]interface{}{
+		{"type": ed25519Signature2018},
+		{"type": jsonWebSignature2020},
+		{"type": ed25519Signature2018},
+		{"type": ed25519Signature2020},
+	}
+
+	suites, err := getSuites(proofs, &embeddedProofCheckOpts{autoSuites: true})
+	require.NoError(t, err)
+	require.Len(t, suites, 3)
+	require.IsType(t, &ed25519signature2020.Suite{}, suites[2])
+
+	// explicitly defined suite is used for its proof type
+	ed25519Suite := ed25519signature2018.New()
+
+	suites, err = getSuites(proofs, &embeddedProofCheckOpts{
+		autoSuites: true,
+		ldpSuites:  []verifier.SignatureSuite{ed25519Suite},
+	})
+	require.NoError(t, err)
+	require.Len(t, suites, 3)
+	require.Equal(t, ed25519Suite, suites[0])
+
+	_, err = getSuites([]map[string]interface{}{{"type": "RsaSignature2018"}},
+		&embeddedProofCheckOpts{autoSuites: true})
+	require.EqualError(t, err, "check embedded proof: no suite registered for type RsaSignature2018")
+
+	_, err = getSuites([]map[string]interface{}{{"type": "DataIntegrityProof", "cryptosuite": "eddsa-2022"}},
+		&embeddedProofCheckOpts{autoSuites: true})
+	require.EqualError(t, err,
+		"check embedded proof: no suite registered for type DataIntegrityProof (cryptosuite eddsa-2022)")
+
+	_, err = getSuites([]map[string]interface{}{{}}, &embeddedProofCheckOpts{autoSuites: true})
+	require.EqualError(t, err, "check embedded proof: proof type is missing")
+}
+
+func TestWithAutoSignatureSuites(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	// the suite of other proof type is passed explicitly
+	_, err = parseTestCredential(t, vcBytes,
+		WithAutoSignatureSuites(),
+		WithEmbeddedSignatureSuites(jsonwebsignature2020.New(
+			suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier()))),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.NoError(t, err)
+
+	var vcMap map[string]interface{}
+	require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+
+	vcMap["proof"].(map[string]interface{})["type"] = "RsaSignature2018"
+
+	vcBytes, err = json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	_, err = parseTestCredential(t, vcBytes,
+		WithAutoSignatureSuites(),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no suite registered for type RsaSignature2018")
+}
+
+func TestWithAutoSignatureSuites_Ed25519Signature2020(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	loader := createTestDocumentLoader(t, ldcontext.Document{
+		URL:     "https://w3id.org/security/suites/ed25519-2020/v1",
+		Content: ed25519Signature2020V1,
+	})
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vc.Context = append(vc.Context, "https://w3id.org/security/suites/ed25519-2020/v1")
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           ed25519signature2020.SignatureType,
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519signature2020.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	var vcMap map[string]interface{}
+	require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+
+	proofValue, ok := vcMap["proof"].(map[string]interface{})["proofValue"].(string)
+	require.True(t, ok)
+	require.True(t, strings.HasPrefix(proofValue, "z"), "proofValue is expected to be multibase base58btc")
+
+	_, err = parseTestCredential(t, vcBytes,
+		WithJSONLDDocumentLoader(loader),
+		WithAutoSignatureSuites(),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.NoError(t, err)
+
+	// the suite is also used by default when no suites are passed
+	_, err = parseTestCredential(t, vcBytes,
+		WithJSONLDDocumentLoader(loader),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.NoError(t, err)
+
+	otherSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, err = parseTestCredential(t, vcBytes,
+		WithJSONLDDocumentLoader(loader),
+		WithAutoSignatureSuites(),
+		WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "check embedded proof")
 }
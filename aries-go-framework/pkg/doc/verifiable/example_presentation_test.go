@@ -698,8 +698,8 @@ func ExamplePresentation_AddLinkedDataProof() {
 	//	"id": "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c6",
 	//	"proof": {
 	//		"created": "2010-01-01T19:23:24Z",
-	//		"jws": "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..8stDRasAcYjkQiqiczyFJdkff8VJIF3Lbaq5BNTaC-PcvJHGo2Xja8GTsHByTOx7QNCwC3bNiboPgfXtmm8aBA",
-	//		"proofPurpose": "assertionMethod",
+	//		"jws": "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..fwEmw-h15YWI0IVVDFa3K993xOqvtiwwVgRSzg9XQXHBUJqKK8KAV8uLFot6TAd6PGMt0yTUmb5I8461iLozBA",
+	//		"proofPurpose": "authentication",
 	//		"type": "Ed25519Signature2018",
 	//		"verificationMethod": "did:example:987654#key1"
 	//	},
@@ -0,0 +1,221 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const credentialSubjectField = "credentialSubject"
+
+// fieldOrder keeps the original order of top-level and subject fields of the credential (see WithPreserveFieldOrder).
+type fieldOrder struct {
+	top []string
+
+	// subject keeps the order of fields of every subject (a single one if credentialSubject is an object).
+	subject [][]string
+}
+
+// recordFieldOrder records the order of fields of the credential in JSON form.
+func recordFieldOrder(vcBytes []byte) (*fieldOrder, error) {
+	top, err := objectKeys(vcBytes)
+	if err != nil {
+		return nil, fmt.Errorf("record field order: %w", err)
+	}
+
+	order := &fieldOrder{top: top}
+
+	var raw map[string]json.RawMessage
+
+	if err = json.Unmarshal(vcBytes, &raw); err != nil {
+		return nil, fmt.Errorf("record field order: %w", err)
+	}
+
+	subjectBytes := bytes.TrimSpace(raw[credentialSubjectField])
+
+	switch {
+	case len(subjectBytes) > 0 && subjectBytes[0] == '{':
+		keys, err := objectKeys(subjectBytes)
+		if err != nil {
+			return nil, fmt.Errorf("record field order of subject: %w", err)
+		}
+
+		order.subject = [][]string{keys}
+
+	case len(subjectBytes) > 0 && subjectBytes[0] == '[':
+		var subjects []json.RawMessage
+
+		if err = json.Unmarshal(subjectBytes, &subjects); err != nil {
+			return nil, fmt.Errorf("record field order of subject: %w", err)
+		}
+
+		order.subject = make([][]string, len(subjects))
+
+		for i, s := range subjects {
+			// the subject could be a string (ID)
+			if keys, err := objectKeys(s); err == nil {
+				order.subject[i] = keys
+			}
+		}
+	}
+
+	return order, nil
+}
+
+// apply reorders the fields of the credential in JSON form according to the recorded order.
+func (o *fieldOrder) apply(vcBytes []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(vcBytes, &raw); err != nil {
+		return nil, err
+	}
+
+	if subjectBytes, ok := raw[credentialSubjectField]; ok && len(o.subject) > 0 {
+		reordered, err := o.applyToSubject(subjectBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		raw[credentialSubjectField] = reordered
+	}
+
+	return marshalOrderedObject(raw, o.top)
+}
+
+func (o *fieldOrder) applyToSubject(subjectBytes json.RawMessage) (json.RawMessage, error) {
+	subjectBytes = bytes.TrimSpace(subjectBytes)
+
+	switch {
+	case len(subjectBytes) == 0:
+		return subjectBytes, nil
+	case subjectBytes[0] == '{':
+		return reorderObject(subjectBytes, o.subject[0])
+	case subjectBytes[0] != '[':
+		return subjectBytes, nil
+	}
+
+	var subjects []json.RawMessage
+
+	if err := json.Unmarshal(subjectBytes, &subjects); err != nil {
+		return nil, err
+	}
+
+	for i, s := range subjects {
+		if i >= len(o.subject) || len(o.subject[i]) == 0 || !bytes.HasPrefix(bytes.TrimSpace(s), []byte("{")) {
+			continue
+		}
+
+		reordered, err := reorderObject(s, o.subject[i])
+		if err != nil {
+			return nil, err
+		}
+
+		subjects[i] = reordered
+	}
+
+	return json.Marshal(subjects)
+}
+
+// objectKeys returns the keys of JSON object in the order they appear in the document.
+func objectKeys(data []byte) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	t, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if delim, ok := t.(json.Delim); !ok || delim != '{' {
+		return nil, errors.New("JSON object is expected")
+	}
+
+	var keys []string
+
+	for decoder.More() {
+		t, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := t.(string)
+		if !ok {
+			return nil, errors.New("JSON object key is expected")
+		}
+
+		// skip the value
+		var value json.RawMessage
+
+		if err = decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func reorderObject(data []byte, order []string) (json.RawMessage, error) {
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	return marshalOrderedObject(raw, order)
+}
+
+// marshalOrderedObject marshals JSON object putting the fields in the given order first,
+// the rest of the fields follow in the sorted order.
+func marshalOrderedObject(raw map[string]json.RawMessage, order []string) ([]byte, error) {
+	keys := make([]string, 0, len(raw))
+	ordered := make(map[string]bool, len(order))
+
+	for _, k := range order {
+		if _, ok := raw[k]; ok && !ordered[k] {
+			keys = append(keys, k)
+			ordered[k] = true
+		}
+	}
+
+	var rest []string
+
+	for k := range raw {
+		if !ordered[k] {
+			rest = append(rest, k)
+		}
+	}
+
+	sort.Strings(rest)
+	keys = append(keys, rest...)
+
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		keyBytes, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		buf.Write(raw[k])
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPreserveFieldOrder(t *testing.T) {
+	const vcJSON = `{
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1"
+  ],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "id": "http://example.edu/credentials/1872",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "name": "Jayden Doe",
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {"type": "BachelorDegree", "university": "MIT"}
+  },
+  "referenceNumber": 83294847
+}`
+
+	var compacted bytes.Buffer
+
+	require.NoError(t, json.Compact(&compacted, []byte(vcJSON)))
+
+	vc, err := parseTestCredential(t, []byte(vcJSON), WithPreserveFieldOrder())
+	require.NoError(t, err)
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, compacted.String(), string(vcBytes))
+
+	// new fields follow the original ones
+	vc.Expired = vc.Issued
+	vc.CustomFields["alumniOf"] = "MIT"
+
+	vcBytes, err = vc.MarshalJSON()
+	require.NoError(t, err)
+
+	keys, err := objectKeys(vcBytes)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"type", "@context", "issuer", "id", "issuanceDate", "credentialSubject", "referenceNumber",
+		"alumniOf", "expirationDate",
+	}, keys)
+
+	// the order is not preserved by default
+	vc, err = parseTestCredential(t, []byte(vcJSON))
+	require.NoError(t, err)
+
+	vcBytes, err = vc.MarshalJSON()
+	require.NoError(t, err)
+	require.NotEqual(t, compacted.String(), string(vcBytes))
+
+	t.Run("several subjects", func(t *testing.T) {
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(vcJSON), &vcMap))
+
+		subject := vcMap["credentialSubject"]
+		vcMap["credentialSubject"] = []interface{}{subject, "did:example:c276e12ec21ebfeb1f712ebc6f1"}
+
+		vcMapBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vcMapBytes = bytes.Replace(vcMapBytes, []byte(`{"degree":{"type":"BachelorDegree","university":"MIT"},`+
+			`"id":"did:example:ebfeb1f712ebc6f1c276e12ec21","name":"Jayden Doe"}`),
+			[]byte(`{"name":"Jayden Doe","degree":{"type":"BachelorDegree","university":"MIT"},`+
+				`"id":"did:example:ebfeb1f712ebc6f1c276e12ec21"}`), 1)
+		require.Contains(t, string(vcMapBytes), `[{"name":"Jayden Doe",`)
+
+		vc, err := parseTestCredential(t, vcMapBytes, WithPreserveFieldOrder())
+		require.NoError(t, err)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, string(vcMapBytes), string(vcBytes))
+	})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/piprate/json-gold/ld"
+)
+
+// WithInlineContexts option defines JSON-LD context documents by their URLs, e.g. the private contexts which
+// are not hosted anywhere. The contexts are used by the ParseCredential call only (in JSON-LD validation and
+// Linked Data Signatures verification) and take precedence over the ones loaded by the document loader.
+// A lightweight alternative to building a custom document loader (see WithJSONLDDocumentLoader).
+func WithInlineContexts(contexts map[string]json.RawMessage) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.inlineContexts = mergeInlineContexts(opts.inlineContexts, contexts)
+	}
+}
+
+// WithPresInlineContexts option is the same as WithInlineContexts but for the ParsePresentation call.
+// The contexts are used for the enclosed credentials as well.
+func WithPresInlineContexts(contexts map[string]json.RawMessage) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.inlineContexts = mergeInlineContexts(opts.inlineContexts, contexts)
+	}
+}
+
+// mergeInlineContexts returns a new map so the map passed to the option is never modified.
+func mergeInlineContexts(current, contexts map[string]json.RawMessage) map[string]json.RawMessage {
+	merged := make(map[string]json.RawMessage, len(current)+len(contexts))
+
+	for u, c := range current {
+		merged[u] = c
+	}
+
+	for u, c := range contexts {
+		merged[u] = c
+	}
+
+	return merged
+}
+
+// inlineContextLoader loads the inline contexts and delegates loading of other documents to the next loader.
+type inlineContextLoader struct {
+	contexts map[string]json.RawMessage
+	next     ld.DocumentLoader
+}
+
+func withInlineContexts(loader ld.DocumentLoader, contexts map[string]json.RawMessage) ld.DocumentLoader {
+	if len(contexts) == 0 {
+		return loader
+	}
+
+	if loader == nil {
+		loader = ld.NewDefaultDocumentLoader(nil)
+	}
+
+	return &inlineContextLoader{
+		contexts: contexts,
+		next:     loader,
+	}
+}
+
+func (l *inlineContextLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	content, ok := l.contexts[u]
+	if !ok {
+		return l.next.LoadDocument(u)
+	}
+
+	doc, err := ld.DocumentFromReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, ld.NewJsonLdError(ld.LoadingDocumentFailed, err)
+	}
+
+	return &ld.RemoteDocument{DocumentURL: u, Document: doc}, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const privateContextURL = "https://private.example.com/contexts/employee/v1"
+
+const privateContext = `{
+  "@context": {
+    "@version": 1.1,
+    "EmployeeCredential": "https://private.example.com/vocab#EmployeeCredential",
+    "employeeNumber": "https://private.example.com/vocab#employeeNumber"
+  }
+}`
+
+const employeeCredential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://private.example.com/contexts/employee/v1"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "EmployeeCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "employeeNumber": "E-1234"
+  }
+}`
+
+func TestWithInlineContexts(t *testing.T) {
+	inlineContexts := map[string]json.RawMessage{privateContextURL: json.RawMessage(privateContext)}
+
+	parseOpts := []CredentialOpt{WithJSONLDValidation(), WithStrictValidation(), WithDisabledProofCheck()}
+
+	t.Run("credential with private context", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(employeeCredential),
+			append(parseOpts, WithInlineContexts(inlineContexts))...)
+		require.NoError(t, err)
+		require.Equal(t, []string{"VerifiableCredential", "EmployeeCredential"}, vc.Types)
+
+		// the private context can't be loaded without the option
+		_, err = parseTestCredential(t, []byte(employeeCredential), parseOpts...)
+		require.Error(t, err)
+	})
+
+	t.Run("several options are merged", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(employeeCredential), append(parseOpts,
+			WithInlineContexts(inlineContexts),
+			WithInlineContexts(map[string]json.RawMessage{"https://private.example.com/other": []byte(`{}`)}))...)
+		require.NoError(t, err)
+
+		require.Len(t, inlineContexts, 1)
+	})
+
+	t.Run("invalid inline context", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(employeeCredential), append(parseOpts,
+			WithInlineContexts(map[string]json.RawMessage{privateContextURL: []byte(`{`)}))...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "loading remote context failed")
+	})
+
+	t.Run("presentation with credential using private context", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(employeeCredential),
+			append(parseOpts, WithInlineContexts(inlineContexts))...)
+		require.NoError(t, err)
+
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresStrictCredentialValidation(),
+			WithPresInlineContexts(inlineContexts))
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresStrictCredentialValidation())
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,308 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// marshalCanonicalJSON serializes the value using JSON Canonicalization Scheme (JCS, RFC 8785): the object
+// properties are sorted, the strings are minimally escaped and the numbers are formatted as ECMAScript does.
+// The same claims therefore always produce the same bytes, regardless of the field order of Go structs
+// or the way the custom fields were defined.
+func marshalCanonicalJSON(v interface{}) ([]byte, error) {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(jsonBytes))
+	d.UseNumber()
+
+	var value interface{}
+
+	if err = d.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err = writeCanonicalJSON(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// checkExactNumbers checks that every number of JSON document can be represented exactly as IEEE 754 double.
+func checkExactNumbers(data []byte) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var value interface{}
+
+	if err := d.Decode(&value); err != nil {
+		return err
+	}
+
+	return checkExactNumbersOf(value)
+}
+
+func checkExactNumbersOf(value interface{}) error {
+	switch v := value.(type) {
+	case json.Number:
+		_, err := canonicalNumber(v)
+
+		return err
+	case []interface{}:
+		for _, item := range v {
+			if err := checkExactNumbersOf(item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			if err := checkExactNumbersOf(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		n, err := canonicalNumber(v)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString(n)
+	case string:
+		writeCanonicalString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeCanonicalJSON(buf, item); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		return writeCanonicalObject(buf, v)
+	default:
+		return fmt.Errorf("unsupported JSON value of type %T", value)
+	}
+
+	return nil
+}
+
+func writeCanonicalObject(buf *bytes.Buffer, object map[string]interface{}) error {
+	keys := make([]string, 0, len(object))
+
+	for k := range object {
+		keys = append(keys, k)
+	}
+
+	// the properties are sorted by UTF-16 code units of their names
+	sort.Slice(keys, func(i, j int) bool {
+		return lessUTF16(keys[i], keys[j])
+	})
+
+	buf.WriteByte('{')
+
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		writeCanonicalString(buf, k)
+		buf.WriteByte(':')
+
+		if err := writeCanonicalJSON(buf, object[k]); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return nil
+}
+
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+
+	return len(ua) < len(ub)
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+
+	buf.WriteByte('"')
+}
+
+// canonicalNumber formats the JSON number literal canonically. JCS defines the numbers as IEEE 754 doubles,
+// so the literal which can't be represented by a double exactly (e.g. an integer above 2^53 like
+// 9007199254740993) is rejected rather than silently changed.
+func canonicalNumber(number json.Number) (string, error) {
+	f, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid number %s: %w", number, err)
+	}
+
+	n, err := formatCanonicalNumber(f)
+	if err != nil {
+		return "", err
+	}
+
+	literalDigits, literalExp, ok := decimalDigits(number.String())
+	if !ok {
+		return "", fmt.Errorf("invalid number %s", number)
+	}
+
+	doubleDigits, doubleExp, _ := decimalDigits(strconv.FormatFloat(f, 'e', -1, 64))
+
+	if literalDigits != doubleDigits || literalExp != doubleExp {
+		return "", fmt.Errorf("number %s can't be represented exactly as IEEE 754 double", number)
+	}
+
+	return n, nil
+}
+
+// decimalDigits returns the significant digits and the exponent of the decimal number literal,
+// i.e. the literal is ±digits × 10^exp. The digits of zero are empty.
+func decimalDigits(literal string) (string, int, bool) {
+	literal = strings.TrimPrefix(literal, "-")
+
+	exp := 0
+
+	if i := strings.IndexAny(literal, "eE"); i >= 0 {
+		e, err := strconv.Atoi(literal[i+1:])
+		if err != nil {
+			return "", 0, false
+		}
+
+		literal, exp = literal[:i], e
+	}
+
+	if i := strings.IndexByte(literal, '.'); i >= 0 {
+		exp -= len(literal) - i - 1
+		literal = literal[:i] + literal[i+1:]
+	}
+
+	digits := strings.TrimLeft(literal, "0")
+	trimmed := strings.TrimRight(digits, "0")
+
+	if trimmed == "" {
+		return "", 0, true
+	}
+
+	return trimmed, exp + len(digits) - len(trimmed), true
+}
+
+// formatCanonicalNumber formats the number as ECMAScript Number.prototype.toString() does.
+func formatCanonicalNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", errors.New("NaN and Infinity are not allowed in JSON")
+	}
+
+	if f == 0 {
+		// -0 is serialized as 0
+		return "0", nil
+	}
+
+	// the shortest representation which is read back to the same number, e.g. "-1.2345e+21"
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+
+	mantissa, exponent := s[:strings.IndexByte(s, 'e')], s[strings.IndexByte(s, 'e')+1:]
+	digits := strings.Replace(mantissa, ".", "", 1)
+
+	e, err := strconv.Atoi(exponent)
+	if err != nil {
+		return "", fmt.Errorf("format number: %w", err)
+	}
+
+	// the value is 0.digits × 10^n
+	n, k := e+1, len(digits)
+
+	const maxDecimalExponent, minDecimalExponent = 21, -6
+
+	switch {
+	case k <= n && n <= maxDecimalExponent:
+		return sign + digits + strings.Repeat("0", n-k), nil
+	case 0 < n && n <= maxDecimalExponent:
+		return sign + digits[:n] + "." + digits[n:], nil
+	case minDecimalExponent < n && n <= 0:
+		return sign + "0." + strings.Repeat("0", -n) + digits, nil
+	}
+
+	if k > 1 {
+		digits = digits[:1] + "." + digits[1:]
+	}
+
+	expSign := "+"
+	if n-1 < 0 {
+		expSign = "-"
+	}
+
+	return sign + digits + "e" + expSign + strconv.Itoa(int(math.Abs(float64(n-1)))), nil
+}
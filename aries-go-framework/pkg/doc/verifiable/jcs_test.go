@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalCanonicalJSON(t *testing.T) {
+	t.Run("sorted properties and minimal escaping", func(t *testing.T) {
+		value := map[string]interface{}{
+			"\u20ac":     "Euro Sign",
+			"\r":         "Carriage Return",
+			"\ufb33":     "Hebrew Letter Dalet With Dagesh",
+			"1":          "One",
+			"\U0001f600": "Emoji: Grinning Face",
+			"\u0080":     "Control",
+			"\u00f6":     "Latin Small Letter O With Diaeresis",
+			"nested": map[string]interface{}{
+				"b": []interface{}{true, nil, "<tag>\u0001\"\\\t"},
+				"a": 1,
+			},
+		}
+
+		canonical, err := marshalCanonicalJSON(value)
+		require.NoError(t, err)
+		require.Equal(t, "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"nested\":{\"a\":1,\"b\":[true,null,"+
+			"\"<tag>\\u0001\\\"\\\\\\t\"]},\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\","+
+			"\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\","+
+			"\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}", string(canonical))
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		tests := map[float64]string{
+			0:                      "0",
+			math.Copysign(0, -1):   "0",
+			1:                      "1",
+			-1.5:                   "-1.5",
+			83294847:               "83294847",
+			333333333.33333329:     "333333333.3333333",
+			1e21:                   "1e+21",
+			1e20:                   "100000000000000000000",
+			4.5e-7:                 "4.5e-7",
+			0.000001:               "0.000001",
+			-5e-324:                "-5e-324",
+			1.7976931348623157e308: "1.7976931348623157e+308",
+			295147905179352830000:  "295147905179352830000",
+			9007199254740992:       "9007199254740992",
+		}
+
+		for f, expected := range tests {
+			canonical, err := marshalCanonicalJSON(f)
+			require.NoError(t, err)
+			require.Equal(t, expected, string(canonical))
+		}
+	})
+
+	t.Run("number literals", func(t *testing.T) {
+		tests := map[string]string{
+			"9007199254740992":      "9007199254740992",
+			"-9007199254740992":     "-9007199254740992",
+			"1.50":                  "1.5",
+			"0.1":                   "0.1",
+			"1E3":                   "1000",
+			"100e-2":                "1",
+			"-0.0":                  "0",
+			"4.50e-7":               "4.5e-7",
+			"295147905179352830000": "295147905179352830000",
+		}
+
+		for literal, expected := range tests {
+			canonical, err := marshalCanonicalJSON(json.RawMessage(literal))
+			require.NoError(t, err)
+			require.Equal(t, expected, string(canonical))
+		}
+
+		for _, literal := range []string{"9007199254740993", "12345678901234567890", "0.10000000000000000001"} {
+			_, err := marshalCanonicalJSON(json.RawMessage(literal))
+			require.EqualError(t, err, "number "+literal+" can't be represented exactly as IEEE 754 double")
+		}
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := marshalCanonicalJSON(math.Inf(1))
+		require.Error(t, err)
+
+		_, err = formatCanonicalNumber(math.NaN())
+		require.EqualError(t, err, "NaN and Infinity are not allowed in JSON")
+
+		_, err = marshalCanonicalJSON(make(chan int))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetSubjectValue gets a value of the credential subject defined by JSON pointer
+// (https://tools.ietf.org/html/rfc6901), e.g. "/degree/university".
+//
+// The pointer is resolved against JSON form of the subject, so it works the same way for the subject
+// defined as a struct, a map or a Subject with custom fields. If a single subject is defined (even inside
+// an array), the pointer starts at that subject; for several subjects it starts at the array (e.g. "/1/name").
+// An empty pointer returns the whole subject.
+func (vc *Credential) GetSubjectValue(pointer string) (interface{}, error) {
+	subjectBytes, err := subjectToBytes(vc.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential subject: %w", err)
+	}
+
+	if len(subjectBytes) == 0 {
+		return nil, errors.New("no subject is defined")
+	}
+
+	var subject interface{}
+
+	if err = json.Unmarshal(subjectBytes, &subject); err != nil {
+		return nil, fmt.Errorf("unmarshal credential subject: %w", err)
+	}
+
+	return resolveJSONPointer(subject, pointer)
+}
+
+// resolveJSONPointer resolves JSON pointer against the document decoded into the generic JSON values.
+func resolveJSONPointer(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" {
+		return doc, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must start with /", pointer)
+	}
+
+	value := doc
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+
+			if value, ok = v[token]; !ok {
+				return nil, fmt.Errorf("JSON pointer %q: property %q is not found", pointer, token)
+			}
+
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+				return nil, fmt.Errorf("JSON pointer %q: invalid array index %q", pointer, token)
+			}
+
+			if i >= len(v) {
+				return nil, fmt.Errorf("JSON pointer %q: array index %d is out of range", pointer, i)
+			}
+
+			value = v[i]
+
+		default:
+			return nil, fmt.Errorf("JSON pointer %q: cannot resolve %q in a primitive value", pointer, token)
+		}
+	}
+
+	return value, nil
+}
+
+// escapeJSONPointerToken escapes "~" and "/" in the reference token of JSON pointer.
+func escapeJSONPointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
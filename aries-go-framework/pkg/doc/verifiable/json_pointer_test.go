@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_GetSubjectValue(t *testing.T) {
+	t.Run("subject with custom fields", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+		require.NoError(t, err)
+
+		value, err := vc.GetSubjectValue("/degree/type")
+		require.NoError(t, err)
+		require.Equal(t, "BachelorDegree", value)
+
+		value, err = vc.GetSubjectValue("/id")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", value)
+
+		value, err = vc.GetSubjectValue("/degree/name/1")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"@value": "Licence en sciences et arts", "@language": "fr"}, value)
+	})
+
+	t.Run("subject defined as struct", func(t *testing.T) {
+		type degree struct {
+			Type       string `json:"type"`
+			University string `json:"university"`
+		}
+
+		type subject struct {
+			ID     string   `json:"id"`
+			Degree degree   `json:"degree"`
+			Names  []string `json:"names"`
+		}
+
+		vc := &Credential{Subject: subject{
+			ID:     "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			Degree: degree{Type: "BachelorDegree", University: "MIT"},
+			Names:  []string{"Jayden Doe", "J. Doe"},
+		}}
+
+		value, err := vc.GetSubjectValue("/degree/university")
+		require.NoError(t, err)
+		require.Equal(t, "MIT", value)
+
+		value, err = vc.GetSubjectValue("/names/1")
+		require.NoError(t, err)
+		require.Equal(t, "J. Doe", value)
+	})
+
+	t.Run("single and multiple subjects", func(t *testing.T) {
+		vc := &Credential{Subject: []Subject{{
+			ID:           "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: CustomFields{"a/b": map[string]interface{}{"m~n": 1.0}},
+		}}}
+
+		value, err := vc.GetSubjectValue("/a~1b/m~0n")
+		require.NoError(t, err)
+		require.Equal(t, 1.0, value)
+
+		vc.Subject = []map[string]interface{}{{"id": "did:example:1"}, {"id": "did:example:2"}}
+
+		value, err = vc.GetSubjectValue("/1/id")
+		require.NoError(t, err)
+		require.Equal(t, "did:example:2", value)
+
+		value, err = vc.GetSubjectValue("")
+		require.NoError(t, err)
+		require.Len(t, value, 2)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		vc := &Credential{Subject: map[string]interface{}{
+			"id":    "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"names": []interface{}{"Jayden Doe"},
+		}}
+
+		_, err := vc.GetSubjectValue("id")
+		require.EqualError(t, err, `invalid JSON pointer "id": must start with /`)
+
+		_, err = vc.GetSubjectValue("/degree")
+		require.EqualError(t, err, `JSON pointer "/degree": property "degree" is not found`)
+
+		_, err = vc.GetSubjectValue("/id/value")
+		require.EqualError(t, err, `JSON pointer "/id/value": cannot resolve "value" in a primitive value`)
+
+		_, err = vc.GetSubjectValue("/names/01")
+		require.EqualError(t, err, `JSON pointer "/names/01": invalid array index "01"`)
+
+		_, err = vc.GetSubjectValue("/names/-")
+		require.EqualError(t, err, `JSON pointer "/names/-": invalid array index "-"`)
+
+		_, err = vc.GetSubjectValue("/names/1")
+		require.EqualError(t, err, `JSON pointer "/names/1": array index 1 is out of range`)
+
+		_, err = (&Credential{}).GetSubjectValue("/id")
+		require.EqualError(t, err, "no subject is defined")
+
+		_, err = (&Credential{Subject: make(chan int)}).GetSubjectValue("/id")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "marshal credential subject")
+	})
+}
@@ -9,6 +9,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 )
@@ -24,6 +27,27 @@ const (
 	VPType = "VerifiablePresentation"
 )
 
+// termDroppedIssue is the message of ValidationIssue reporting the term dropped by compaction.
+const termDroppedIssue = "term is not defined by JSON-LD context and is dropped by compaction"
+
+// ValidationIssue is a non-fatal issue of JSON-LD validation.
+type ValidationIssue struct {
+	// Path of the document field, e.g. "credentialSubject.degree.university" or "credentialSubject[1].name".
+	Path string
+	// Message describes the issue.
+	Message string
+}
+
+// ValidationReport collects the non-fatal issues of JSON-LD validation (see ValidateCredentialJSONLD).
+type ValidationReport struct {
+	Issues []ValidationIssue
+}
+
+// HasIssues checks if any issue is reported.
+func (r *ValidationReport) HasIssues() bool {
+	return len(r.Issues) > 0
+}
+
 func compactJSONLD(doc string, opts *jsonldCredentialOpts, strict bool) error {
 	docMap, err := toMap(doc)
 	if err != nil {
@@ -39,6 +63,13 @@ func compactJSONLD(doc string, opts *jsonldCredentialOpts, strict bool) error {
 		return fmt.Errorf("compact JSON-LD document: %w", err)
 	}
 
+	if opts.validationReport != nil {
+		opts.validationReport.Issues = append(opts.validationReport.Issues,
+			compactionIssues(docMap, docCompactedMap)...)
+
+		return nil
+	}
+
 	if strict && !mapsHaveSameStructure(docMap, docCompactedMap) {
 		return errors.New("JSON-LD doc has different structure after compaction")
 	}
@@ -46,6 +77,95 @@ func compactJSONLD(doc string, opts *jsonldCredentialOpts, strict bool) error {
 	return nil
 }
 
+// compactionIssues reports the differences of the document structure after compaction
+// (the ones mapsHaveSameStructure fails on), sorted by path.
+func compactionIssues(originalMap, compactedMap map[string]interface{}) []ValidationIssue {
+	var issues []ValidationIssue
+
+	collectCompactionIssues("", compactMap(originalMap), compactMap(compactedMap), &issues)
+
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].Path < issues[j].Path
+	})
+
+	return issues
+}
+
+func collectCompactionIssues(path string, original, compacted map[string]interface{}, issues *[]ValidationIssue) {
+	if reflect.DeepEqual(original, compacted) {
+		return
+	}
+
+	for k, v1 := range original {
+		fieldPath := k
+		if path != "" {
+			fieldPath = path + "." + k
+		}
+
+		v2, present := compacted[k]
+		if !present {
+			*issues = append(*issues, ValidationIssue{
+				Path:    fieldPath,
+				Message: termDroppedIssue,
+			})
+
+			continue
+		}
+
+		collectValueCompactionIssues(fieldPath, v1, v2, issues)
+	}
+
+	for k := range compacted {
+		if _, present := original[k]; !present {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+
+			*issues = append(*issues, ValidationIssue{
+				Path:    fieldPath,
+				Message: "field is added by compaction",
+			})
+		}
+	}
+}
+
+func collectValueCompactionIssues(path string, v1, v2 interface{}, issues *[]ValidationIssue) {
+	switch v1 := v1.(type) {
+	case map[string]interface{}:
+		v2Map, isMap := v2.(map[string]interface{})
+		if !isMap {
+			*issues = append(*issues, ValidationIssue{Path: path, Message: "object is changed by compaction"})
+
+			return
+		}
+
+		collectCompactionIssues(path, v1, v2Map, issues)
+
+	case []interface{}:
+		v2Slice, isSlice := v2.([]interface{})
+		if !isSlice || len(v1) != len(v2Slice) {
+			*issues = append(*issues, ValidationIssue{Path: path, Message: "array is changed by compaction"})
+
+			return
+		}
+
+		for i := range v1 {
+			v1Map, isMap := v1[i].(map[string]interface{})
+			if !isMap {
+				continue
+			}
+
+			v2Map, isMap := v2Slice[i].(map[string]interface{})
+			if !isMap {
+				continue
+			}
+
+			collectCompactionIssues(path+"["+strconv.Itoa(i)+"]", v1Map, v2Map, issues)
+		}
+	}
+}
+
 func mapsHaveSameStructure(originalMap, compactedMap map[string]interface{}) bool {
 	original := compactMap(originalMap)
 	compacted := compactMap(compactedMap)
@@ -150,3 +270,53 @@ func compactValue(v interface{}) interface{} {
 		return cv
 	}
 }
+
+// blankNodePredicates returns the properties of the expanded JSON-LD document which are blank node identifiers
+// (e.g. the terms expanded by "@vocab": "_:"), sorted. RDF canonicalization drops the statements with such
+// predicates, so they are not covered by the linked data proofs.
+func blankNodePredicates(docMap map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]string, error) {
+	// the copy is expanded as the processor can change the document (e.g. add the external contexts)
+	docCopy, err := toMap(docMap)
+	if err != nil {
+		return nil, fmt.Errorf("convert JSON-LD doc to map: %w", err)
+	}
+
+	delete(docCopy, "proof")
+
+	expanded, err := jsonld.Default().Expand(docCopy, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("expand JSON-LD document: %w", err)
+	}
+
+	found := make(map[string]struct{})
+
+	collectBlankNodePredicates(expanded, found)
+
+	predicates := make([]string, 0, len(found))
+
+	for p := range found {
+		predicates = append(predicates, p)
+	}
+
+	sort.Strings(predicates)
+
+	return predicates, nil
+}
+
+func collectBlankNodePredicates(v interface{}, found map[string]struct{}) {
+	switch value := v.(type) {
+	case []interface{}:
+		for _, item := range value {
+			collectBlankNodePredicates(item, found)
+		}
+
+	case map[string]interface{}:
+		for k, item := range value {
+			if strings.HasPrefix(k, "_:") {
+				found[k] = struct{}{}
+			}
+
+			collectBlankNodePredicates(item, found)
+		}
+	}
+}
@@ -8,6 +8,7 @@ package verifiable
 import (
 	_ "embed"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -178,6 +179,140 @@ func Test_compactJSONLDWithExtraUndefinedFields(t *testing.T) {
 	require.EqualError(t, err, "JSON-LD doc has different structure after compaction")
 }
 
+func TestValidateCredentialJSONLD(t *testing.T) {
+	vcJSON := `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "http://127.0.0.1?context=5"
+  ],
+  "id": "http://example.com/credentials/4643",
+  "type": ["VerifiableCredential", "CustomExt12"],
+  "issuer": "https://example.com/issuers/14",
+  "issuanceDate": "2018-02-24T05:28:04Z",
+  "referenceNumber": 83294847,
+  "credentialSubject": {
+    "id": "did:example:abcdef1234567",
+    "name": "Jane Doe",
+    "favoriteFood": "Papaya",
+    "favoriteDrink": "Coffee"
+  }
+}
+`
+
+	loader := createTestDocumentLoader(t, ldcontext.Document{
+		URL:     "http://127.0.0.1?context=5",
+		Content: context5,
+	})
+
+	expectedIssues := []ValidationIssue{
+		{
+			Path:    "credentialSubject.favoriteDrink",
+			Message: "term is not defined by JSON-LD context and is dropped by compaction",
+		},
+		{
+			Path:    "referenceNumber",
+			Message: "term is not defined by JSON-LD context and is dropped by compaction",
+		},
+	}
+
+	t.Run("issues are collected instead of failing", func(t *testing.T) {
+		report, err := ValidateCredentialJSONLD([]byte(vcJSON),
+			WithJSONLDDocumentLoader(loader),
+			WithStrictValidation(),
+			WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		require.True(t, report.HasIssues())
+		require.Equal(t, expectedIssues, report.Issues)
+	})
+
+	t.Run("no issues", func(t *testing.T) {
+		report, err := ValidateCredentialJSONLD([]byte(validCredential),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithStrictValidation(),
+			WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.False(t, report.HasIssues())
+	})
+
+	t.Run("strict validation fails when parsing", func(t *testing.T) {
+		_, err := ParseCredential([]byte(vcJSON),
+			WithJSONLDDocumentLoader(loader),
+			WithJSONLDValidation(),
+			WithStrictValidation(),
+			WithDisabledProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JSON-LD doc has different structure after compaction")
+	})
+
+	t.Run("JSON-LD processing error is fatal", func(t *testing.T) {
+		report, err := ValidateCredentialJSONLD([]byte(vcJSON),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithDisabledProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "compact JSON-LD document")
+		require.Nil(t, report)
+	})
+
+	t.Run("options are reused by concurrent calls", func(t *testing.T) {
+		opts := []CredentialOpt{
+			WithJSONLDDocumentLoader(loader),
+			WithStrictValidation(),
+			WithDisabledProofCheck(),
+		}
+
+		const callsNum = 8
+
+		var wg sync.WaitGroup
+
+		reports := make([]*ValidationReport, callsNum)
+		errs := make([]error, callsNum)
+
+		for i := 0; i < callsNum; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				reports[i], errs[i] = ValidateCredentialJSONLD([]byte(vcJSON), opts...)
+			}(i)
+		}
+
+		wg.Wait()
+
+		for i := 0; i < callsNum; i++ {
+			require.NoError(t, errs[i])
+			require.Equal(t, expectedIssues, reports[i].Issues)
+		}
+	})
+}
+
+func Test_compactionIssues(t *testing.T) {
+	issues := compactionIssues(map[string]interface{}{
+		"credentialSubject": []interface{}{
+			map[string]interface{}{"id": "did:example:1"},
+			map[string]interface{}{"id": "did:example:2", "name": "Jane"},
+		},
+		"evidence": map[string]interface{}{"id": "urn:1", "type": "Evidence"},
+		"terms":    []interface{}{"a", "b"},
+	}, map[string]interface{}{
+		"credentialSubject": []interface{}{
+			map[string]interface{}{"id": "did:example:1"},
+			map[string]interface{}{"id": "did:example:2", "sec:name": "Jane"},
+		},
+		"evidence": "urn:1",
+		"terms":    []interface{}{"a", "b", "c"},
+	})
+
+	require.Equal(t, []ValidationIssue{
+		{Path: "credentialSubject[1].name", Message: "term is not defined by JSON-LD context and is dropped by compaction"},
+		{Path: "credentialSubject[1].sec:name", Message: "field is added by compaction"},
+		{Path: "evidence", Message: "object is changed by compaction"},
+		{Path: "terms", Message: "array is changed by compaction"},
+	}, issues)
+}
+
 func Test_compactJSONLDWithExtraUndefinedSubjectFields(t *testing.T) {
 	contextURL := "http://127.0.0.1?context=6"
 
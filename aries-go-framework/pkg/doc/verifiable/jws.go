@@ -12,6 +12,14 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 )
 
+const (
+	// VCJWTType is JWS typ header value of VC-JWT 2.0 credential.
+	VCJWTType = "vc+jwt"
+
+	// VPJWTType is JWS typ header value of VC-JWT 2.0 presentation.
+	VPJWTType = "vp+jwt"
+)
+
 // Signer defines signer interface which is used to sign VC JWT.
 type Signer interface {
 	Sign(data []byte) ([]byte, error)
@@ -48,30 +56,48 @@ func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
 	return nil
 }
 
-// MarshalJWS serializes JWT presentation claims into signed form (JWS).
-func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
+// marshalJWS serializes JWT claims into signed form (JWS).
+// The headers (e.g. "typ" and "cty") override the default ones, except for "alg" and "kid" which are defined
+// by signatureAlg and keyID only.
+// The claims are serialized canonically (JCS), so the same claims always yield the same signing input.
+func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	headers map[string]interface{}) (string, error) {
 	algName, err := signatureAlg.name()
 	if err != nil {
 		return "", err
 	}
 
-	headers := map[string]interface{}{
+	jwsHeaders := map[string]interface{}{
 		jose.HeaderKeyID: keyID,
 	}
 
-	token, err := jwt.NewSigned(jwtClaims, headers, getJWTSigner(signer, algName))
+	for k, v := range headers {
+		if k == jose.HeaderAlgorithm || k == jose.HeaderKeyID {
+			return "", fmt.Errorf("%s header can't be overridden", k)
+		}
+
+		jwsHeaders[k] = v
+	}
+
+	payload, err := marshalCanonicalJSON(jwtClaims)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("marshal JWT claims: %w", err)
+	}
+
+	// JWS compact serialization uses only protected headers (https://tools.ietf.org/html/rfc7515#section-3.1).
+	jws, err := jose.NewJWS(jwsHeaders, nil, payload, getJWTSigner(signer, algName))
+	if err != nil {
+		return "", fmt.Errorf("create JWS: %w", err)
 	}
 
-	return token.Serialize(false)
+	return jws.SerializeCompact(false)
 }
 
 func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, claims interface{}) error {
 	var verifier jose.SignatureVerifier
 
 	if checkProof {
-		verifier = jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))
+		verifier = jwt.NewVerifier(jwt.KeyResolverFunc(fetcher), registeredJWSVerifiers()...)
 	} else {
 		verifier = &noVerifier{}
 	}
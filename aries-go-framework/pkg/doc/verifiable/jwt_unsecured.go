@@ -6,19 +6,45 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 )
 
+// ErrUnsecuredJWT is returned when the credential or presentation is defined as unsecured JWT ("alg": "none")
+// and its proof is checked, unless the unsecured JWTs are allowed explicitly
+// (see WithAllowUnsecuredJWT and WithPresAllowUnsecuredJWT).
+var ErrUnsecuredJWT = errors.New(`unsecured JWT ("alg": "none") is not allowed`)
+
+// unsecuredJWTSigner produces the empty signature of unsecured JWT ("alg": "none").
+type unsecuredJWTSigner struct{}
+
+func (unsecuredJWTSigner) Sign([]byte) ([]byte, error) {
+	return []byte{}, nil
+}
+
+func (unsecuredJWTSigner) Headers() jose.Headers {
+	return jose.Headers{
+		jose.HeaderAlgorithm: jwt.AlgorithmNone,
+		jose.HeaderType:      jwt.TypeJWT,
+	}
+}
+
+// marshalUnsecuredJWT serializes the claims canonically (JCS) as the signed JWT does (see marshalJWS).
 func marshalUnsecuredJWT(headers jose.Headers, claims interface{}) (string, error) {
-	token, err := jwt.NewUnsecured(claims, headers)
+	payload, err := marshalCanonicalJSON(claims)
+	if err != nil {
+		return "", fmt.Errorf("marshal unsecured JWT: %w", err)
+	}
+
+	jws, err := jose.NewJWS(headers, nil, payload, unsecuredJWTSigner{})
 	if err != nil {
 		return "", fmt.Errorf("marshal unsecured JWT: %w", err)
 	}
 
-	return token.Serialize(false)
+	return jws.SerializeCompact(false)
 }
 
 func unmarshalUnsecuredJWT(rawJWT string, claims interface{}) error {
@@ -6,6 +6,9 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,6 +30,15 @@ func TestUnsecuredJWT(t *testing.T) {
 
 	require.Equal(t, claims, claimsParsed)
 
+	// the claims are serialized canonically as the signed JWT ones
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(serializedJWT, ".")[1])
+	require.NoError(t, err)
+	require.Equal(t, `{"productIds":[1,2],"sub":"user123"}`, string(payload))
+
+	_, err = marshalUnsecuredJWT(headers, map[string]interface{}{"n": json.Number("9007199254740993")})
+	require.EqualError(t, err, "marshal unsecured JWT: number 9007199254740993 can't be represented exactly "+
+		"as IEEE 754 double")
+
 	// marshal with invalid claims
 	invalidClaims := map[string]interface{}{"error": map[chan int]interface{}{make(chan int): 6}}
 	serializedJWT, err = marshalUnsecuredJWT(headers, invalidClaims)
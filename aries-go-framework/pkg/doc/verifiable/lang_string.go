@@ -0,0 +1,210 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LangString is a language-tagged string value as defined by JSON-LD
+// (https://www.w3.org/TR/json-ld11/#string-internationalization), e.g. {"@value": "...", "@language": "en"}.
+type LangString struct {
+	Value     string `json:"@value"`
+	Language  string `json:"@language,omitempty"`
+	Direction string `json:"@direction,omitempty"`
+}
+
+// SubjectLangValue gets a string value of the credential subject property defined by path in the preferred
+// language. Path consists of property names separated by "." (e.g. "degree.name").
+//
+// The property can be defined as a plain string, a language-tagged string object, an array of such objects or
+// a language map ({"en": "...", "fr": "..."}). If there is no value in the preferred language, a value of
+// the same primary language (e.g. "en-US" for "en") is taken, then a value without language and finally
+// the first value defined.
+func (vc *Credential) SubjectLangValue(path, lang string) (string, error) {
+	subject, err := vc.subjectMap()
+	if err != nil {
+		return "", err
+	}
+
+	var value interface{} = subject
+
+	for _, name := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("subject property %s is not found", path)
+		}
+
+		if value, ok = m[name]; !ok {
+			return "", fmt.Errorf("subject property %s is not found", path)
+		}
+	}
+
+	values, err := parseLangStrings(value)
+	if err != nil {
+		return "", fmt.Errorf("subject property %s: %w", path, err)
+	}
+
+	return pickLangString(values, lang).Value, nil
+}
+
+// IssuerName returns a name of the credential issuer in the preferred language. The name can be defined
+// in any form supported by SubjectLangValue; the same language fallback rules apply.
+// An empty string is returned if the issuer has no name or the name is of unsupported form.
+func (vc *Credential) IssuerName(preferredLang string) string {
+	name, ok := vc.Issuer.CustomFields["name"]
+	if !ok {
+		return ""
+	}
+
+	values, err := parseLangStrings(name)
+	if err != nil {
+		return ""
+	}
+
+	return pickLangString(values, preferredLang).Value
+}
+
+// subjectMap returns the single subject of the credential as JSON-like map.
+func (vc *Credential) subjectMap() (map[string]interface{}, error) {
+	subjectBytes, err := subjectToBytes(vc.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential subject: %w", err)
+	}
+
+	var subject interface{}
+
+	if len(subjectBytes) > 0 {
+		if err = json.Unmarshal(subjectBytes, &subject); err != nil {
+			return nil, fmt.Errorf("unmarshal credential subject: %w", err)
+		}
+	}
+
+	switch s := subject.(type) {
+	case map[string]interface{}:
+		return s, nil
+
+	case string:
+		return map[string]interface{}{"id": s}, nil
+
+	case []interface{}:
+		if len(s) == 1 {
+			if m, ok := s[0].(map[string]interface{}); ok {
+				return m, nil
+			}
+		}
+
+		return nil, errors.New("more than one subject is defined")
+
+	default:
+		return nil, errors.New("no subject is defined")
+	}
+}
+
+func parseLangStrings(v interface{}) ([]LangString, error) {
+	switch value := v.(type) {
+	case string:
+		return []LangString{{Value: value}}, nil
+
+	case map[string]interface{}:
+		if _, ok := value["@value"]; ok {
+			ls, err := parseLangString(value)
+			if err != nil {
+				return nil, err
+			}
+
+			return []LangString{ls}, nil
+		}
+
+		// language map
+		langs := make([]string, 0, len(value))
+		for lang := range value {
+			langs = append(langs, lang)
+		}
+
+		sort.Strings(langs)
+
+		values := make([]LangString, 0, len(value))
+
+		for _, lang := range langs {
+			s, ok := value[lang].(string)
+			if !ok {
+				return nil, fmt.Errorf("value of language %s is not a string", lang)
+			}
+
+			values = append(values, LangString{Value: s, Language: lang})
+		}
+
+		return values, nil
+
+	case []interface{}:
+		values := make([]LangString, 0, len(value))
+
+		for _, item := range value {
+			itemValues, err := parseLangStrings(item)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, itemValues...)
+		}
+
+		return values, nil
+
+	default:
+		return nil, errors.New("value is not a language string")
+	}
+}
+
+func parseLangString(m map[string]interface{}) (LangString, error) {
+	var ls LangString
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return LangString{}, err
+	}
+
+	if err = json.Unmarshal(b, &ls); err != nil {
+		return LangString{}, fmt.Errorf("value is not a language string: %w", err)
+	}
+
+	return ls, nil
+}
+
+func pickLangString(values []LangString, lang string) LangString {
+	if len(values) == 0 {
+		return LangString{}
+	}
+
+	for _, v := range values {
+		if strings.EqualFold(v.Language, lang) {
+			return v
+		}
+	}
+
+	for _, v := range values {
+		if strings.EqualFold(primaryLanguage(v.Language), primaryLanguage(lang)) {
+			return v
+		}
+	}
+
+	for _, v := range values {
+		if v.Language == "" {
+			return v
+		}
+	}
+
+	return values[0]
+}
+
+// primaryLanguage returns primary language subtag of the language tag (e.g. "en" for "en-US").
+func primaryLanguage(lang string) string {
+	return strings.Split(lang, "-")[0]
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const credentialWithLangStrings = `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": [
+    "VerifiableCredential",
+    "UniversityDegreeCredential"
+  ],
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "BachelorDegree",
+      "name": [
+        {
+          "@value": "Bachelor of Science and Arts",
+          "@language": "en"
+        },
+        {
+          "@value": "Licence en sciences et arts",
+          "@language": "fr"
+        }
+      ]
+    }
+  },
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z"
+}
+`
+
+func TestCredential_SubjectLangValue(t *testing.T) {
+	t.Run("language-tagged strings", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+		require.NoError(t, err)
+
+		value, err := vc.SubjectLangValue("degree.name", "fr")
+		require.NoError(t, err)
+		require.Equal(t, "Licence en sciences et arts", value)
+
+		value, err = vc.SubjectLangValue("degree.name", "en-US")
+		require.NoError(t, err)
+		require.Equal(t, "Bachelor of Science and Arts", value)
+
+		// fallback to the first value
+		value, err = vc.SubjectLangValue("degree.name", "de")
+		require.NoError(t, err)
+		require.Equal(t, "Bachelor of Science and Arts", value)
+
+		// plain string
+		value, err = vc.SubjectLangValue("degree.type", "fr")
+		require.NoError(t, err)
+		require.Equal(t, "BachelorDegree", value)
+	})
+
+	t.Run("language map and value without language", func(t *testing.T) {
+		vc := &Credential{Subject: map[string]interface{}{
+			"id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"name": map[string]interface{}{
+				"fr": "Université",
+				"en": "University",
+			},
+			"title": []interface{}{
+				map[string]interface{}{"@value": "Titel", "@language": "de"},
+				map[string]interface{}{"@value": "Title"},
+			},
+		}}
+
+		value, err := vc.SubjectLangValue("name", "fr-CA")
+		require.NoError(t, err)
+		require.Equal(t, "Université", value)
+
+		value, err = vc.SubjectLangValue("name", "es")
+		require.NoError(t, err)
+		require.Equal(t, "University", value)
+
+		value, err = vc.SubjectLangValue("title", "fr")
+		require.NoError(t, err)
+		require.Equal(t, "Title", value)
+	})
+
+	t.Run("marshalling preserves language-tagged strings", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(credentialWithLangStrings))
+		require.NoError(t, err)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		require.JSONEq(t, credentialWithLangStrings, string(vcBytes))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		vc := &Credential{Subject: map[string]interface{}{
+			"id":     "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"degree": "BachelorDegree",
+			"age":    21,
+			"name":   map[string]interface{}{"en": 1},
+		}}
+
+		_, err := vc.SubjectLangValue("unknown", "en")
+		require.EqualError(t, err, "subject property unknown is not found")
+
+		_, err = vc.SubjectLangValue("degree.name", "en")
+		require.EqualError(t, err, "subject property degree.name is not found")
+
+		_, err = vc.SubjectLangValue("age", "en")
+		require.EqualError(t, err, "subject property age: value is not a language string")
+
+		_, err = vc.SubjectLangValue("name", "en")
+		require.EqualError(t, err, "subject property name: value of language en is not a string")
+
+		vc.Subject = []map[string]interface{}{{"id": "did:example:1"}, {"id": "did:example:2"}}
+
+		_, err = vc.SubjectLangValue("name", "en")
+		require.EqualError(t, err, "more than one subject is defined")
+
+		_, err = (&Credential{}).SubjectLangValue("name", "en")
+		require.EqualError(t, err, "no subject is defined")
+	})
+}
+
+func TestCredential_IssuerName(t *testing.T) {
+	newVC := func(name interface{}) *Credential {
+		return &Credential{Issuer: Issuer{
+			ID:           "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			CustomFields: CustomFields{"name": name},
+		}}
+	}
+
+	vc := newVC("Example University")
+	require.Equal(t, "Example University", vc.IssuerName("fr"))
+
+	vc = newVC(map[string]interface{}{"en": "Example University", "fr": "Université de Exemple"})
+	require.Equal(t, "Université de Exemple", vc.IssuerName("fr"))
+	require.Equal(t, "Example University", vc.IssuerName("en-GB"))
+
+	vc = newVC([]interface{}{
+		map[string]interface{}{"@value": "Example University", "@language": "en"},
+		map[string]interface{}{"@value": "Exemple d'Université", "@language": "fr"},
+	})
+	require.Equal(t, "Exemple d'Université", vc.IssuerName("fr-CA"))
+	require.Equal(t, "Example University", vc.IssuerName("de"))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+	require.Equal(t, "Example University", vc.IssuerName("en"))
+
+	require.Empty(t, newVC(42).IssuerName("en"))
+	require.Empty(t, (&Credential{Issuer: Issuer{ID: "did:example:1"}}).IssuerName("en"))
+}
+
+func TestLangString_MarshalJSON(t *testing.T) {
+	lsBytes, err := json.Marshal(LangString{Value: "University", Language: "en"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"@value": "University", "@language": "en"}`, string(lsBytes))
+
+	var ls LangString
+
+	err = json.Unmarshal([]byte(`{"@value": "Université", "@language": "fr", "@direction": "ltr"}`), &ls)
+	require.NoError(t, err)
+	require.Equal(t, LangString{Value: "Université", Language: "fr", Direction: "ltr"}, ls)
+}
@@ -6,7 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -19,8 +19,25 @@ import (
 
 const (
 	resolveIDParts = 2
+
+	// a purpose of proof which is set if LinkedDataProofContext.Purpose is not defined.
+	defaultProofPurpose = "assertionMethod"
+
+	// a purpose of presentation proof which is set if LinkedDataProofContext.Purpose is not defined.
+	defaultPresentationProofPurpose = "authentication"
 )
 
+// ErrDuplicateProof is returned when adding a linked data proof to the document which already has a proof
+// with the same verification method and purpose.
+var ErrDuplicateProof = errors.New("proof with the same verification method and purpose already exists")
+
+// ErrUnsafeCanonicalization is returned when adding a linked data proof with SafeCanonicalization to the document
+// having the terms not defined by its JSON-LD context or expanded to blank node identifiers (e.g. by
+// "@vocab": "_:"). Such terms are dropped by canonicalization, so they would not be covered by the signature.
+// The embedded proofs of the documents with blank node terms are rejected on verification
+// with WithSafeCanonicalizationCheck as well.
+var ErrUnsafeCanonicalization = errors.New("terms are dropped by canonicalization and are not signed")
+
 type keyResolverAdapter struct {
 	pubKeyFetcher PublicKeyFetcher
 }
@@ -57,13 +74,29 @@ type LinkedDataProofContext struct {
 	SignatureType           string                  // required
 	Suite                   signer.SignatureSuite   // required
 	SignatureRepresentation SignatureRepresentation // required
-	Created                 *time.Time              // optional
+	Created                 *time.Time              // optional, the current UTC time truncated to seconds by default
+	Expires                 *time.Time              // optional
+	ProofTimePrecision      time.Duration           // optional
 	VerificationMethod      string                  // optional
 	Challenge               string                  // optional
 	Domain                  string                  // optional
 	Purpose                 string                  // optional
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// AdditionalProofFields are the extra fields of the proof, e.g. "capability" and "capabilityAction"
+	// of ZCAP-LD proof with "capabilityInvocation" Purpose. They are serialized into the proof and signed
+	// along with the other proof options, so the terms must be defined by the JSON-LD context of the proof.
+	AdditionalProofFields map[string]interface{}
+	// AllowDuplicateProof allows to add a proof with the same verification method and purpose as one of
+	// the existing proofs of the Verifiable Credential or Presentation, e.g. to sign it by another suite.
+	AllowDuplicateProof bool
+	// SetHolderFromVM sets the holder of the Verifiable Presentation to the DID of VerificationMethod
+	// if the holder is not defined. Ignored for the Verifiable Credential.
+	SetHolderFromVM bool
+	// SafeCanonicalization rejects the document with ErrUnsafeCanonicalization if any of its terms is not
+	// defined by the JSON-LD context or is expanded to a blank node, i.e. the signature would not cover it.
+	// The existing proofs are not checked.
+	SafeCanonicalization bool
 }
 
 func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite,
@@ -99,46 +132,148 @@ func mapJSONLDProcessorOpts(jsonldOpts *jsonldCredentialOpts) []jsonld.Processor
 	return processorOpts
 }
 
-type rawProof struct {
-	Proof json.RawMessage `json:"proof,omitempty"`
-}
-
-// addLinkedDataProof adds a new proof to the JSON-LD document (VC or VP). It returns a slice
-// of the proofs which were already present appended with a newly created proof.
-func addLinkedDataProof(context *LinkedDataProofContext, jsonldBytes []byte,
+// addLinkedDataProof adds a new proof to the JSON-LD document (VC or VP unmarshalled from JSON) in place.
+// It returns a slice of the proofs which were already present appended with a newly created proof.
+func addLinkedDataProof(context *LinkedDataProofContext, jsonldDoc map[string]interface{},
 	opts ...jsonld.ProcessorOpts) ([]Proof, error) {
+	if context.SafeCanonicalization {
+		if err := checkSafeCanonicalization(jsonldDoc, opts...); err != nil {
+			return nil, fmt.Errorf("add linked data proof: %w", err)
+		}
+
+		if err := checkBlankNodePredicates(jsonldDoc, opts...); err != nil {
+			return nil, fmt.Errorf("add linked data proof: %w", err)
+		}
+	}
+
 	documentSigner := signer.New(context.Suite)
 
-	vcWithNewProofBytes, err := documentSigner.Sign(mapContext(context), jsonldBytes, opts...)
+	err := documentSigner.SignObject(mapContext(context), jsonldDoc, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("add linked data proof: %w", err)
 	}
 
-	// Get a proof from json-ld document.
-	var rProof rawProof
-
-	err = json.Unmarshal(vcWithNewProofBytes, &rProof)
+	// Get the proofs from json-ld document.
+	proofMaps, err := getProofs(jsonldDoc["proof"])
 	if err != nil {
 		return nil, err
 	}
 
-	proofs, err := parseProof(rProof.Proof)
-	if err != nil {
-		return nil, err
+	proofs := make([]Proof, len(proofMaps))
+	for i := range proofMaps {
+		proofs[i] = proofMaps[i]
 	}
 
 	return proofs, nil
 }
 
+// checkSafeCanonicalization checks that no term of the JSON-LD document (except the proofs) is dropped
+// by compaction, i.e. all of them are defined by the JSON-LD context and are canonicalized.
+func checkSafeCanonicalization(jsonldDoc map[string]interface{}, opts ...jsonld.ProcessorOpts) error {
+	// the copy is compacted as the processor can change the document (e.g. add the external contexts)
+	docMap, err := toMap(jsonldDoc)
+	if err != nil {
+		return fmt.Errorf("convert JSON-LD doc to map: %w", err)
+	}
+
+	delete(docMap, "proof")
+
+	docCompactedMap, err := jsonld.Default().Compact(docMap, nil, opts...)
+	if err != nil {
+		return fmt.Errorf("compact JSON-LD document: %w", err)
+	}
+
+	var dropped []string
+
+	for _, issue := range compactionIssues(docMap, docCompactedMap) {
+		if issue.Message == termDroppedIssue {
+			dropped = append(dropped, issue.Path)
+		}
+	}
+
+	if len(dropped) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnsafeCanonicalization, strings.Join(dropped, ", "))
+	}
+
+	return nil
+}
+
+// checkBlankNodePredicates checks that no term of the JSON-LD document (except the proofs) is expanded
+// to a blank node predicate. Such terms are dropped by canonicalization and can be changed without breaking the proof.
+func checkBlankNodePredicates(jsonldDoc map[string]interface{}, opts ...jsonld.ProcessorOpts) error {
+	blankNodes, err := blankNodePredicates(jsonldDoc, opts...)
+	if err != nil {
+		return err
+	}
+
+	if len(blankNodes) > 0 {
+		return fmt.Errorf("%w: blank node predicates %s", ErrUnsafeCanonicalization, strings.Join(blankNodes, ", "))
+	}
+
+	return nil
+}
+
+// checkDuplicateProof returns ErrDuplicateProof if there is a proof with the same verification method
+// and purpose as the proof to be created using the context, whatever the proof type is.
+// The proofs without purpose (and the context without it) are of the default purpose of the document,
+// i.e. "assertionMethod" for credentials and "authentication" for presentations.
+func checkDuplicateProof(context *LinkedDataProofContext, proofs []Proof, defaultPurpose string) error {
+	if context.AllowDuplicateProof || context.VerificationMethod == "" {
+		return nil
+	}
+
+	purpose := context.Purpose
+	if purpose == "" {
+		purpose = defaultPurpose
+	}
+
+	for _, p := range proofs {
+		verificationMethod := safeStringValue(p["verificationMethod"])
+		if verificationMethod == "" {
+			verificationMethod = safeStringValue(p["creator"])
+		}
+
+		proofPurpose := safeStringValue(p["proofPurpose"])
+		if proofPurpose == "" {
+			proofPurpose = defaultPurpose
+		}
+
+		if verificationMethod == context.VerificationMethod && proofPurpose == purpose {
+			return ErrDuplicateProof
+		}
+	}
+
+	return nil
+}
+
+// proofCreated returns the creation time of the proof truncated to ProofTimePrecision (if it's defined),
+// e.g. time.Second precision makes "created" to be serialized in RFC3339 form without a fractional part.
+// If Created is not defined, the current UTC time of the package clock truncated to seconds is used.
+// As "created" is a part of the signed data, the truncation is applied before signing.
+func proofCreated(context *LinkedDataProofContext) *time.Time {
+	created := now().UTC().Truncate(time.Second)
+	if context.Created != nil {
+		created = *context.Created
+	}
+
+	if context.ProofTimePrecision > 0 {
+		created = created.Truncate(context.ProofTimePrecision)
+	}
+
+	return &created
+}
+
 func mapContext(context *LinkedDataProofContext) *signer.Context {
 	return &signer.Context{
 		SignatureType:           context.SignatureType,
 		SignatureRepresentation: proof.SignatureRepresentation(context.SignatureRepresentation),
-		Created:                 context.Created,
+		Created:                 proofCreated(context),
+		Expires:                 context.Expires,
 		VerificationMethod:      context.VerificationMethod,
 		Challenge:               context.Challenge,
 		Domain:                  context.Domain,
 		Purpose:                 context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		AdditionalProofFields:   context.AdditionalProofFields,
 	}
 }
@@ -194,3 +194,66 @@ func prepareVCWithSecp256k1LDP(t *testing.T, vcJSON string, signer Signer) *Cred
 
 	return vc
 }
+
+func TestLinkedDataProofContext_SafeCanonicalization(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:123456#key1",
+		SafeCanonicalization:    true,
+	}
+
+	t.Run("all terms are defined", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		require.NoError(t, vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t))))
+		require.Len(t, vc.Proofs, 1)
+
+		// the existing proof is not checked
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		require.NoError(t, vp.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t))))
+	})
+
+	t.Run("undefined terms are rejected", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.CustomFields = CustomFields{"undefinedTerm": "value", "otherUndefinedTerm": "value"}
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.True(t, errors.Is(err, ErrUnsafeCanonicalization))
+		require.EqualError(t, err, "add linked data proof: "+
+			"terms are dropped by canonicalization and are not signed: otherUndefinedTerm, undefinedTerm")
+		require.Empty(t, vc.Proofs)
+
+		// the terms are silently dropped without SafeCanonicalization
+		unsafeContext := *ldpContext
+		unsafeContext.SafeCanonicalization = false
+
+		require.NoError(t, vc.AddLinkedDataProof(&unsafeContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t))))
+	})
+
+	t.Run("blank node terms are rejected", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.CustomContext = []interface{}{map[string]interface{}{"@vocab": "_:"}}
+		vc.Subject = map[string]interface{}{
+			"id":            "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"favoriteColor": "blue",
+		}
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.True(t, errors.Is(err, ErrUnsafeCanonicalization))
+		require.EqualError(t, err, "add linked data proof: "+
+			"terms are dropped by canonicalization and are not signed: blank node predicates _:favoriteColor")
+		require.Empty(t, vc.Proofs)
+	})
+}
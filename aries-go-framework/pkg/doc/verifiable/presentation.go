@@ -6,9 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
 	jsonld "github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
@@ -16,8 +19,12 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
+// defaultMaxNestingDepth is a default max depth of presentations nested into the presentation.
+const defaultMaxNestingDepth = 3
+
 const basePresentationSchema = `
 {
   "required": [
@@ -169,11 +176,16 @@ type Presentation struct {
 	Context       []string
 	CustomContext []interface{}
 	ID            string
-	Type          []string
-	credentials   []interface{}
-	Holder        string
-	Proofs        []Proof
-	CustomFields  CustomFields
+	// Type is parsed from "type" defined either as a single string or as an array, it's marshalled
+	// as a single string if there is only one type and as an array otherwise (the same as Credential Types).
+	Type         []string
+	credentials  []interface{}
+	Holder       string
+	Proofs       []Proof
+	CustomFields CustomFields
+
+	// jwt is the original JWT of the presentation nested into another one (see JWTString)
+	jwt string
 }
 
 // NewPresentation creates a new Presentation with default context and type with the provided credentials.
@@ -206,6 +218,7 @@ func WithCredentials(cs ...*Credential) CreatePresentationOpt {
 }
 
 // WithJWTCredentials sets the provided base64url encoded JWT credentials into the presentation.
+// Only the compact form of the JWT is checked, the JWT is neither decoded nor verified.
 func WithJWTCredentials(cs ...string) CreatePresentationOpt {
 	return func(p *Presentation) error {
 		for _, c := range cs {
@@ -220,7 +233,74 @@ func WithJWTCredentials(cs ...string) CreatePresentationOpt {
 	}
 }
 
+// WithJWTCredentialsNoVerify sets the provided JWT credentials into the presentation as opaque strings.
+// Unlike WithJWTCredentials, no attempt to check, decode or verify the JWT is made, so the holder can
+// present the credential without the issuer's key. The JWTs are marshalled verbatim, they are checked
+// by the verifier when the presentation is parsed.
+func WithJWTCredentialsNoVerify(cs ...string) CreatePresentationOpt {
+	return func(p *Presentation) error {
+		for _, c := range cs {
+			if c == "" {
+				return errors.New("credential JWT is empty")
+			}
+
+			p.credentials = append(p.credentials, c)
+		}
+
+		return nil
+	}
+}
+
+// WithNestedPresentationJWT nests the provided presentations in JWT form (e.g. the signed VPs received from
+// the holders) into the presentation as items of verifiableCredential. The JWTs are neither decoded nor
+// verified, they are marshalled verbatim, so their signatures remain valid. ParsePresentation decodes them
+// into the nested presentations keeping the original JWTs (see NestedPresentations and JWTString).
+func WithNestedPresentationJWT(vps ...string) CreatePresentationOpt {
+	return func(p *Presentation) error {
+		for _, vp := range vps {
+			if !isNestedPresentation(vp) {
+				return errors.New("nested presentation is not JWT with vp claim")
+			}
+
+			p.credentials = append(p.credentials, vp)
+		}
+
+		return nil
+	}
+}
+
+// WithPresentationContext adds the provided contexts to the default one of the presentation
+// (e.g. to define extension types of the presentation). Duplicated contexts are skipped.
+func WithPresentationContext(ctx ...string) CreatePresentationOpt {
+	return func(p *Presentation) error {
+		for _, c := range ctx {
+			if c == "" {
+				return errors.New("presentation context is empty")
+			}
+
+			if !hasContext(p.Context, c) {
+				p.Context = append(p.Context, c)
+			}
+		}
+
+		return nil
+	}
+}
+
+func hasContext(contexts []string, ctx string) bool {
+	for _, c := range contexts {
+		if c == ctx {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MarshalJSON converts Verifiable Presentation to JSON bytes.
+// The credentials are marshalled into "verifiableCredential" array exactly in the order they were added,
+// and ParsePresentation keeps the order of the array, so the index of the credential (e.g. referenced
+// by presentation submission) is stable across marshal/unmarshal cycles.
 func (vp *Presentation) MarshalJSON() ([]byte, error) {
 	raw, err := vp.raw()
 	if err != nil {
@@ -236,16 +316,40 @@ func (vp *Presentation) MarshalJSON() ([]byte, error) {
 }
 
 // JWTClaims converts Verifiable Presentation into JWT Presentation claims, which can be than serialized
-// e.g. into JWS.
+// e.g. into JWS. The linked data proofs of the presentation (see AddLinkedDataProof) are kept in "vp" claim,
+// so the presentation can be signed by linked data proof and wrapped into JWS envelope as well.
+// The fields removed from minimized "vp" claim are restored from "jti" and "iss" claims on parsing,
+// so the linked data proof is checked against the same document in both cases.
 func (vp *Presentation) JWTClaims(audience []string, minimizeVP bool) (*JWTPresClaims, error) {
 	return newJWTPresClaims(vp, audience, minimizeVP)
 }
 
-// Credentials returns current credentials of presentation.
+// Credentials returns current credentials of presentation in the order of "verifiableCredential".
 func (vp *Presentation) Credentials() []interface{} {
 	return vp.credentials
 }
 
+// NestedPresentations returns presentations enclosed into the presentation (as items of verifiableCredential).
+// The presentations nested by WithNestedPresentationJWT are returned after the presentation is parsed only.
+func (vp *Presentation) NestedPresentations() []*Presentation {
+	var nested []*Presentation
+
+	for _, cred := range vp.credentials {
+		if nestedVP, ok := cred.(*Presentation); ok {
+			nested = append(nested, nestedVP)
+		}
+	}
+
+	return nested
+}
+
+// JWTString returns the original JWT (compact JWS or unsecured JWT) of the presentation nested into another one,
+// e.g. to forward it as is, and whether the nested presentation is defined as JWT.
+// The nested presentation defined as JWT is marshalled back into the same JWT.
+func (vp *Presentation) JWTString() (string, bool) {
+	return vp.jwt, vp.jwt != ""
+}
+
 // AddCredentials adds credentials to presentation.
 func (vp *Presentation) AddCredentials(credentials ...*Credential) {
 	for _, credential := range credentials {
@@ -253,6 +357,135 @@ func (vp *Presentation) AddCredentials(credentials ...*Credential) {
 	}
 }
 
+// AddCredential appends the credential to the presentation. The credential is marshalled in JSON form
+// the same way as the ones set by WithCredentials.
+// An error is returned if the presentation already has a proof as adding the credential would break it.
+func (vp *Presentation) AddCredential(vc *Credential) error {
+	if len(vp.Proofs) > 0 {
+		return errors.New("add credential to presentation with proof")
+	}
+
+	if vc == nil {
+		return errors.New("credential is not defined")
+	}
+
+	vp.credentials = append(vp.credentials, vc)
+
+	return nil
+}
+
+// AddJWTCredential appends the credential in JWS or unsecured JWT form to the presentation. The credential is
+// kept as is, the same way as the ones set by WithJWTCredentials.
+// An error is returned if the presentation already has a proof as adding the credential would break it.
+func (vp *Presentation) AddJWTCredential(jws string) error {
+	if len(vp.Proofs) > 0 {
+		return errors.New("add credential to presentation with proof")
+	}
+
+	if !jose.IsCompactJWS(jws) {
+		return errors.New("credential is not base64url encoded JWT")
+	}
+
+	vp.credentials = append(vp.credentials, jws)
+
+	return nil
+}
+
+// RemoveCredential removes the credentials with the given ID from the presentation. The credentials in JWT form
+// are matched by "jti" claim (or ID of "vc" claim if "jti" is not defined).
+// Existing proofs of the presentation are removed as they are no longer valid.
+// An error is returned if there is no credential with the given ID or the ID is empty (the credentials
+// without ID and nested presentations can't be removed).
+func (vp *Presentation) RemoveCredential(id string) error {
+	if id == "" {
+		return errors.New("credential id is not defined")
+	}
+
+	credentials := make([]interface{}, 0, len(vp.credentials))
+
+	for _, cred := range vp.credentials {
+		if credentialID(cred) != id {
+			credentials = append(credentials, cred)
+		}
+	}
+
+	if len(credentials) == len(vp.credentials) {
+		return fmt.Errorf("credential %s is not found in presentation", id)
+	}
+
+	vp.credentials = credentials
+	vp.Proofs = nil
+
+	return nil
+}
+
+// credentialID returns ID of the credential enclosed into the presentation. An empty string is returned
+// if the ID cannot be read (e.g. for nested presentation).
+func credentialID(cred interface{}) string {
+	switch c := cred.(type) {
+	case *Credential:
+		return c.ID
+
+	case string:
+		return jwtCredentialID(c)
+
+	case []byte:
+		return jsonCredentialID(c)
+
+	case json.RawMessage:
+		return jsonCredentialID(c)
+
+	case map[string]interface{}:
+		id, _ := c["id"].(string)
+
+		return id
+
+	default:
+		return ""
+	}
+}
+
+func jsonCredentialID(credBytes []byte) string {
+	var cred struct {
+		ID string `json:"id"`
+	}
+
+	if err := json.Unmarshal(credBytes, &cred); err != nil {
+		return ""
+	}
+
+	return cred.ID
+}
+
+// jwtCredentialID reads ID of the credential in JWT form without checking its signature.
+func jwtCredentialID(vcJWT string) string {
+	if !jose.IsCompactJWS(vcJWT) {
+		return ""
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(vcJWT, ".")[1])
+	if err != nil {
+		return ""
+	}
+
+	var claims struct {
+		JTI string `json:"jti"`
+		VC  struct {
+			ID string `json:"id"`
+		} `json:"vc"`
+	}
+
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+
+	if claims.JTI != "" {
+		return claims.JTI
+	}
+
+	return claims.VC.ID
+}
+
 // MarshalledCredentials provides marshalled credentials enclosed into Presentation in raw byte array format.
 // They can be used to decode Credentials into struct.
 func (vp *Presentation) MarshalledCredentials() ([]MarshalledCredential, error) {
@@ -278,6 +511,54 @@ func (vp *Presentation) MarshalledCredentials() ([]MarshalledCredential, error)
 	return mCreds, nil
 }
 
+// ParsedCredentials decodes all credentials enclosed into the presentation (in JSON, JWS or unsecured JWT form)
+// into Credential objects using ParseCredential with the given options, so every credential is verified
+// independently (e.g. WithPublicKeyFetcher has to be passed to check proofs of the credentials).
+// Nested presentations (see NestedPresentations) are skipped.
+func (vp *Presentation) ParsedCredentials(opts ...CredentialOpt) ([]*Credential, error) {
+	credentials := make([]*Credential, 0, len(vp.credentials))
+
+	for i, cred := range vp.credentials {
+		var credBytes []byte
+
+		switch c := cred.(type) {
+		case *Presentation:
+			continue
+
+		case *Credential:
+			b, err := c.MarshalJSON()
+			if err != nil {
+				return nil, fmt.Errorf("marshal credential %d of presentation: %w", i, err)
+			}
+
+			credBytes = b
+
+		case string:
+			credBytes = []byte(c)
+
+		case []byte:
+			credBytes = c
+
+		default:
+			b, err := json.Marshal(cred)
+			if err != nil {
+				return nil, fmt.Errorf("marshal credential %d of presentation: %w", i, err)
+			}
+
+			credBytes = b
+		}
+
+		vc, err := ParseCredential(credBytes, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("parse credential %d of presentation: %w", i, err)
+		}
+
+		credentials = append(credentials, vc)
+	}
+
+	return credentials, nil
+}
+
 func (vp *Presentation) raw() (*rawPresentation, error) {
 	proof, err := proofsToRaw(vp.Proofs)
 	if err != nil {
@@ -290,13 +571,34 @@ func (vp *Presentation) raw() (*rawPresentation, error) {
 		Context:      vp.Context,
 		ID:           vp.ID,
 		Type:         typesToRaw(vp.Type),
-		Credential:   vp.credentials,
+		Credential:   credentialsToRaw(vp.credentials),
 		Holder:       vp.Holder,
 		Proof:        proof,
 		CustomFields: vp.CustomFields,
 	}, nil
 }
 
+// credentialsToRaw replaces the nested presentations defined as JWT by their original JWTs.
+func credentialsToRaw(credentials []interface{}) []interface{} {
+	var raw []interface{}
+
+	for i, cred := range credentials {
+		if nestedVP, ok := cred.(*Presentation); ok && nestedVP.jwt != "" {
+			if raw == nil {
+				raw = append([]interface{}{}, credentials...)
+			}
+
+			raw[i] = nestedVP.jwt
+		}
+	}
+
+	if raw == nil {
+		return credentials
+	}
+
+	return raw
+}
+
 // rawPresentation is a basic verifiable credential.
 type rawPresentation struct {
 	Context    interface{}     `json:"@context,omitempty"`
@@ -338,14 +640,46 @@ type presentationOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
 	disabledProofCheck bool
 	ldpSuites          []verifier.SignatureSuite
+	vpLDPSuites        []verifier.SignatureSuite
+	vcLDPSuites        []verifier.SignatureSuite
 	strictValidation   bool
 	requireVC          bool
 	requireProof       bool
+	requireHolder      bool
+
+	strictCredentialValidation bool
+	maxDocumentSize            int
+	keepJWTCredentials         bool
+
+	verificationResult *VerificationResult
+	challenge          string
+	domain             string
+	audience           string
+
+	maxNestingDepth int
+	nestingDepth    int
+	maxProofs       int
+
+	verifyAllEmbedded   bool
+	verifyEmbeddedDepth int
+
+	bestEffortCredentialVerification bool
+
+	httpClient         *http.Client
+	allowUnsecuredJWT  bool
+	vmAuthorizationVDR vdrapi.Registry
+
+	verifyHolderConfirmation bool
+
+	inlineContexts map[string]json.RawMessage
+	trustedHolders map[string]bool
 
 	jsonldCredentialOpts
 }
 
 // PresentationOpt is the Verifiable Presentation decoding option.
+// Like CredentialOpt, the same options can be reused by concurrent ParsePresentation calls,
+// except for WithPresVerificationResult which is written by every call.
 type PresentationOpt func(opts *presentationOpts)
 
 // WithPresPublicKeyFetcher indicates that Verifiable Presentation should be decoded from JWS using
@@ -356,13 +690,31 @@ func WithPresPublicKeyFetcher(fetcher PublicKeyFetcher) PresentationOpt {
 	}
 }
 
-// WithPresEmbeddedSignatureSuites defines the suites which are used to check embedded linked data proof of VP.
+// WithPresEmbeddedSignatureSuites defines the suites which are used to check embedded linked data proof of VP
+// and of the enclosed VCs. The suites can be scoped to the presentation or credential level
+// by WithPresVPSuites and WithPresVCSuites.
 func WithPresEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) PresentationOpt {
 	return func(opts *presentationOpts) {
 		opts.ldpSuites = suites
 	}
 }
 
+// WithPresVPSuites defines the suites which are used to check embedded linked data proof of VP
+// (and of the nested VPs). They take precedence over the suites of WithPresEmbeddedSignatureSuites.
+func WithPresVPSuites(suites ...verifier.SignatureSuite) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.vpLDPSuites = suites
+	}
+}
+
+// WithPresVCSuites defines the suites which are used to check embedded linked data proofs of the VCs
+// enclosed into VP. They take precedence over the suites of WithPresEmbeddedSignatureSuites.
+func WithPresVCSuites(suites ...verifier.SignatureSuite) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.vcLDPSuites = suites
+	}
+}
+
 // WithPresDisabledProofCheck option for disabling of proof check.
 func WithPresDisabledProofCheck() PresentationOpt {
 	return func(opts *presentationOpts) {
@@ -373,12 +725,23 @@ func WithPresDisabledProofCheck() PresentationOpt {
 // WithPresStrictValidation enabled strict JSON-LD validation of VP.
 // In case of JSON-LD validation, the comparison of JSON-LD VP document after compaction with original VP one is made.
 // In case of mismatch a validation exception is raised.
+// The credentials enclosed in JWT form are not covered, use WithPresStrictCredentialValidation to validate
+// every enclosed credential strictly.
 func WithPresStrictValidation() PresentationOpt {
 	return func(opts *presentationOpts) {
 		opts.strictValidation = true
 	}
 }
 
+// WithPresStrictCredentialValidation enables strict JSON-LD validation of every credential enclosed into VP
+// (including the ones in JWT form) the same way as WithStrictValidation does for the credential,
+// i.e. all terms of the credential must be defined by its contexts.
+func WithPresStrictCredentialValidation() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.strictCredentialValidation = true
+	}
+}
+
 // WithPresJSONLDDocumentLoader defines custom JSON-LD document loader. If not defined, when decoding VP
 // a new document loader will be created using CachingJSONLDLoader() if JSON-LD validation is made.
 func WithPresJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) PresentationOpt {
@@ -387,16 +750,220 @@ func WithPresJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) Presenta
 	}
 }
 
+// WithPresSafeCanonicalizationCheck option rejects the presentation and the enclosed credentials having the terms
+// expanded to blank node identifiers with ErrUnsafeCanonicalization when their linked data proofs are checked
+// (see WithSafeCanonicalizationCheck).
+func WithPresSafeCanonicalizationCheck() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.blankNodeTermsCheck = true
+	}
+}
+
+// WithPresHTTPClient option defines HTTP client used for all the network requests made when parsing
+// the presentation and its credentials, i.e. loading of remote JSON-LD contexts
+// (unless WithPresJSONLDDocumentLoader is used).
+func WithPresHTTPClient(client *http.Client) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithPresAllowUnsecuredJWT option allows the presentation and the enclosed credentials defined as unsecured JWT
+// ("alg": "none"), e.g. for test fixtures. Without this option ErrUnsecuredJWT is returned for such
+// a presentation or credential unless the proof check is disabled.
+func WithPresAllowUnsecuredJWT() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.allowUnsecuredJWT = true
+	}
+}
+
+// WithPresVerifyMethodAuthorization option enables the check that the verification method of every linked data
+// proof of the presentation and of the enclosed credentials (whose proofs are checked, see WithPresVerifyAllEmbedded)
+// belongs to the holder of the presentation (the issuer of the credential) and is authorized for the proof purpose
+// in its DID document resolved by vdr (e.g. listed under "authentication" for the presentation proof).
+func WithPresVerifyMethodAuthorization(vdr vdrapi.Registry) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.vmAuthorizationVDR = vdr
+	}
+}
+
+// WithPresRequireHolder option enables check that the Verifiable Presentation has a holder.
+// For the presentation decoded from JWT, "iss" claim is required and it must match the holder
+// of "vp" claim (if defined).
+func WithPresRequireHolder() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.requireHolder = true
+	}
+}
+
+// WithPresVerificationResult defines the result which is filled with details of the presentation proofs
+// checked during decoding (proof types, public keys resolved to check them, challenges and domains).
+// The option can't be shared by concurrent ParsePresentation calls, each of them needs its own result.
+func WithPresVerificationResult(result *VerificationResult) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.verificationResult = result
+	}
+}
+
+// WithPresExpectedChallenge defines the challenge the linked data proofs of VP are expected to have.
+// Whether the challenge matches is reported in VerificationResult.
+func WithPresExpectedChallenge(challenge string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.challenge = challenge
+	}
+}
+
+// WithPresExpectedDomain defines the domain the linked data proofs of VP are expected to have.
+// Whether the domain matches is reported in VerificationResult.
+func WithPresExpectedDomain(domain string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.domain = domain
+	}
+}
+
+// WithPresExpectedAudience defines the identifier of the verifier the presentation in JWT form must be
+// intended for, i.e. JWT "aud" claim (a string or an array) must contain it. The presentation with other
+// or undefined audience is rejected, which prevents replay of the presentation to a different verifier.
+// The presentation which is not in JWT form must have a linked data proof with the domain equal to the audience
+// (use it with WithPresExpectedDomain to check the domain of every proof).
+func WithPresExpectedAudience(aud string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.audience = aud
+	}
+}
+
+// WithPresMaxNestingDepth defines max depth of presentations enclosed into the presentation
+// (see Presentation.NestedPresentations()). Default depth is 3; 0 forbids nested presentations.
+func WithPresMaxNestingDepth(depth int) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.maxNestingDepth = depth
+	}
+}
+
+// WithPresVerifyAllEmbedded defines how deep proofs of the credentials and presentations enclosed into
+// the presentation are checked. Depth 0 checks the proof of the presentation only, depth 1 checks the proofs
+// of the enclosed credentials (both JWT and linked data ones) and nested presentations too, depth 2 checks
+// the proofs of the credentials enclosed into the nested presentations etc.
+// If the option is not set, only the proofs of the enclosed credentials in JWT form
+// and the nested presentations are checked.
+func WithPresVerifyAllEmbedded(depth int) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.verifyAllEmbedded = true
+		opts.verifyEmbeddedDepth = depth
+	}
+}
+
+// WithPresBestEffortCredentialVerification option makes the decoding of the presentation tolerate the credentials
+// enclosed into it which can't be verified (e.g. their issuer keys can't be resolved). The result of the proof
+// check of every enclosed credential is reported in VerificationResult.Credentials, so the caller decides which
+// credentials to accept. A credential which failed the check is kept in the presentation in its original form.
+// The presentation proof is checked as usual. The option requires WithPresVerificationResult, otherwise
+// the decoding fails, so the failed credentials can't be accepted unnoticed. The option is not applied
+// to the credentials of the nested presentations.
+func WithPresBestEffortCredentialVerification() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.bestEffortCredentialVerification = true
+	}
+}
+
+// WithPresMaxDocumentSize option limits the size of the presentation (in bytes) to be parsed. The presentation
+// which exceeds the limit is rejected before it's decoded. For the presentation in JWT form, the limit is applied
+// to the decoded JWT payload too. The same limit is applied to every enclosed credential.
+func WithPresMaxDocumentSize(n int) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.maxDocumentSize = n
+	}
+}
+
+// WithPresMaxProofs option limits the number of embedded proofs (proof set or chain) of the presentation
+// to be checked. The same limit is applied to every enclosed credential. The default limit is 10,
+// a non-positive value disables the limit.
+func WithPresMaxProofs(n int) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.maxProofs = n
+	}
+}
+
+// WithPresKeepJWTCredentials option keeps the credentials enclosed into VP in JWT form as is, i.e. as
+// the original compact JWS (or unsecured JWT) strings instead of decoding them into JSON objects.
+// The credentials are still decoded to be validated (and their proofs to be checked), but the decoded
+// ones are dropped, so the presentation can be forwarded with the original bytes of the credentials.
+func WithPresKeepJWTCredentials() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.keepJWTCredentials = true
+	}
+}
+
 // ParsePresentation creates an instance of Verifiable Presentation by reading a JSON document from bytes.
 // It also applies miscellaneous options like custom decoders or settings of schema validation.
+// If the presentation in JWS form has linked data proof in "vp" claim, both JWS and linked data proof are checked.
 func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, error) {
 	vpOpts := getPresentationOpts(opts)
 
+	if err := vpOpts.checkBestEffortCredentials(); err != nil {
+		return nil, err
+	}
+
+	return parsePresentation(vpData, vpOpts)
+}
+
+// ParsePresentations parses several Verifiable Presentations submitted together (e.g. JSON-LD and JWT ones
+// returned in response to a single request). The options are prepared once, so all the presentations share
+// the JSON-LD document loader. If the loader is not defined (see WithPresJSONLDDocumentLoader), the caching
+// loader is created, so every JSON-LD context is loaded once. The results are returned in the same order as the data;
+// a failure to parse one presentation is reported in its error only.
+// VerificationResult (see WithPresVerificationResult) is not filled, use ParsePresentation to get it.
+// For the same reason, WithPresBestEffortCredentialVerification is not supported.
+func ParsePresentations(data [][]byte, opts ...PresentationOpt) ([]*Presentation, []error) {
+	vpOpts := applyPresentationOpts(opts)
+	vpOpts.verificationResult = nil
+
+	vps := make([]*Presentation, len(data))
+	errs := make([]error, len(data))
+
+	if err := vpOpts.checkBestEffortCredentials(); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+
+		return vps, errs
+	}
+
+	if vpOpts.jsonldDocumentLoader == nil {
+		vpOpts.jsonldDocumentLoader = jsonld.NewCachingDocumentLoader(jsonld.NewDefaultDocumentLoader(vpOpts.httpClient))
+	}
+
+	vpOpts.jsonldDocumentLoader = withInlineContexts(vpOpts.jsonldDocumentLoader, vpOpts.inlineContexts)
+
+	for i, vpData := range data {
+		// parsePresentation may change the options, so each presentation gets its own copy.
+		itemOpts := *vpOpts
+
+		vps[i], errs[i] = parsePresentation(vpData, &itemOpts)
+	}
+
+	return vps, errs
+}
+
+func parsePresentation(vpData []byte, vpOpts *presentationOpts) (*Presentation, error) {
+	if err := checkDocumentSize(len(vpData), vpOpts.maxDocumentSize); err != nil {
+		return nil, fmt.Errorf("decode presentation: %w", err)
+	}
+
+	if vpOpts.verifyHolderConfirmation && vpOpts.verificationResult == nil {
+		// the keys of the presentation proofs are taken from the verification result
+		vpOpts.verificationResult = &VerificationResult{}
+	}
+
 	vpDataDecoded, vpRaw, err := decodeRawPresentation(vpData, vpOpts)
 	if err != nil {
 		return nil, err
 	}
 
+	if err = checkDocumentSize(len(vpDataDecoded), vpOpts.maxDocumentSize); err != nil {
+		return nil, fmt.Errorf("decode presentation: %w", err)
+	}
+
 	err = validateVP(vpDataDecoded, vpOpts)
 	if err != nil {
 		return nil, err
@@ -411,10 +978,36 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, fmt.Errorf("verifiableCredential is required")
 	}
 
+	if vpOpts.requireHolder && p.Holder == "" {
+		return nil, errors.New("holder is required")
+	}
+
+	if err = checkTrustedHolder(p.Holder, vpOpts.trustedHolders); err != nil {
+		return nil, err
+	}
+
+	if vpOpts.verifyHolderConfirmation {
+		if err = checkHolderConfirmation(vpRaw.Credential, vpOpts.verificationResult.Proofs); err != nil {
+			return nil, err
+		}
+	}
+
 	return p, nil
 }
 
 func getPresentationOpts(opts []PresentationOpt) *presentationOpts {
+	vpOpts := applyPresentationOpts(opts)
+
+	if vpOpts.jsonldDocumentLoader == nil && vpOpts.httpClient != nil {
+		vpOpts.jsonldDocumentLoader = jsonld.NewDefaultDocumentLoader(vpOpts.httpClient)
+	}
+
+	vpOpts.jsonldDocumentLoader = withInlineContexts(vpOpts.jsonldDocumentLoader, vpOpts.inlineContexts)
+
+	return vpOpts
+}
+
+func applyPresentationOpts(opts []PresentationOpt) *presentationOpts {
 	vpOpts := defaultPresentationOpts()
 
 	for _, opt := range opts {
@@ -463,26 +1056,80 @@ func newPresentation(vpRaw *rawPresentation, vpOpts *presentationOpts) (*Present
 // 2) the same as 1) but as array - e.g. zero ore more JWS
 // 3) struct (should be map[string]interface{}) representing credential data model
 // 4) the same as 3) but as array - i.e. zero or more credentials structs.
+// Nested presentations (defined as JWT or struct) are decoded into *Presentation.
 func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface{}, error) {
 	// Accept the case when VP does not have any VCs.
 	if rawCred == nil {
 		return nil, nil
 	}
 
-	marshalSingleCredFn := func(cred interface{}) (interface{}, error) {
+	if opts.bestEffortCredentials() {
+		opts.verificationResult.Credentials = nil
+	}
+
+	marshalSingleCredFn := func(i int, cred interface{}) (interface{}, error) {
+		if isNestedPresentation(cred) {
+			return decodeNestedPresentation(cred, opts)
+		}
+
+		credOpts := mapOpts(opts)
+		credOpts.disabledProofCheck = opts.enclosedProofCheckDisabled()
+
 		// Check the case when VC is defined in string format (e.g. JWT).
 		// Decode credential and keep result of decoding.
 		if sCred, ok := cred.(string); ok {
 			bCred := []byte(sCred)
 
-			credDecoded, err := decodeRaw(bCred, mapOpts(opts))
+			credDecoded, err := decodeRaw(bCred, credOpts)
 			if err != nil {
-				return nil, fmt.Errorf("decode credential of presentation: %w", err)
+				err = fmt.Errorf("decode credential of presentation: %w", err)
+
+				if !opts.bestEffortCredentials() {
+					return nil, err
+				}
+
+				opts.addCredentialResult(i, !credOpts.disabledProofCheck, err)
+
+				return sCred, nil
+			}
+
+			if opts.strictCredentialValidation {
+				if err = validateEnclosedCredentialStrictly(credDecoded, opts); err != nil {
+					return nil, err
+				}
+			}
+
+			opts.addCredentialResult(i, !credOpts.disabledProofCheck, nil)
+
+			if opts.keepJWTCredentials {
+				return sCred, nil
 			}
 
 			return credDecoded, nil
 		}
 
+		if opts.strictCredentialValidation {
+			if err := validateEnclosedCredentialStrictly(cred, opts); err != nil {
+				return nil, err
+			}
+		}
+
+		proofChecked := opts.verifyAllEmbedded && !credOpts.disabledProofCheck
+
+		if proofChecked {
+			if err := checkEnclosedCredentialProof(cred, credOpts); err != nil {
+				if !opts.bestEffortCredentials() {
+					return nil, err
+				}
+
+				opts.addCredentialResult(i, proofChecked, err)
+
+				return cred, nil
+			}
+		}
+
+		opts.addCredentialResult(i, proofChecked, nil)
+
 		// return credential in a structure format as is
 		return cred, nil
 	}
@@ -498,7 +1145,7 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 		creds := make([]interface{}, len(cred))
 
 		for i := range cred {
-			c, err := marshalSingleCredFn(cred[i])
+			c, err := marshalSingleCredFn(i, cred[i])
 			if err != nil {
 				return nil, err
 			}
@@ -509,7 +1156,7 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 		return creds, nil
 	default:
 		// single credential
-		c, err := marshalSingleCredFn(cred)
+		c, err := marshalSingleCredFn(0, cred)
 		if err != nil {
 			return nil, err
 		}
@@ -518,11 +1165,155 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 	}
 }
 
+// isNestedPresentation checks if the item of verifiableCredential is a presentation, i.e. a struct
+// of VerifiablePresentation type or JWT with "vp" claim.
+func isNestedPresentation(cred interface{}) bool {
+	switch c := cred.(type) {
+	case map[string]interface{}:
+		types, err := decodeType(c["type"])
+		if err != nil {
+			return false
+		}
+
+		for _, t := range types {
+			if t == vpType {
+				return true
+			}
+		}
+
+		return false
+
+	case string:
+		if !jwt.IsJWS(c) && !jwt.IsJWTUnsecured(c) {
+			return false
+		}
+
+		payload, err := base64.RawURLEncoding.DecodeString(strings.Split(c, ".")[1])
+		if err != nil {
+			return false
+		}
+
+		var claims map[string]interface{}
+
+		if err = json.Unmarshal(payload, &claims); err != nil {
+			return false
+		}
+
+		_, ok := claims["vp"]
+
+		return ok
+
+	default:
+		return false
+	}
+}
+
+func decodeNestedPresentation(vp interface{}, opts *presentationOpts) (*Presentation, error) {
+	if opts.nestingDepth >= opts.maxNestingDepth {
+		return nil, fmt.Errorf("max nesting depth %d of presentations is exceeded", opts.maxNestingDepth)
+	}
+
+	vpBytes, ok := vp.(string)
+	if !ok {
+		b, err := json.Marshal(vp)
+		if err != nil {
+			return nil, fmt.Errorf("marshal nested presentation: %w", err)
+		}
+
+		vpBytes = string(b)
+	}
+
+	nestedOpts := *opts
+	nestedOpts.disabledProofCheck = opts.enclosedProofCheckDisabled()
+	nestedOpts.nestingDepth++
+	nestedOpts.verificationResult = nil
+	// the trusted holders are checked for the outer presentation only
+	nestedOpts.trustedHolders = nil
+	// the holder confirmation can't be checked without the proof of the nested presentation
+	nestedOpts.verifyHolderConfirmation = opts.verifyHolderConfirmation && !nestedOpts.disabledProofCheck
+
+	nestedVP, err := parsePresentation([]byte(vpBytes), &nestedOpts)
+	if err != nil {
+		return nil, fmt.Errorf("decode nested presentation: %w", err)
+	}
+
+	if ok {
+		nestedVP.jwt = vpBytes
+	}
+
+	return nestedVP, nil
+}
+
+// enclosedProofCheckDisabled reports whether proofs of the credentials and presentations enclosed
+// into the presentation being decoded must not be checked.
+func (opts *presentationOpts) enclosedProofCheckDisabled() bool {
+	if opts.disabledProofCheck {
+		return true
+	}
+
+	return opts.verifyAllEmbedded && opts.nestingDepth >= opts.verifyEmbeddedDepth
+}
+
+func checkEnclosedCredentialProof(cred interface{}, credOpts *credentialOpts) error {
+	credBytes, err := json.Marshal(cred)
+	if err != nil {
+		return fmt.Errorf("marshal credential of presentation: %w", err)
+	}
+
+	if _, err = checkEmbeddedProof(credBytes, getEmbeddedProofCheckOpts(credOpts)); err != nil {
+		return fmt.Errorf("check proof of credential of presentation: %w", err)
+	}
+
+	return nil
+}
+
+func validateEnclosedCredentialStrictly(cred interface{}, opts *presentationOpts) error {
+	credBytes, ok := cred.([]byte)
+	if !ok {
+		b, err := json.Marshal(cred)
+		if err != nil {
+			return fmt.Errorf("marshal credential of presentation: %w", err)
+		}
+
+		credBytes = b
+	}
+
+	if err := compactJSONLD(string(credBytes), &opts.jsonldCredentialOpts, true); err != nil {
+		return fmt.Errorf("strict validation of credential of presentation: %w", err)
+	}
+
+	return nil
+}
+
+// presentationSuites returns the suites used to check embedded linked data proof of VP.
+func (opts *presentationOpts) presentationSuites() []verifier.SignatureSuite {
+	if len(opts.vpLDPSuites) > 0 {
+		return opts.vpLDPSuites
+	}
+
+	return opts.ldpSuites
+}
+
+// credentialSuites returns the suites used to check embedded linked data proofs of the enclosed VCs.
+func (opts *presentationOpts) credentialSuites() []verifier.SignatureSuite {
+	if len(opts.vcLDPSuites) > 0 {
+		return opts.vcLDPSuites
+	}
+
+	return opts.ldpSuites
+}
+
 func mapOpts(vpOpts *presentationOpts) *credentialOpts {
 	return &credentialOpts{
-		publicKeyFetcher:   vpOpts.publicKeyFetcher,
-		disabledProofCheck: vpOpts.disabledProofCheck,
-		ldpSuites:          vpOpts.ldpSuites,
+		publicKeyFetcher:     vpOpts.publicKeyFetcher,
+		disabledProofCheck:   vpOpts.disabledProofCheck,
+		ldpSuites:            vpOpts.credentialSuites(),
+		maxDocumentSize:      vpOpts.maxDocumentSize,
+		maxProofs:            vpOpts.maxProofs,
+		httpClient:           vpOpts.httpClient,
+		allowUnsecuredJWT:    vpOpts.allowUnsecuredJWT,
+		vmAuthorizationVDR:   vpOpts.vmAuthorizationVDR,
+		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
 	}
 }
 
@@ -555,32 +1346,57 @@ func validateVPJSONSchema(data []byte) error {
 	return nil
 }
 
-//nolint:gocyclo
+//nolint:gocyclo,funlen
 func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *rawPresentation, error) {
 	vpStr := string(vpData)
 
+	publicKeyFetcher, keyRecorder := vpOpts.newPublicKeyFetcher()
+
+	embeddedProofCheckOpts := &embeddedProofCheckOpts{
+		publicKeyFetcher:     publicKeyFetcher,
+		disabledProofCheck:   vpOpts.disabledProofCheck,
+		ldpSuites:            vpOpts.presentationSuites(),
+		vmAuthorizationVDR:   vpOpts.vmAuthorizationVDR,
+		controllerProofs:     vpOpts.trustedHolders != nil,
+		maxProofs:            vpOpts.maxProofs,
+		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
+	}
+
 	if jwt.IsJWS(vpStr) {
 		if vpOpts.publicKeyFetcher == nil {
 			return nil, nil, errors.New("public key fetcher is not defined")
 		}
 
-		vcDataFromJwt, rawCred, err := decodeVPFromJWS(vpStr, !vpOpts.disabledProofCheck, vpOpts.publicKeyFetcher)
+		vcDataFromJwt, rawCred, err := decodeVPFromJWS(vpStr, !vpOpts.disabledProofCheck, publicKeyFetcher,
+			vpOpts.requireHolder, vpOpts.audience)
 		if err != nil {
 			return nil, nil, fmt.Errorf("decoding of Verifiable Presentation from JWS: %w", err)
 		}
 
-		return vcDataFromJwt, rawCred, nil
-	}
+		if err = vpOpts.fillJWSVerificationResult(vpStr, keyRecorder); err != nil {
+			return nil, nil, err
+		}
 
-	embeddedProofCheckOpts := &embeddedProofCheckOpts{
-		publicKeyFetcher:     vpOpts.publicKeyFetcher,
-		disabledProofCheck:   vpOpts.disabledProofCheck,
-		ldpSuites:            vpOpts.ldpSuites,
-		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
+		// "vp" claim can have linked data proof as well, it's checked in addition to JWS.
+		if rawCred.Proof != nil {
+			if _, err = checkEmbeddedProof(vcDataFromJwt, embeddedProofCheckOpts); err != nil {
+				return nil, nil, err
+			}
+
+			if err = vpOpts.addLDPVerificationResult(vcDataFromJwt, keyRecorder); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return vcDataFromJwt, rawCred, nil
 	}
 
 	if jwt.IsJWTUnsecured(vpStr) {
-		rawBytes, rawPres, err := decodeVPFromUnsecuredJWT(vpStr)
+		if !vpOpts.allowUnsecuredJWT && !vpOpts.disabledProofCheck {
+			return nil, nil, fmt.Errorf("decoding of Verifiable Presentation from unsecured JWT: %w", ErrUnsecuredJWT)
+		}
+
+		rawBytes, rawPres, err := decodeVPFromUnsecuredJWT(vpStr, vpOpts.requireHolder, vpOpts.audience)
 		if err != nil {
 			return nil, nil, fmt.Errorf("decoding of Verifiable Presentation from unsecured JWT: %w", err)
 		}
@@ -589,6 +1405,10 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 			return nil, nil, err
 		}
 
+		if err := vpOpts.fillLDPVerificationResult(rawBytes, keyRecorder); err != nil {
+			return nil, nil, err
+		}
+
 		return rawBytes, rawPres, nil
 	}
 
@@ -607,9 +1427,37 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 		return nil, nil, errors.New("embedded proof is missing")
 	}
 
+	if vpOpts.audience != "" {
+		if err = checkProofDomain(vpRaw.Proof, vpOpts.audience); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = vpOpts.fillLDPVerificationResult(vpBytes, keyRecorder)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	return vpBytes, vpRaw, err
 }
 
+// checkProofDomain checks that the presentation is intended for the audience, i.e. one of the linked data proofs
+// has the domain equal to it.
+func checkProofDomain(proofBytes json.RawMessage, audience string) error {
+	proofs, err := parseProof(proofBytes)
+	if err != nil {
+		return fmt.Errorf("presentation is not intended for %s: %w", audience, err)
+	}
+
+	for _, p := range proofs {
+		if domain, ok := p["domain"].(string); ok && domain == audience {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("presentation is not intended for %s: linked data proof with the domain is not found", audience)
+}
+
 func decodeVPFromJSON(vpData []byte) ([]byte, *rawPresentation, error) {
 	// unmarshal VP from JSON
 	raw := new(rawPresentation)
@@ -623,5 +1471,8 @@ func decodeVPFromJSON(vpData []byte) ([]byte, *rawPresentation, error) {
 }
 
 func defaultPresentationOpts() *presentationOpts {
-	return &presentationOpts{}
+	return &presentationOpts{
+		maxNestingDepth: defaultMaxNestingDepth,
+		maxProofs:       defaultMaxProofs,
+	}
 }
@@ -0,0 +1,63 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// presentationChallengeSize is a number of random bytes of the challenge generated by GeneratePresentationChallenge.
+const presentationChallengeSize = 32
+
+// ErrChallengeMismatch is returned by VerifyPresentationChallenge if no proof of the presentation has
+// the expected challenge.
+var ErrChallengeMismatch = errors.New("presentation challenge does not match")
+
+// GeneratePresentationChallenge generates a cryptographically random challenge (256 bits, base64url encoded)
+// to be sent by the verifier to the holder and put into the linked data proof of the presentation
+// (see LinkedDataProofContext.Challenge).
+func GeneratePresentationChallenge() (string, error) {
+	challenge := make([]byte, presentationChallengeSize)
+
+	if _, err := rand.Read(challenge); err != nil {
+		return "", fmt.Errorf("generate presentation challenge: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(challenge), nil
+}
+
+// VerifyPresentationChallenge checks that the presentation has a linked data proof with the expected challenge.
+// The challenges are compared in constant time. ErrChallengeMismatch is returned if there is no such proof.
+// The proofs themselves are not checked, so the presentation must be parsed by ParsePresentation with
+// the proof check enabled.
+func VerifyPresentationChallenge(vp *Presentation, expectedChallenge string) error {
+	if expectedChallenge == "" {
+		return errors.New("expected presentation challenge is not defined")
+	}
+
+	matched := false
+
+	for _, p := range vp.Proofs {
+		challenge, ok := p["challenge"].(string)
+		if !ok {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(challenge), []byte(expectedChallenge)) == 1 {
+			matched = true
+		}
+	}
+
+	if !matched {
+		return ErrChallengeMismatch
+	}
+
+	return nil
+}
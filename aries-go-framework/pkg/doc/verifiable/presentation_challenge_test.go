@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestGeneratePresentationChallenge(t *testing.T) {
+	challenge, err := GeneratePresentationChallenge()
+	require.NoError(t, err)
+
+	challengeBytes, err := base64.RawURLEncoding.DecodeString(challenge)
+	require.NoError(t, err)
+	require.Len(t, challengeBytes, presentationChallengeSize)
+
+	challenge2, err := GeneratePresentationChallenge()
+	require.NoError(t, err)
+	require.NotEqual(t, challenge, challenge2)
+}
+
+func TestVerifyPresentationChallenge(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	challenge, err := GeneratePresentationChallenge()
+	require.NoError(t, err)
+
+	vp, err := NewPresentation()
+	require.NoError(t, err)
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:holder#key-1",
+		Challenge:               challenge,
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	vp, err = newTestPresentation(t, vpBytes,
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.NoError(t, err)
+
+	t.Run("challenge matches", func(t *testing.T) {
+		require.NoError(t, VerifyPresentationChallenge(vp, challenge))
+	})
+
+	t.Run("challenge does not match", func(t *testing.T) {
+		otherChallenge, err := GeneratePresentationChallenge()
+		require.NoError(t, err)
+
+		err = VerifyPresentationChallenge(vp, otherChallenge)
+		require.True(t, errors.Is(err, ErrChallengeMismatch))
+	})
+
+	t.Run("presentation without proof", func(t *testing.T) {
+		vpWithoutProof, err := NewPresentation()
+		require.NoError(t, err)
+
+		err = VerifyPresentationChallenge(vpWithoutProof, challenge)
+		require.True(t, errors.Is(err, ErrChallengeMismatch))
+	})
+
+	t.Run("expected challenge is not defined", func(t *testing.T) {
+		err := VerifyPresentationChallenge(vp, "")
+		require.EqualError(t, err, "expected presentation challenge is not defined")
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// WithPresVerifyHolderConfirmation option enables the proof-of-possession check of the holder-bound credentials.
+// Every credential enclosed into the presentation in JWT form with "cnf" claim (see HolderConfirmation)
+// must be presented by the holder of the confirmation key, i.e. the presentation must have a proof made
+// by that key: the ID of the key must match "kid" or the public key must match "jwk" of the claim.
+// The credentials without "cnf" claim are not checked. As the proof of the presentation is needed,
+// the credentials with "cnf" claim are rejected if the proof check is disabled.
+func WithPresVerifyHolderConfirmation() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.verifyHolderConfirmation = true
+	}
+}
+
+// checkHolderConfirmation checks that the credentials in JWT form with "cnf" claim are confirmed by any of the
+// verified proofs of the presentation.
+func checkHolderConfirmation(rawCred interface{}, proofs []VerifiedProof) error {
+	var creds []interface{}
+
+	switch c := rawCred.(type) {
+	case nil:
+	case []interface{}:
+		creds = c
+	default:
+		creds = []interface{}{c}
+	}
+
+	for i := range creds {
+		vcJWT, ok := creds[i].(string)
+		if !ok || isNestedPresentation(vcJWT) {
+			continue
+		}
+
+		cnf, err := peekCredConfirmation(vcJWT)
+		if err != nil {
+			return fmt.Errorf("check holder confirmation of credential %d: %w", i, err)
+		}
+
+		if cnf == nil {
+			continue
+		}
+
+		if !cnf.confirmedByAny(proofs) {
+			return fmt.Errorf("check holder confirmation of credential %d: presentation is not signed "+
+				"by the confirmation key", i)
+		}
+	}
+
+	return nil
+}
+
+func (c *HolderConfirmation) confirmedByAny(proofs []VerifiedProof) bool {
+	for i := range proofs {
+		if c.confirmedBy(&proofs[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *HolderConfirmation) confirmedBy(p *VerifiedProof) bool {
+	if c.KID != "" && c.KID == p.VerificationMethod {
+		return true
+	}
+
+	if c.JWK == nil || p.PublicKey == nil {
+		return false
+	}
+
+	cnfKey, err := c.JWK.PublicKeyBytes()
+	if err != nil {
+		return false
+	}
+
+	proofKey := p.PublicKey.Value
+	if len(proofKey) == 0 && p.PublicKey.JWK != nil {
+		proofKey, err = p.PublicKey.JWK.PublicKeyBytes()
+		if err != nil {
+			return false
+		}
+	}
+
+	return len(proofKey) > 0 && bytes.Equal(cnfKey, proofKey)
+}
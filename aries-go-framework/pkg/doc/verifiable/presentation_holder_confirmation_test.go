@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestWithPresVerifyHolderConfirmation(t *testing.T) {
+	const holderKeyID = "did:example:holder#key-1"
+
+	holderSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	otherSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holderJWK, err := jwksupport.JWKFromKey(ed25519.PublicKey(holderSigner.PublicKeyBytes()))
+	require.NoError(t, err)
+
+	boundVCJWT := func(t *testing.T, cnf *HolderConfirmation) string {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Confirmation = cnf
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		return vcJWT
+	}
+
+	presentJWS := func(t *testing.T, vcJWT string, signer Signer) []byte {
+		t.Helper()
+
+		vp, err := NewPresentation(WithJWTCredentials(vcJWT))
+		require.NoError(t, err)
+
+		vp.Holder = "did:example:holder"
+
+		jwtClaims, err := vp.JWTClaims(nil, false)
+		require.NoError(t, err)
+
+		vpJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, holderKeyID)
+		require.NoError(t, err)
+
+		return []byte(vpJWS)
+	}
+
+	parse := func(t *testing.T, vpBytes []byte, signer signature.Signer, opts ...PresentationOpt) error {
+		t.Helper()
+
+		_, err := newTestPresentation(t, vpBytes, append([]PresentationOpt{
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithPresAllowUnsecuredJWT(),
+		}, opts...)...)
+
+		return err
+	}
+
+	t.Run("cnf claim is parsed into credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(boundVCJWT(t, &HolderConfirmation{JWK: holderJWK})),
+			WithAllowUnsecuredJWT())
+		require.NoError(t, err)
+		require.NotNil(t, vc.Confirmation)
+		require.Equal(t, ed25519.PublicKey(holderSigner.PublicKeyBytes()), vc.Confirmation.JWK.Key)
+
+		vc, err = parseTestCredential(t, []byte(boundVCJWT(t, nil)), WithAllowUnsecuredJWT())
+		require.NoError(t, err)
+		require.Nil(t, vc.Confirmation)
+	})
+
+	t.Run("presentation in JWS form signed by the confirmation key", func(t *testing.T) {
+		for _, cnf := range []*HolderConfirmation{{JWK: holderJWK}, {KID: holderKeyID}} {
+			vpJWS := presentJWS(t, boundVCJWT(t, cnf), holderSigner)
+
+			require.NoError(t, parse(t, vpJWS, holderSigner, WithPresVerifyHolderConfirmation()))
+		}
+	})
+
+	t.Run("presentation in JWS form signed by other key", func(t *testing.T) {
+		vpJWS := presentJWS(t, boundVCJWT(t, &HolderConfirmation{JWK: holderJWK}), otherSigner)
+
+		err := parse(t, vpJWS, otherSigner, WithPresVerifyHolderConfirmation())
+		require.EqualError(t, err,
+			"check holder confirmation of credential 0: presentation is not signed by the confirmation key")
+
+		// not checked without the option
+		require.NoError(t, parse(t, vpJWS, otherSigner))
+
+		vpJWS = presentJWS(t, boundVCJWT(t, &HolderConfirmation{KID: "did:example:holder#key-2"}), holderSigner)
+
+		err = parse(t, vpJWS, holderSigner, WithPresVerifyHolderConfirmation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation is not signed by the confirmation key")
+	})
+
+	t.Run("presentation with linked data proof", func(t *testing.T) {
+		vp, err := NewPresentation(WithJWTCredentials(boundVCJWT(t, &HolderConfirmation{JWK: holderJWK})))
+		require.NoError(t, err)
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(holderSigner)),
+			VerificationMethod:      holderKeyID,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		require.NoError(t, parse(t, vpBytes, holderSigner, WithPresVerifyHolderConfirmation()))
+
+		err = parse(t, vpBytes, otherSigner, WithPresVerifyHolderConfirmation())
+		require.Error(t, err)
+	})
+
+	t.Run("credentials without cnf claim are not checked", func(t *testing.T) {
+		vpJWS := presentJWS(t, boundVCJWT(t, nil), otherSigner)
+
+		require.NoError(t, parse(t, vpJWS, otherSigner, WithPresVerifyHolderConfirmation()))
+	})
+
+	t.Run("holder confirmation requires proof check", func(t *testing.T) {
+		vpJWS := presentJWS(t, boundVCJWT(t, &HolderConfirmation{JWK: holderJWK}), holderSigner)
+
+		err := parse(t, vpJWS, holderSigner, WithPresVerifyHolderConfirmation(), WithPresDisabledProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation is not signed by the confirmation key")
+	})
+}
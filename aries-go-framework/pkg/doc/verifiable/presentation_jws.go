@@ -6,8 +6,18 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
+// The claims (including the "vp" object) are serialized using JSON Canonicalization Scheme (JCS),
+// so the same claims always yield the same signing input.
 func (jpc *JWTPresClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jpc, signatureAlg, signer, keyID)
+	return marshalJWS(jpc, signatureAlg, signer, keyID, nil)
+}
+
+// MarshalJWSWithHeaders serializes JWT presentation claims into signed form (JWS) with additional JOSE headers,
+// e.g. {"typ": "vp+jwt", "cty": "vp"}. The headers override the default ones ("typ" is "JWT" by default),
+// "alg" and "kid" headers are not allowed as they are defined by signatureAlg and keyID.
+func (jpc *JWTPresClaims) MarshalJWSWithHeaders(signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	headers map[string]interface{}) (string, error) {
+	return marshalJWS(jpc, signatureAlg, signer, keyID, headers)
 }
 
 func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetcher) (*JWTPresClaims, error) {
@@ -21,8 +31,9 @@ func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetc
 	return &claims, err
 }
 
-func decodeVPFromJWS(vpJWT string, checkProof bool, fetcher PublicKeyFetcher) ([]byte, *rawPresentation, error) {
+func decodeVPFromJWS(vpJWT string, checkProof bool, fetcher PublicKeyFetcher,
+	requireHolder bool, expectedAudience string) ([]byte, *rawPresentation, error) {
 	return decodePresJWT(vpJWT, func(vpJWT string) (*JWTPresClaims, error) {
 		return unmarshalPresJWSClaims(vpJWT, checkProof, fetcher)
-	})
+	}, requireHolder, expectedAudience)
 }
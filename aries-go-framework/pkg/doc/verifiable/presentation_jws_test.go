@@ -27,7 +27,7 @@ func TestJWTPresClaims_MarshalJWS(t *testing.T) {
 
 	jws := createCredJWS(t, vp, signer)
 
-	_, rawVC, err := decodeVPFromJWS(jws, true, holderPublicKeyFetcher(signer.PublicKeyBytes()))
+	_, rawVC, err := decodeVPFromJWS(jws, true, holderPublicKeyFetcher(signer.PublicKeyBytes()), false, "")
 
 	require.NoError(t, err)
 	require.Equal(t, vp.stringJSON(t), rawVC.stringJSON(t))
@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
@@ -19,6 +20,36 @@ type JWTPresClaims struct {
 	Presentation *rawPresentation `json:"vp,omitempty"`
 }
 
+// checkHolder checks that "iss" claim is defined and matches the holder of "vp" claim (if defined).
+func (jpc *JWTPresClaims) checkHolder() error {
+	if jpc.Claims == nil || jpc.Issuer == "" {
+		return errors.New("holder is required: JWT iss claim is not defined")
+	}
+
+	if jpc.Presentation == nil {
+		return errors.New("holder is required: JWT vp claim is not defined")
+	}
+
+	if jpc.Presentation.Holder != "" && jpc.Presentation.Holder != jpc.Issuer {
+		return fmt.Errorf("holder %s of presentation differs from JWT iss %s", jpc.Presentation.Holder, jpc.Issuer)
+	}
+
+	return nil
+}
+
+// checkAudience checks that "aud" claim (a string or an array) contains the expected audience.
+func (jpc *JWTPresClaims) checkAudience(expectedAudience string) error {
+	if jpc.Claims == nil || len(jpc.Audience) == 0 {
+		return fmt.Errorf("presentation is not intended for %s: JWT aud claim is not defined", expectedAudience)
+	}
+
+	if !jpc.Audience.Contains(expectedAudience) {
+		return fmt.Errorf("presentation is not intended for %s: JWT aud claim %v", expectedAudience, jpc.Audience)
+	}
+
+	return nil
+}
+
 func (jpc *JWTPresClaims) refineFromJWTClaims() {
 	raw := jpc.Presentation
 
@@ -73,12 +104,31 @@ type JWTPresClaimsUnmarshaller func(vpJWT string) (*JWTPresClaims, error)
 
 // decodePresJWT parses JWT from the specified bytes array in compact format using the unmarshaller.
 // It returns decoded Verifiable Presentation refined by JWT Claims in raw byte array and rawPresentation form.
-func decodePresJWT(vpJWT string, unmarshaller JWTPresClaimsUnmarshaller) ([]byte, *rawPresentation, error) {
+// If requireHolder is set, JWT "iss" claim is checked against the holder of the presentation.
+// If expectedAudience is defined, JWT "aud" claim must contain it.
+func decodePresJWT(vpJWT string, unmarshaller JWTPresClaimsUnmarshaller,
+	requireHolder bool, expectedAudience string) ([]byte, *rawPresentation, error) {
 	presClaims, err := unmarshaller(vpJWT)
 	if err != nil {
 		return nil, nil, fmt.Errorf("decode Verifiable Presentation JWT claims: %w", err)
 	}
 
+	if presClaims.Presentation == nil {
+		return nil, nil, errors.New("JWT vp claim is not defined")
+	}
+
+	if requireHolder {
+		if err = presClaims.checkHolder(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if expectedAudience != "" {
+		if err = presClaims.checkAudience(expectedAudience); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Apply VC-related claims from JWT.
 	presClaims.refineFromJWTClaims()
 
@@ -91,3 +141,38 @@ func decodePresJWT(vpJWT string, unmarshaller JWTPresClaimsUnmarshaller) ([]byte
 
 	return rawBytes, vpRaw, nil
 }
+
+// PeekPresentationHolder returns the holder of the Verifiable Presentation defined as JWT (signed or unsecured):
+// JWT "iss" claim or, if it is not defined, the holder of "vp" claim. Only the JWT payload is decoded, so it is
+// much cheaper than ParsePresentation, e.g. to route the presentation before its verification.
+//
+// IMPORTANT: neither the JWT signature nor the presentation is verified, so the returned holder is not trusted
+// and must not be used for trust decisions.
+func PeekPresentationHolder(vpJWS []byte) (string, error) {
+	vpStr := string(vpJWS)
+
+	if !jwt.IsJWS(vpStr) && !jwt.IsJWTUnsecured(vpStr) {
+		return "", errors.New("peek presentation holder: presentation is not a JWT")
+	}
+
+	var claims struct {
+		Issuer       string `json:"iss,omitempty"`
+		Presentation struct {
+			Holder string `json:"holder,omitempty"`
+		} `json:"vp,omitempty"`
+	}
+
+	if err := unmarshalJWS(vpStr, false, nil, &claims); err != nil {
+		return "", fmt.Errorf("peek presentation holder: %w", err)
+	}
+
+	if claims.Issuer != "" {
+		return claims.Issuer, nil
+	}
+
+	if claims.Presentation.Holder != "" {
+		return claims.Presentation.Holder, nil
+	}
+
+	return "", errors.New("peek presentation holder: neither JWT iss claim nor holder of presentation is defined")
+}
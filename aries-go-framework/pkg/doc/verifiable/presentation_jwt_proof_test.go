@@ -14,6 +14,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -121,7 +124,8 @@ func TestParsePresentationFromUnsecuredJWT(t *testing.T) {
 	vpBytes := []byte(validPresentation)
 
 	t.Run("Decoding presentation from unsecured JWT", func(t *testing.T) {
-		vpFromJWT, err := newTestPresentation(t, createPresUnsecuredJWT(t, vpBytes, false))
+		vpFromJWT, err := newTestPresentation(t, createPresUnsecuredJWT(t, vpBytes, false),
+			WithPresAllowUnsecuredJWT())
 
 		require.NoError(t, err)
 
@@ -132,7 +136,8 @@ func TestParsePresentationFromUnsecuredJWT(t *testing.T) {
 	})
 
 	t.Run("Decoding presentation from unsecured JWT with minimized fields of \"vp\" claim", func(t *testing.T) {
-		vpFromJWT, err := newTestPresentation(t, createPresUnsecuredJWT(t, vpBytes, true))
+		vpFromJWT, err := newTestPresentation(t, createPresUnsecuredJWT(t, vpBytes, true),
+			WithPresAllowUnsecuredJWT())
 
 		require.NoError(t, err)
 
@@ -141,6 +146,15 @@ func TestParsePresentationFromUnsecuredJWT(t *testing.T) {
 
 		require.Equal(t, vp, vpFromJWT)
 	})
+
+	t.Run("Unsecured JWT is not allowed", func(t *testing.T) {
+		_, err := newTestPresentation(t, createPresUnsecuredJWT(t, vpBytes, false))
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrUnsecuredJWT))
+
+		_, err = newTestPresentation(t, createPresUnsecuredJWT(t, vpBytes, false), WithPresDisabledProofCheck())
+		require.NoError(t, err)
+	})
 }
 
 func TestParsePresentationWithVCJWT(t *testing.T) {
@@ -345,3 +359,105 @@ func createPresUnsecuredJWT(t *testing.T, cred []byte, minimize bool) []byte {
 
 	return []byte(vpJWT)
 }
+
+func TestParsePresentationFromJWS_WithLinkedDataProof(t *testing.T) {
+	r := require.New(t)
+
+	ldpSigner, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	jwsSigner, err := newCryptoSigner(kms.RSARS256Type)
+	r.NoError(err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(ldpSigner),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	keyFetcher := func(_, keyID string) (*verifier.PublicKey, error) {
+		switch keyID {
+		case "#key1", "did:example:123456#key1":
+			return &verifier.PublicKey{Type: kms.ED25519, Value: ldpSigner.PublicKeyBytes()}, nil
+		case "holder-jws-key":
+			return &verifier.PublicKey{Type: kms.RSARS256, Value: jwsSigner.PublicKeyBytes()}, nil
+		default:
+			return nil, errors.New("unexpected key")
+		}
+	}
+
+	newDoubleProofVP := func(t *testing.T) *Presentation {
+		t.Helper()
+
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ss,
+			VerificationMethod:      "did:example:123456#key1",
+			Challenge:               "challenge",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		return vp
+	}
+
+	for _, minimizeVP := range []bool{false, true} {
+		vp := newDoubleProofVP(t)
+
+		jwtClaims, err := vp.JWTClaims([]string{"did:example:verifier"}, minimizeVP)
+		r.NoError(err)
+		r.NotEmpty(jwtClaims.Presentation.Proof)
+
+		vpJWS, err := jwtClaims.MarshalJWS(RS256, jwsSigner, "holder-jws-key")
+		r.NoError(err)
+
+		result := &VerificationResult{}
+
+		vpDecoded, err := newTestPresentation(t, []byte(vpJWS),
+			WithPresEmbeddedSignatureSuites(ss),
+			WithPresPublicKeyFetcher(keyFetcher),
+			WithPresVerificationResult(result))
+		r.NoError(err)
+		r.Equal(vp.Proofs, vpDecoded.Proofs)
+
+		r.Len(result.Proofs, 2)
+		r.Equal("JWS", result.Proofs[0].Type)
+		r.Equal("Ed25519Signature2018", result.Proofs[1].Type)
+		r.Equal("did:example:123456#key1", result.Proofs[1].VerificationMethod)
+		r.Equal("challenge", result.Proofs[1].Challenge)
+	}
+
+	t.Run("linked data proof is invalid", func(t *testing.T) {
+		vp := newDoubleProofVP(t)
+		vp.Holder = "did:example:other"
+
+		jwtClaims, err := vp.JWTClaims(nil, false)
+		r.NoError(err)
+
+		vpJWS, err := jwtClaims.MarshalJWS(RS256, jwsSigner, "holder-jws-key")
+		r.NoError(err)
+
+		_, err = newTestPresentation(t, []byte(vpJWS),
+			WithPresEmbeddedSignatureSuites(ss),
+			WithPresPublicKeyFetcher(keyFetcher))
+		r.Error(err)
+		r.Contains(err.Error(), "check embedded proof")
+	})
+
+	t.Run("JWS is invalid", func(t *testing.T) {
+		jwtClaims, err := newDoubleProofVP(t).JWTClaims(nil, false)
+		r.NoError(err)
+
+		otherSigner, err := newCryptoSigner(kms.RSARS256Type)
+		r.NoError(err)
+
+		vpJWS, err := jwtClaims.MarshalJWS(RS256, otherSigner, "holder-jws-key")
+		r.NoError(err)
+
+		_, err = newTestPresentation(t, []byte(vpJWS),
+			WithPresEmbeddedSignatureSuites(ss),
+			WithPresPublicKeyFetcher(keyFetcher))
+		r.Error(err)
+		r.Contains(err.Error(), "decoding of Verifiable Presentation from JWS")
+	})
+}
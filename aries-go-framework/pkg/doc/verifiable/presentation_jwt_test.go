@@ -7,9 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
 func TestNewJWTPresClaims(t *testing.T) {
@@ -56,3 +62,193 @@ func TestNewJWTPresClaims(t *testing.T) {
 		require.Equal(t, vp.Holder, claims.Presentation.Holder)
 	})
 }
+
+func TestPeekPresentationHolder(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	require.NoError(t, err)
+
+	t.Run("holder of signed JWT", func(t *testing.T) {
+		for _, minimize := range []bool{true, false} {
+			jwtClaims, err := vp.JWTClaims([]string{}, minimize)
+			require.NoError(t, err)
+
+			vpJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vp.Holder+"#keys-1")
+			require.NoError(t, err)
+
+			holder, err := PeekPresentationHolder([]byte(vpJWS))
+			require.NoError(t, err)
+			require.Equal(t, vp.Holder, holder)
+		}
+	})
+
+	t.Run("holder of unsecured JWT", func(t *testing.T) {
+		holder, err := PeekPresentationHolder(createPresUnsecuredJWT(t, []byte(validPresentation), true))
+		require.NoError(t, err)
+		require.Equal(t, vp.Holder, holder)
+	})
+
+	t.Run("holder of vp claim is used if iss is not defined", func(t *testing.T) {
+		jwtClaims, err := vp.JWTClaims([]string{}, false)
+		require.NoError(t, err)
+
+		jwtClaims.Issuer = ""
+
+		vpJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		holder, err := PeekPresentationHolder([]byte(vpJWT))
+		require.NoError(t, err)
+		require.Equal(t, vp.Holder, holder)
+	})
+
+	t.Run("signature is not verified", func(t *testing.T) {
+		jwtClaims, err := vp.JWTClaims([]string{}, true)
+		require.NoError(t, err)
+
+		vpJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vp.Holder+"#keys-1")
+		require.NoError(t, err)
+
+		parts := strings.Split(vpJWS, ".")
+		parts[2] = "aW52YWxpZCBzaWduYXR1cmU"
+
+		holder, err := PeekPresentationHolder([]byte(strings.Join(parts, ".")))
+		require.NoError(t, err)
+		require.Equal(t, vp.Holder, holder)
+	})
+
+	t.Run("holder is not defined", func(t *testing.T) {
+		jwtClaims, err := vp.JWTClaims([]string{}, true)
+		require.NoError(t, err)
+
+		jwtClaims.Issuer = ""
+
+		vpJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		_, err = PeekPresentationHolder([]byte(vpJWT))
+		require.EqualError(t, err,
+			"peek presentation holder: neither JWT iss claim nor holder of presentation is defined")
+	})
+
+	t.Run("not a JWT", func(t *testing.T) {
+		_, err := PeekPresentationHolder([]byte(validPresentation))
+		require.EqualError(t, err, "peek presentation holder: presentation is not a JWT")
+	})
+}
+
+func TestWithPresExpectedAudience(t *testing.T) {
+	const verifierID = "did:example:verifier"
+
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	require.NoError(t, err)
+
+	newVPJWT := func(t *testing.T, audience []string) []byte {
+		t.Helper()
+
+		jwtClaims, err := vp.JWTClaims(audience, false)
+		require.NoError(t, err)
+
+		vpJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		return []byte(vpJWT)
+	}
+
+	parse := func(t *testing.T, vpJWT []byte, opts ...PresentationOpt) error {
+		t.Helper()
+
+		_, err := newTestPresentation(t, vpJWT, append([]PresentationOpt{WithPresAllowUnsecuredJWT()}, opts...)...)
+
+		return err
+	}
+
+	t.Run("audience matches", func(t *testing.T) {
+		// single audience is serialized as a string
+		require.NoError(t, parse(t, newVPJWT(t, []string{verifierID}), WithPresExpectedAudience(verifierID)))
+
+		require.NoError(t, parse(t, newVPJWT(t, []string{"did:example:other", verifierID}),
+			WithPresExpectedAudience(verifierID)))
+	})
+
+	t.Run("presentation is intended for other verifier", func(t *testing.T) {
+		vpJWT := newVPJWT(t, []string{"did:example:other"})
+
+		err := parse(t, vpJWT, WithPresExpectedAudience(verifierID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation is not intended for did:example:verifier: JWT aud claim "+
+			"[did:example:other]")
+
+		// audience is not checked without the option
+		require.NoError(t, parse(t, vpJWT))
+	})
+
+	t.Run("audience is not defined", func(t *testing.T) {
+		err := parse(t, newVPJWT(t, nil), WithPresExpectedAudience(verifierID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation is not intended for did:example:verifier: JWT aud claim "+
+			"is not defined")
+	})
+
+	t.Run("presentation in JWS form", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		jwtClaims, err := vp.JWTClaims([]string{verifierID}, false)
+		require.NoError(t, err)
+
+		vpJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vp.Holder+"#keys-1")
+		require.NoError(t, err)
+
+		keyOpt := WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+		require.NoError(t, parse(t, []byte(vpJWS), keyOpt, WithPresExpectedAudience(verifierID)))
+
+		err = parse(t, []byte(vpJWS), keyOpt, WithPresExpectedAudience("did:example:other"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation is not intended for did:example:other")
+	})
+
+	t.Run("presentation with linked data proof", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		newVPLDP := func(t *testing.T, domain string) []byte {
+			t.Helper()
+
+			ldpVP, err := newTestPresentation(t, []byte(validPresentation))
+			require.NoError(t, err)
+
+			err = ldpVP.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				SignatureRepresentation: SignatureJWS,
+				Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+				VerificationMethod:      ldpVP.Holder + "#keys-1",
+				Purpose:                 "authentication",
+				Domain:                  domain,
+			}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+			require.NoError(t, err)
+
+			vpBytes, err := ldpVP.MarshalJSON()
+			require.NoError(t, err)
+
+			return vpBytes
+		}
+
+		keyOpt := WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+		require.NoError(t, parse(t, newVPLDP(t, verifierID), keyOpt, WithPresExpectedAudience(verifierID)))
+
+		err = parse(t, newVPLDP(t, "did:example:other"), keyOpt, WithPresExpectedAudience(verifierID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation is not intended for did:example:verifier: "+
+			"linked data proof with the domain is not found")
+
+		// the presentation without proof can't be bound to the audience
+		err = parse(t, []byte(validPresentation), WithPresExpectedAudience(verifierID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "presentation is not intended for did:example:verifier")
+	})
+}
@@ -25,6 +25,7 @@ func unmarshalUnsecuredJWTPresClaims(vpJWT string) (*JWTPresClaims, error) {
 	return &claims, nil
 }
 
-func decodeVPFromUnsecuredJWT(vpJWT string) ([]byte, *rawPresentation, error) {
-	return decodePresJWT(vpJWT, unmarshalUnsecuredJWTPresClaims)
+func decodeVPFromUnsecuredJWT(vpJWT string, requireHolder bool,
+	expectedAudience string) ([]byte, *rawPresentation, error) {
+	return decodePresJWT(vpJWT, unmarshalUnsecuredJWTPresClaims, requireHolder, expectedAudience)
 }
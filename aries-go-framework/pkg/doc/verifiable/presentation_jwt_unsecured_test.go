@@ -20,7 +20,7 @@ func TestJWTPresClaims_MarshalUnsecuredJWT(t *testing.T) {
 
 	jws := createCredUnsecuredJWT(t, vp)
 
-	_, rawVC, err := decodeVPFromUnsecuredJWT(jws)
+	_, rawVC, err := decodeVPFromUnsecuredJWT(jws, false, "")
 
 	require.NoError(t, err)
 	require.Equal(t, vp.stringJSON(t), rawVC.stringJSON(t))
@@ -33,14 +33,14 @@ func TestDecodeVPFromUnsecuredJWT(t *testing.T) {
 
 		jws := createCredUnsecuredJWT(t, vp)
 
-		vpDecodedBytes, vpRaw, err := decodeVPFromUnsecuredJWT(jws)
+		vpDecodedBytes, vpRaw, err := decodeVPFromUnsecuredJWT(jws, false, "")
 		require.NoError(t, err)
 		require.NotNil(t, vpDecodedBytes)
 		require.Equal(t, vp.stringJSON(t), vpRaw.stringJSON(t))
 	})
 
 	t.Run("Invalid serialized unsecured JWT", func(t *testing.T) {
-		vpBytes, vpRaw, err := decodeVPFromUnsecuredJWT("invalid JWS")
+		vpBytes, vpRaw, err := decodeVPFromUnsecuredJWT("invalid JWS", false, "")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "decode Verifiable Presentation JWT claims")
 		require.Nil(t, vpBytes)
@@ -56,7 +56,7 @@ func TestDecodeVPFromUnsecuredJWT(t *testing.T) {
 		rawJWT, err := marshalUnsecuredJWT(jose.Headers{}, claims)
 		require.NoError(t, err)
 
-		vpBytes, vpRaw, err := decodeVPFromUnsecuredJWT(rawJWT)
+		vpBytes, vpRaw, err := decodeVPFromUnsecuredJWT(rawJWT, false, "")
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "decode Verifiable Presentation JWT claims")
 		require.Nil(t, vpBytes)
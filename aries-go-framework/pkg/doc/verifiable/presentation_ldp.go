@@ -6,24 +6,108 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
 // AddLinkedDataProof appends proof to the Verifiable Presentation.
+// The proof purpose is "authentication" unless LinkedDataProofContext.Purpose is defined.
+// ErrDuplicateProof is returned if the presentation already has a proof with the same verification method
+// and purpose, unless LinkedDataProofContext.AllowDuplicateProof is set.
+// If LinkedDataProofContext.SetHolderFromVM is set and the presentation has no holder, the holder is set
+// to the DID of the verification method before signing.
 func (vp *Presentation) AddLinkedDataProof(context *LinkedDataProofContext, jsonldOpts ...jsonld.ProcessorOpts) error {
-	vcBytes, err := vp.MarshalJSON()
+	if err := checkDuplicateProof(context, vp.Proofs, defaultPresentationProofPurpose); err != nil {
+		return err
+	}
+
+	if context.Purpose == "" {
+		vpContext := *context
+		vpContext.Purpose = defaultPresentationProofPurpose
+		context = &vpContext
+	}
+
+	vpToSign := vp
+
+	if context.SetHolderFromVM && vp.Holder == "" {
+		holder, err := holderFromVerificationMethod(context.VerificationMethod)
+		if err != nil {
+			return fmt.Errorf("add linked data proof to VP: %w", err)
+		}
+
+		vpCopy := *vp
+		vpCopy.Holder = holder
+		vpToSign = &vpCopy
+	}
+
+	vpDoc, err := toMap(vpToSign)
 	if err != nil {
 		return fmt.Errorf("add linked data proof to VP: %w", err)
 	}
 
-	proofs, err := addLinkedDataProof(context, vcBytes, jsonldOpts...)
+	proofs, err := addLinkedDataProof(context, vpDoc, jsonldOpts...)
 	if err != nil {
 		return err
 	}
 
+	vp.Holder = vpToSign.Holder
 	vp.Proofs = proofs
 
 	return nil
 }
+
+// VerifyProof checks the embedded linked data proof of the Verifiable Presentation only. Unlike ParsePresentation,
+// it does not descend into the enclosed credentials, so their own proofs are not checked (their content is still
+// covered by the presentation proof). It's useful to re-verify the presentation of already verified credentials.
+//
+// If no suites are passed, the suites defined by WithPresVPSuites or WithPresEmbeddedSignatureSuites are used,
+// otherwise the default suite for every proof type. JSON-LD options (e.g. WithPresJSONLDDocumentLoader) and
+// WithPresVerificationResult are applied as well.
+func (vp *Presentation) VerifyProof(fetcher PublicKeyFetcher, suites []verifier.SignatureSuite,
+	opts ...PresentationOpt) error {
+	if len(vp.Proofs) == 0 {
+		return errors.New("verify presentation proof: presentation has no proof")
+	}
+
+	vpOpts := getPresentationOpts(opts)
+	vpOpts.publicKeyFetcher = fetcher
+
+	if len(suites) > 0 {
+		vpOpts.vpLDPSuites = suites
+	}
+
+	vpBytes, err := vp.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("verify presentation proof: %w", err)
+	}
+
+	publicKeyFetcher, keyRecorder := vpOpts.newPublicKeyFetcher()
+
+	_, err = checkEmbeddedProof(vpBytes, &embeddedProofCheckOpts{
+		publicKeyFetcher:     publicKeyFetcher,
+		ldpSuites:            vpOpts.presentationSuites(),
+		vmAuthorizationVDR:   vpOpts.vmAuthorizationVDR,
+		controllerProofs:     vpOpts.trustedHolders != nil,
+		maxProofs:            vpOpts.maxProofs,
+		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
+	})
+	if err != nil {
+		return fmt.Errorf("verify presentation proof: %w", err)
+	}
+
+	return vpOpts.fillLDPVerificationResult(vpBytes, keyRecorder)
+}
+
+// holderFromVerificationMethod returns the DID of verification method (DID URL).
+func holderFromVerificationMethod(vm string) (string, error) {
+	did := strings.SplitN(vm, "#", 2)[0]
+	if !strings.HasPrefix(did, "did:") {
+		return "", fmt.Errorf("set holder from verification method: %q is not a DID URL", vm)
+	}
+
+	return did, nil
+}
@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,6 +15,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -94,5 +96,226 @@ func TestPresentation_AddLinkedDataProof(t *testing.T) {
 		r.Contains(newVPProof, "created")
 		r.Contains(newVPProof, "proofValue")
 		r.Equal("Ed25519Signature2018", newVPProof["type"])
+		r.Equal("authentication", newVPProof["proofPurpose"])
+		r.Empty(ldpContext.Purpose)
 	})
+
+	t.Run("Add Linked Data proof with explicit purpose to VP", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		r.NoError(err)
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			Purpose:                 "assertionMethod",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vp.Proofs, 1)
+		r.Equal("assertionMethod", vp.Proofs[0]["proofPurpose"])
+	})
+
+	t.Run("Add duplicate Linked Data proof to VP", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		r.NoError(err)
+
+		vmContext := *ldpContext
+		vmContext.VerificationMethod = "did:example:ebfeb1f712ebc6f1c276e12ec21#key-1"
+
+		err = vp.AddLinkedDataProof(&vmContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+
+		// the same verification method and default purpose of presentation
+		vmContext.Purpose = "authentication"
+		err = vp.AddLinkedDataProof(&vmContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.True(errors.Is(err, ErrDuplicateProof))
+		r.EqualError(err, "proof with the same verification method and purpose already exists")
+		r.Len(vp.Proofs, 1)
+
+		// the same verification method but other purpose
+		vmContext.Purpose = "assertionMethod"
+		err = vp.AddLinkedDataProof(&vmContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vp.Proofs, 2)
+	})
+
+	t.Run("Set holder from verification method", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		r.NoError(err)
+
+		vp.Holder = ""
+
+		ss := ed25519signature2018.New(suite.WithSigner(signer),
+			suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ss,
+			VerificationMethod:      "did:example:123456#key1",
+			SetHolderFromVM:         true,
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Equal("did:example:123456", vp.Holder)
+
+		vpBytes, err := json.Marshal(vp)
+		r.NoError(err)
+
+		// the holder is covered by the proof
+		vpWithProof, err := newTestPresentation(t, vpBytes,
+			WithPresEmbeddedSignatureSuites(ss),
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		r.NoError(err)
+		r.Equal("did:example:123456", vpWithProof.Holder)
+	})
+
+	t.Run("Holder is not overridden by verification method", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		r.NoError(err)
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:123456#key1",
+			SetHolderFromVM:         true,
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Equal("did:example:ebfeb1f712ebc6f1c276e12ec21", vp.Holder)
+	})
+
+	t.Run("Set holder from invalid verification method", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		r.NoError(err)
+
+		vp.Holder = ""
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			SetHolderFromVM:         true,
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.EqualError(err, `add linked data proof to VP: set holder from verification method: "" is not a DID URL`)
+		r.Empty(vp.Holder)
+		r.Empty(vp.Proofs)
+	})
+}
+
+func TestPresentation_VerifyProof(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ss,
+		VerificationMethod:      "did:example:123456#key1",
+	}
+
+	fetcher := SingleKey(signer.PublicKeyBytes(), kms.ED25519)
+	loaderOpt := WithPresJSONLDDocumentLoader(createTestDocumentLoader(t))
+
+	newSignedVP := func(t *testing.T) *Presentation {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		// the proof of the credential is invalid
+		vc.ID = "http://example.edu/credentials/tampered"
+
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		err = vp.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		return vp
+	}
+
+	t.Run("proof of presentation only is verified", func(t *testing.T) {
+		vp := newSignedVP(t)
+
+		var result VerificationResult
+
+		require.NoError(t, vp.VerifyProof(fetcher, []verifier.SignatureSuite{ss}, loaderOpt,
+			WithPresVerificationResult(&result)))
+		require.Len(t, result.Proofs, 1)
+		require.Equal(t, "did:example:123456#key1", result.Proofs[0].VerificationMethod)
+
+		// default suite
+		require.NoError(t, vp.VerifyProof(fetcher, nil, loaderOpt))
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(fetcher), WithPresVerifyAllEmbedded(1))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check proof of credential of presentation")
+	})
+
+	t.Run("invalid proof of presentation", func(t *testing.T) {
+		vp := newSignedVP(t)
+		vp.Holder = "did:example:tampered"
+
+		err := vp.VerifyProof(fetcher, []verifier.SignatureSuite{ss}, loaderOpt)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify presentation proof: check embedded proof")
+	})
+
+	t.Run("presentation has no proof", func(t *testing.T) {
+		vp, err := NewPresentation()
+		require.NoError(t, err)
+
+		err = vp.VerifyProof(fetcher, []verifier.SignatureSuite{ss}, loaderOpt)
+		require.EqualError(t, err, "verify presentation proof: presentation has no proof")
+	})
+
+	t.Run("public key fetcher is not defined", func(t *testing.T) {
+		err := newSignedVP(t).VerifyProof(nil, []verifier.SignatureSuite{ss}, loaderOpt)
+		require.EqualError(t, err, "verify presentation proof: public key fetcher is not defined")
+	})
+}
+
+func TestWithPresMaxProofs(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vp, err := NewPresentation()
+	require.NoError(t, err)
+
+	for _, vm := range []string{"did:example:123456#key1", "did:example:123456#key2"} {
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      vm,
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+	}
+
+	vpBytes, err := json.Marshal(vp)
+	require.NoError(t, err)
+
+	fetcher := SingleKey(signer.PublicKeyBytes(), kms.ED25519)
+	keyOpt := WithPresPublicKeyFetcher(fetcher)
+
+	_, err = newTestPresentation(t, vpBytes, keyOpt)
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(t, vpBytes, keyOpt, WithPresMaxProofs(1))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "number of proofs 2 exceeds the limit of 1")
+
+	err = vp.VerifyProof(fetcher, nil, WithPresMaxProofs(1),
+		WithPresJSONLDDocumentLoader(createTestDocumentLoader(t)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "number of proofs 2 exceeds the limit of 1")
 }
@@ -8,15 +8,20 @@ package verifiable
 import (
 	_ "embed"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"testing"
 
 	jsonld "github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/ldcontext/embed"
 	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -250,6 +255,73 @@ func TestParsePresentation(t *testing.T) {
 	})
 }
 
+func TestParsePresentations(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	require.NoError(t, err)
+
+	jwtClaims, err := vp.JWTClaims([]string{}, false)
+	require.NoError(t, err)
+
+	vpJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vp.Holder+"#keys-1")
+	require.NoError(t, err)
+
+	var result VerificationResult
+
+	vps, errs := ParsePresentations([][]byte{[]byte(validPresentation), []byte(vpJWS), []byte("invalid")},
+		WithPresJSONLDDocumentLoader(createTestDocumentLoader(t)),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithPresVerificationResult(&result))
+	require.Len(t, vps, 3)
+	require.Len(t, errs, 3)
+
+	require.NoError(t, errs[0])
+	require.Equal(t, vp, vps[0])
+
+	require.NoError(t, errs[1])
+	require.Equal(t, vp.Holder, vps[1].Holder)
+	require.Len(t, vps[1].Credentials(), 1)
+
+	require.Error(t, errs[2])
+	require.Nil(t, vps[2])
+
+	require.Empty(t, result.Proofs)
+
+	vps, errs = ParsePresentations(nil)
+	require.Empty(t, vps)
+	require.Empty(t, errs)
+
+	t.Run("JSON-LD contexts are loaded once if the loader is not defined", func(t *testing.T) {
+		documents := make(map[string][]byte)
+
+		for _, c := range append(embed.Contexts, ldtestutil.Contexts()...) {
+			documents[c.URL] = c.Content
+		}
+
+		parse := func(n int) int32 {
+			transport := &countingTransport{documents: documents}
+
+			data := make([][]byte, n)
+			for i := range data {
+				data[i] = []byte(validPresentation)
+			}
+
+			_, errs := ParsePresentations(data, WithPresHTTPClient(transport.client()), WithPresDisabledProofCheck())
+			for _, err := range errs {
+				require.NoError(t, err)
+			}
+
+			return transport.count()
+		}
+
+		loadsOfOne := parse(1)
+		require.Positive(t, loadsOfOne)
+		require.Equal(t, loadsOfOne, parse(3))
+	})
+}
+
 func TestValidateVP_Context(t *testing.T) {
 	t.Run("rejects verifiable presentation with empty context", func(t *testing.T) {
 		raw := &rawPresentation{}
@@ -407,6 +479,110 @@ func TestPresentation_MarshalJSON(t *testing.T) {
 	require.Equal(t, vp, vp2)
 }
 
+func TestPresentation_MarshalJSON_TypeForms(t *testing.T) {
+	for _, vpType := range []interface{}{"VerifiablePresentation", []interface{}{"VerifiablePresentation"}} {
+		vpMap, err := toMap(validPresentation)
+		require.NoError(t, err)
+
+		vpMap["type"] = vpType
+
+		vpBytes, err := json.Marshal(vpMap)
+		require.NoError(t, err)
+
+		vp, err := newTestPresentation(t, vpBytes)
+		require.NoError(t, err)
+		require.Equal(t, []string{"VerifiablePresentation"}, vp.Type)
+
+		vpBytes, err = vp.MarshalJSON()
+		require.NoError(t, err)
+
+		vpMap, err = toMap(vpBytes)
+		require.NoError(t, err)
+
+		// a single type is marshalled as a string, the same as credential type
+		require.Equal(t, "VerifiablePresentation", vpMap["type"])
+	}
+}
+
+func TestPresentation_MarshalJSON_CredentialsOrder(t *testing.T) {
+	newVC := func(t *testing.T, id string) *Credential {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.ID = id
+
+		return vc
+	}
+
+	vc1 := newVC(t, "http://example.edu/credentials/1")
+	vc3 := newVC(t, "http://example.edu/credentials/3")
+
+	jwtClaims, err := newVC(t, "http://example.edu/credentials/2").JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(WithCredentials(vc1), WithJWTCredentials(vcJWT), WithCredentials(vc3))
+	require.NoError(t, err)
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	credIDs := func(t *testing.T, vpBytes []byte) []string {
+		t.Helper()
+
+		var raw struct {
+			Credentials []interface{} `json:"verifiableCredential"`
+		}
+
+		require.NoError(t, json.Unmarshal(vpBytes, &raw))
+
+		ids := make([]string, len(raw.Credentials))
+
+		for i, cred := range raw.Credentials {
+			switch c := cred.(type) {
+			case string:
+				ids[i] = c
+			case map[string]interface{}:
+				ids[i] = c["id"].(string)
+			}
+		}
+
+		return ids
+	}
+
+	expectedIDs := []string{"http://example.edu/credentials/1", vcJWT, "http://example.edu/credentials/3"}
+	require.Equal(t, expectedIDs, credIDs(t, vpBytes))
+
+	// the order is kept through several marshal/unmarshal cycles
+	for i := 0; i < 3; i++ {
+		vp, err = newTestPresentation(t, vpBytes, WithPresAllowUnsecuredJWT(), WithPresKeepJWTCredentials())
+		require.NoError(t, err)
+
+		vpBytes, err = vp.MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, expectedIDs, credIDs(t, vpBytes))
+	}
+
+	// JWT credential decoded into JSON keeps its position too
+	vp, err = newTestPresentation(t, vpBytes, WithPresAllowUnsecuredJWT())
+	require.NoError(t, err)
+
+	creds, err := vp.MarshalledCredentials()
+	require.NoError(t, err)
+	require.Len(t, creds, 3)
+
+	for i, cred := range creds {
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(cred, &vcMap))
+		require.Equal(t, fmt.Sprintf("http://example.edu/credentials/%d", i+1), vcMap["id"])
+	}
+}
+
 func TestNewPresentation(t *testing.T) {
 	r := require.New(t)
 
@@ -452,6 +628,299 @@ func TestNewPresentation(t *testing.T) {
 	r.EqualError(err, "credential is not base64url encoded JWT")
 }
 
+func TestWithJWTCredentialsNoVerify(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	// the holder does not know the key of the issuer
+	issuerSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vcJWS, err := jwtClaims.MarshalJWS(EdDSA, issuerSigner, vc.Issuer.ID+"#keys-1")
+	require.NoError(t, err)
+
+	// JWT which cannot be decoded at the time of presentation building
+	const opaqueJWT = "eyJhbGciOiJub25lIn0.opaque-payload.opaque-signature"
+
+	vp, err := NewPresentation(WithJWTCredentialsNoVerify(vcJWS, opaqueJWT))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{vcJWS, opaqueJWT}, vp.Credentials())
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	var vpMap map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(vpBytes, &vpMap))
+	require.Equal(t, []interface{}{vcJWS, opaqueJWT}, vpMap["verifiableCredential"])
+
+	_, err = NewPresentation(WithJWTCredentialsNoVerify(""))
+	require.EqualError(t, err, "credential JWT is empty")
+}
+
+func TestPresentation_AddCredential(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	vp, err := NewPresentation()
+	require.NoError(t, err)
+
+	require.NoError(t, vp.AddCredential(vc))
+	require.NoError(t, vp.AddJWTCredential(vcJWT))
+	require.Equal(t, []interface{}{vc, vcJWT}, vp.Credentials())
+
+	// marshalled the same way as the presentation created with the credentials
+	expectedVP, err := NewPresentation(WithCredentials(vc), WithJWTCredentials(vcJWT))
+	require.NoError(t, err)
+
+	expectedBytes, err := expectedVP.MarshalJSON()
+	require.NoError(t, err)
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedBytes), string(vpBytes))
+
+	err = vp.AddCredential(nil)
+	require.EqualError(t, err, "credential is not defined")
+
+	err = vp.AddJWTCredential("not a JWT")
+	require.EqualError(t, err, "credential is not base64url encoded JWT")
+	require.Len(t, vp.Credentials(), 2)
+
+	// presentation with proof
+	vp.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+	err = vp.AddCredential(vc)
+	require.EqualError(t, err, "add credential to presentation with proof")
+
+	err = vp.AddJWTCredential(vcJWT)
+	require.EqualError(t, err, "add credential to presentation with proof")
+	require.Len(t, vp.Credentials(), 2)
+}
+
+func TestPresentation_RemoveCredential(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtVC, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtVC.ID = "http://example.edu/credentials/jwt"
+
+	jwtClaims, err := jwtVC.JWTClaims(true)
+	require.NoError(t, err)
+
+	vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	t.Run("credentials added to presentation", func(t *testing.T) {
+		vp, err := NewPresentation(WithCredentials(vc), WithJWTCredentials(vcJWT))
+		require.NoError(t, err)
+
+		vp.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+		require.NoError(t, vp.RemoveCredential(jwtVC.ID))
+		require.Equal(t, []interface{}{vc}, vp.Credentials())
+		require.Empty(t, vp.Proofs)
+
+		require.NoError(t, vp.RemoveCredential(vc.ID))
+		require.Empty(t, vp.Credentials())
+	})
+
+	t.Run("credentials of parsed presentation", func(t *testing.T) {
+		vp, err := NewPresentation(WithCredentials(vc), WithJWTCredentials(vcJWT))
+		require.NoError(t, err)
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		vp, err = newTestPresentation(t, vpBytes, WithPresDisabledProofCheck())
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 2)
+
+		require.NoError(t, vp.RemoveCredential(vc.ID))
+		require.NoError(t, vp.RemoveCredential(jwtVC.ID))
+		require.Empty(t, vp.Credentials())
+	})
+
+	t.Run("credential is not found", func(t *testing.T) {
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		vp.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+		err = vp.RemoveCredential("http://example.edu/credentials/unknown")
+		require.EqualError(t, err,
+			"credential http://example.edu/credentials/unknown is not found in presentation")
+		require.Len(t, vp.Credentials(), 1)
+		require.Len(t, vp.Proofs, 1)
+	})
+
+	t.Run("empty id", func(t *testing.T) {
+		noIDVC := *vc
+		noIDVC.ID = ""
+
+		vp, err := NewPresentation(WithCredentials(&noIDVC))
+		require.NoError(t, err)
+
+		err = vp.RemoveCredential("")
+		require.EqualError(t, err, "credential id is not defined")
+		require.Len(t, vp.Credentials(), 1)
+	})
+}
+
+func TestPresentation_ParsedCredentials(t *testing.T) {
+	issuerSigner, err := newCryptoSigner(kms.RSARS256Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	vcJWS, err := jwtClaims.MarshalJWS(RS256, issuerSigner, "#key-1")
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(WithCredentials(vc), WithJWTCredentials(vcJWS))
+	require.NoError(t, err)
+
+	vcOpts := []CredentialOpt{
+		WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+		WithPublicKeyFetcher(SingleKey(issuerSigner.PublicKeyBytes(), kms.RSARS256)),
+	}
+
+	credentials, err := vp.ParsedCredentials(vcOpts...)
+	require.NoError(t, err)
+	require.Len(t, credentials, 2)
+	require.Equal(t, vc.ID, credentials[0].ID)
+	require.Equal(t, vc.ID, credentials[1].ID)
+
+	// credentials of parsed presentation
+	vp.Holder = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+	vpJWS := createCredJWS(t, vp, issuerSigner)
+
+	vp, err = newTestPresentation(t, []byte(vpJWS),
+		WithPresPublicKeyFetcher(SingleKey(issuerSigner.PublicKeyBytes(), kms.RSARS256)))
+	require.NoError(t, err)
+
+	credentials, err = vp.ParsedCredentials(vcOpts...)
+	require.NoError(t, err)
+	require.Len(t, credentials, 2)
+
+	// JWT credential is signed with other key
+	otherSigner, err := newCryptoSigner(kms.RSARS256Type)
+	require.NoError(t, err)
+
+	vp, err = NewPresentation(WithJWTCredentials(vcJWS))
+	require.NoError(t, err)
+
+	credentials, err = vp.ParsedCredentials(WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+		WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.RSARS256)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parse credential 0 of presentation")
+	require.Nil(t, credentials)
+}
+
+func TestWithPresStrictCredentialValidation(t *testing.T) {
+	vp, err := newTestPresentation(t, []byte(validPresentation), WithPresStrictCredentialValidation())
+	require.NoError(t, err)
+	require.Len(t, vp.Credentials(), 1)
+
+	vpMap := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(validPresentation), &vpMap))
+
+	// add undefined term to the credential
+	vpMap["verifiableCredential"].([]interface{})[0].(map[string]interface{})["foo"] = "bar"
+
+	vpBytes, err := json.Marshal(vpMap)
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(t, vpBytes)
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(t, vpBytes, WithPresStrictCredentialValidation())
+	require.EqualError(t, err, "decode credentials of presentation: strict validation of credential of presentation: "+
+		"JSON-LD doc has different structure after compaction")
+
+	// credential in JWT form
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vc.CustomFields = CustomFields{"foo": "bar"}
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	vp, err = NewPresentation(WithJWTCredentials(vcJWT))
+	require.NoError(t, err)
+
+	vpBytes, err = vp.MarshalJSON()
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(t, vpBytes, WithPresStrictCredentialValidation(), WithPresAllowUnsecuredJWT())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "strict validation of credential of presentation")
+}
+
+func TestWithPresMaxDocumentSize(t *testing.T) {
+	vp, err := newTestPresentation(t, []byte(validPresentation), WithPresMaxDocumentSize(len(validPresentation)))
+	require.NoError(t, err)
+	require.NotNil(t, vp)
+
+	_, err = newTestPresentation(t, []byte(validPresentation), WithPresMaxDocumentSize(len(validPresentation)-1))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode presentation: document size")
+	require.Contains(t, err.Error(), "exceeds the limit")
+
+	// presentation in JWT form
+	jwtClaims, err := vp.JWTClaims(nil, false)
+	require.NoError(t, err)
+
+	vpJWT, err := jwtClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(t, []byte(vpJWT), WithPresMaxDocumentSize(len(vpJWT)/2))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds the limit")
+}
+
+func TestWithPresentationContext(t *testing.T) {
+	vp, err := NewPresentation(
+		WithPresentationContext(baseContext, "https://www.w3.org/2018/credentials/examples/v1"),
+		WithPresentationContext("https://www.w3.org/2018/credentials/examples/v1",
+			"https://trustbloc.github.io/context/vc/examples-v1.jsonld"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"https://www.w3.org/2018/credentials/v1",
+		"https://www.w3.org/2018/credentials/examples/v1",
+		"https://trustbloc.github.io/context/vc/examples-v1.jsonld",
+	}, vp.Context)
+
+	vp.Type = append(vp.Type, "UniversityDegreeCredential")
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(t, vpBytes, WithPresStrictValidation())
+	require.NoError(t, err)
+
+	_, err = NewPresentation(WithPresentationContext(""))
+	require.EqualError(t, err, "presentation context is empty")
+}
+
 func TestPresentation_decodeCredentials(t *testing.T) {
 	r := require.New(t)
 
@@ -508,6 +977,90 @@ func TestWithPresEmbeddedSignatureSuites(t *testing.T) {
 	require.Equal(t, []verifier.SignatureSuite{ss}, opts.ldpSuites)
 }
 
+func TestWithPresVPSuitesAndVCSuites(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	localCrypto, err := createLocalCrypto()
+	require.NoError(t, err)
+
+	vcSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(suite.NewCryptoVerifier(localCrypto)))
+	vpSuite := jsonwebsignature2020.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(suite.NewCryptoVerifier(localCrypto)))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   vcSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(WithCredentials(vc))
+	require.NoError(t, err)
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "JsonWebSignature2020",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   vpSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	verifyOpts := []PresentationOpt{
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), "Ed25519Signature2018")),
+		WithPresVerifyAllEmbedded(1),
+	}
+
+	t.Run("suites are scoped to presentation and credentials", func(t *testing.T) {
+		_, err := newTestPresentation(t, vpBytes,
+			append(verifyOpts, WithPresVPSuites(vpSuite), WithPresVCSuites(vcSuite))...)
+		require.NoError(t, err)
+	})
+
+	t.Run("suites for both levels", func(t *testing.T) {
+		_, err := newTestPresentation(t, vpBytes,
+			append(verifyOpts, WithPresEmbeddedSignatureSuites(vcSuite, vpSuite))...)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes,
+			append(verifyOpts, WithPresEmbeddedSignatureSuites(vcSuite), WithPresVPSuites(vpSuite))...)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, append(verifyOpts, WithPresEmbeddedSignatureSuites(vcSuite))...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JsonWebSignature2020")
+	})
+
+	t.Run("credential suite is not defined", func(t *testing.T) {
+		_, err := newTestPresentation(t, vpBytes,
+			append(verifyOpts, WithPresVPSuites(vpSuite), WithPresVCSuites(vpSuite))...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check proof of credential of presentation")
+	})
+
+	t.Run("options", func(t *testing.T) {
+		opts := getPresentationOpts([]PresentationOpt{WithPresEmbeddedSignatureSuites(vcSuite)})
+		require.Equal(t, []verifier.SignatureSuite{vcSuite}, opts.presentationSuites())
+		require.Equal(t, []verifier.SignatureSuite{vcSuite}, opts.credentialSuites())
+
+		opts = getPresentationOpts([]PresentationOpt{
+			WithPresEmbeddedSignatureSuites(vcSuite), WithPresVPSuites(vpSuite), WithPresVCSuites(vpSuite),
+		})
+		require.Equal(t, []verifier.SignatureSuite{vpSuite}, opts.presentationSuites())
+		require.Equal(t, []verifier.SignatureSuite{vpSuite}, mapOpts(opts).ldpSuites)
+	})
+}
+
 func TestWithPresJSONLDDocumentLoader(t *testing.T) {
 	documentLoader := jsonld.NewDefaultDocumentLoader(nil)
 	presentationOpt := WithPresJSONLDDocumentLoader(documentLoader)
@@ -518,6 +1071,343 @@ func TestWithPresJSONLDDocumentLoader(t *testing.T) {
 	require.Equal(t, documentLoader, opts.jsonldDocumentLoader)
 }
 
+func TestWithPresHTTPClient(t *testing.T) {
+	client := &http.Client{}
+
+	opts := getPresentationOpts([]PresentationOpt{WithPresHTTPClient(client)})
+	require.Equal(t, client, opts.httpClient)
+	require.NotNil(t, opts.jsonldDocumentLoader)
+	require.Equal(t, client, mapOpts(opts).httpClient)
+
+	documentLoader := createTestDocumentLoader(t)
+
+	opts = getPresentationOpts([]PresentationOpt{WithPresHTTPClient(client), WithPresJSONLDDocumentLoader(documentLoader)})
+	require.Equal(t, documentLoader, opts.jsonldDocumentLoader)
+}
+
+func TestWithPresRequireHolder(t *testing.T) {
+	t.Run("JSON presentation", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation), WithPresRequireHolder())
+		require.NoError(t, err)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", vp.Holder)
+
+		raw := &rawPresentation{}
+		require.NoError(t, json.Unmarshal([]byte(validPresentation), &raw))
+		raw.Holder = ""
+
+		vpBytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		vp, err = newTestPresentation(t, vpBytes)
+		require.NoError(t, err)
+		require.Empty(t, vp.Holder)
+
+		vp, err = newTestPresentation(t, vpBytes, WithPresRequireHolder())
+		require.EqualError(t, err, "holder is required")
+		require.Nil(t, vp)
+	})
+
+	t.Run("JWT presentation", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		createJWT := func(iss string) []byte {
+			claims, err := newJWTPresClaims(vp, nil, false)
+			require.NoError(t, err)
+
+			claims.Issuer = iss
+
+			vpJWT, err := claims.MarshalUnsecuredJWT()
+			require.NoError(t, err)
+
+			return []byte(vpJWT)
+		}
+
+		vpDecoded, err := newTestPresentation(t, createJWT(vp.Holder),
+			WithPresRequireHolder(), WithPresDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, vp.Holder, vpDecoded.Holder)
+
+		vpDecoded, err = newTestPresentation(t, createJWT("did:example:76e12ec712ebc6f1c221ebfeb1f"),
+			WithPresRequireHolder(), WithPresDisabledProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "holder did:example:ebfeb1f712ebc6f1c276e12ec21 of presentation "+
+			"differs from JWT iss did:example:76e12ec712ebc6f1c221ebfeb1f")
+		require.Nil(t, vpDecoded)
+
+		vpDecoded, err = newTestPresentation(t, createJWT(""),
+			WithPresRequireHolder(), WithPresDisabledProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "holder is required: JWT iss claim is not defined")
+		require.Nil(t, vpDecoded)
+
+		// iss claim is not checked by default
+		vpDecoded, err = newTestPresentation(t, createJWT(""), WithPresDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, vp.Holder, vpDecoded.Holder)
+	})
+
+	t.Run("JWT presentation without vp claim", func(t *testing.T) {
+		claims := &JWTPresClaims{Claims: &jwt.Claims{Issuer: "did:example:ebfeb1f712ebc6f1c276e12ec21"}}
+
+		require.EqualError(t, claims.checkHolder(), "holder is required: JWT vp claim is not defined")
+
+		vpJWT, err := claims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		vp, err := newTestPresentation(t, []byte(vpJWT), WithPresRequireHolder(), WithPresDisabledProofCheck())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWT vp claim is not defined")
+		require.Nil(t, vp)
+	})
+}
+
+func TestPresentation_NestedPresentations(t *testing.T) {
+	nestedVP := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(validPresentation), &nestedVP))
+
+	newVPBytes := func(nested interface{}) []byte {
+		vpMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validPresentation), &vpMap))
+
+		vpMap["verifiableCredential"] = append(vpMap["verifiableCredential"].([]interface{}), nested)
+
+		vpBytes, err := json.Marshal(vpMap)
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	t.Run("presentation enclosed as object", func(t *testing.T) {
+		vp, err := newTestPresentation(t, newVPBytes(nestedVP))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 2)
+		require.Len(t, vp.NestedPresentations(), 1)
+
+		nested := vp.NestedPresentations()[0]
+		require.Equal(t, "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5", nested.ID)
+		require.Len(t, nested.Credentials(), 1)
+		require.Empty(t, nested.NestedPresentations())
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, string(newVPBytes(nestedVP)), string(vpBytes))
+	})
+
+	t.Run("presentation enclosed as JWT", func(t *testing.T) {
+		nested, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		vp, err := newTestPresentation(t, newVPBytes(createCredUnsecuredJWT(t, nested)), WithPresAllowUnsecuredJWT())
+		require.NoError(t, err)
+		require.Len(t, vp.NestedPresentations(), 1)
+		require.Equal(t, nested.Holder, vp.NestedPresentations()[0].Holder)
+	})
+
+	t.Run("max nesting depth is exceeded", func(t *testing.T) {
+		vpMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(newVPBytes(nestedVP), &vpMap))
+
+		vpBytes := newVPBytes(vpMap)
+
+		vp, err := newTestPresentation(t, vpBytes)
+		require.NoError(t, err)
+		require.Len(t, vp.NestedPresentations()[0].NestedPresentations(), 1)
+
+		vp, err = newTestPresentation(t, vpBytes, WithPresMaxNestingDepth(1))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "max nesting depth 1 of presentations is exceeded")
+		require.Nil(t, vp)
+
+		vp, err = newTestPresentation(t, vpBytes, WithPresMaxNestingDepth(0))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "max nesting depth 0 of presentations is exceeded")
+		require.Nil(t, vp)
+	})
+
+	t.Run("invalid nested presentation", func(t *testing.T) {
+		vp, err := newTestPresentation(t, newVPBytes(map[string]interface{}{
+			"type": "VerifiablePresentation",
+		}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode nested presentation")
+		require.Nil(t, vp)
+	})
+}
+
+func TestWithNestedPresentationJWT(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	nested, err := newTestPresentation(t, []byte(validPresentation))
+	require.NoError(t, err)
+
+	jwtClaims, err := nested.JWTClaims([]string{}, false)
+	require.NoError(t, err)
+
+	nestedJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, nested.Holder+"#keys-1")
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(WithNestedPresentationJWT(nestedJWS))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{nestedJWS}, vp.Credentials())
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ss,
+		VerificationMethod:      "did:example:123456#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vpBytes, err := json.Marshal(vp)
+	require.NoError(t, err)
+
+	keyOpts := []PresentationOpt{
+		WithPresEmbeddedSignatureSuites(ss),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithPresVerifyAllEmbedded(1),
+	}
+
+	parsedVP, err := newTestPresentation(t, vpBytes, keyOpts...)
+	require.NoError(t, err)
+	require.Len(t, parsedVP.NestedPresentations(), 1)
+
+	parsedNested := parsedVP.NestedPresentations()[0]
+	require.Equal(t, nested.Holder, parsedNested.Holder)
+
+	jws, ok := parsedNested.JWTString()
+	require.True(t, ok)
+	require.Equal(t, nestedJWS, jws)
+
+	_, ok = parsedVP.JWTString()
+	require.False(t, ok)
+
+	// the nested presentation is marshalled back into the same JWT, so the proof of the outer one remains valid
+	parsedVPBytes, err := json.Marshal(parsedVP)
+	require.NoError(t, err)
+	require.JSONEq(t, string(vpBytes), string(parsedVPBytes))
+
+	_, err = newTestPresentation(t, parsedVPBytes, keyOpts...)
+	require.NoError(t, err)
+
+	t.Run("not a presentation JWT", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vcClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWS, err := vcClaims.MarshalJWS(EdDSA, signer, "did:example:123456#key1")
+		require.NoError(t, err)
+
+		for _, invalid := range []string{vcJWS, "", "not a JWT"} {
+			_, err = NewPresentation(WithNestedPresentationJWT(invalid))
+			require.EqualError(t, err, "nested presentation is not JWT with vp claim")
+		}
+	})
+}
+
+func TestWithPresVerifyAllEmbedded(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:123456#key1",
+	}
+
+	newSignedVP := func(t *testing.T, tamperVC bool, nested *Presentation) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		if tamperVC {
+			vc.ID = "http://example.edu/credentials/tampered"
+		}
+
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		if nested != nil {
+			vp.credentials = append(vp.credentials, nested)
+		}
+
+		err = vp.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	verifyOpts := []PresentationOpt{WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))}
+
+	t.Run("enclosed credentials are verified", func(t *testing.T) {
+		vpBytes := newSignedVP(t, false, nil)
+
+		for _, depth := range []int{0, 1, 2} {
+			vp, err := newTestPresentation(t, vpBytes, append(verifyOpts, WithPresVerifyAllEmbedded(depth))...)
+			require.NoError(t, err)
+			require.Len(t, vp.Credentials(), 1)
+		}
+	})
+
+	t.Run("enclosed credential has invalid proof", func(t *testing.T) {
+		vpBytes := newSignedVP(t, true, nil)
+
+		// only VP proof is checked
+		_, err := newTestPresentation(t, vpBytes, verifyOpts...)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, append(verifyOpts, WithPresVerifyAllEmbedded(0))...)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, append(verifyOpts, WithPresVerifyAllEmbedded(1))...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check proof of credential of presentation")
+
+		_, err = newTestPresentation(t, vpBytes, append(verifyOpts, WithPresVerifyAllEmbedded(1),
+			WithPresDisabledProofCheck())...)
+		require.NoError(t, err)
+
+		result := &VerificationResult{}
+
+		vp, err := newTestPresentation(t, vpBytes, append(verifyOpts, WithPresVerifyAllEmbedded(1),
+			WithPresBestEffortCredentialVerification(), WithPresVerificationResult(result))...)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+		require.Len(t, result.Credentials, 1)
+		require.True(t, result.Credentials[0].ProofChecked)
+		require.Error(t, result.Credentials[0].Err)
+		require.Contains(t, result.Credentials[0].Err.Error(), "check proof of credential of presentation")
+	})
+
+	t.Run("credential of nested presentation has invalid proof", func(t *testing.T) {
+		nested, err := newTestPresentation(t, newSignedVP(t, true, nil), WithPresDisabledProofCheck())
+		require.NoError(t, err)
+
+		vpBytes := newSignedVP(t, false, nested)
+
+		_, err = newTestPresentation(t, vpBytes, append(verifyOpts, WithPresVerifyAllEmbedded(1))...)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, append(verifyOpts, WithPresVerifyAllEmbedded(2))...)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode nested presentation")
+	})
+}
+
 func TestParseUnverifiedPresentation(t *testing.T) {
 	loader, err := ldtestutil.DocumentLoader()
 	require.NoError(t, err)
@@ -549,3 +1439,57 @@ func TestParseUnverifiedPresentation(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, vp)
 }
+
+func TestWithPresKeepJWTCredentials(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(WithJWTCredentials(vcJWT))
+	require.NoError(t, err)
+
+	vp.Holder = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+	signer, err := newCryptoSigner(kms.RSARS256Type)
+	require.NoError(t, err)
+
+	vpJWS := createCredJWS(t, vp, signer)
+
+	vp, err = newTestPresentation(t, []byte(vpJWS),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.RSARS256)),
+		WithPresAllowUnsecuredJWT())
+	require.NoError(t, err)
+	require.Len(t, vp.Credentials(), 1)
+	require.IsType(t, []byte{}, vp.Credentials()[0])
+
+	vp, err = newTestPresentation(t, []byte(vpJWS),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.RSARS256)),
+		WithPresAllowUnsecuredJWT(),
+		WithPresKeepJWTCredentials())
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{vcJWT}, vp.Credentials())
+
+	// the credential is still validated
+	_, err = newTestPresentation(t, []byte(vpJWS),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.RSARS256)),
+		WithPresAllowUnsecuredJWT(),
+		WithPresKeepJWTCredentials(),
+		WithPresStrictCredentialValidation())
+	require.NoError(t, err)
+
+	vpBytes, err := json.Marshal(map[string]interface{}{
+		"@context":             []string{"https://www.w3.org/2018/credentials/v1"},
+		"type":                 "VerifiablePresentation",
+		"verifiableCredential": []string{"eyJhbGciOiJub25lIn0.invalid."},
+	})
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(t, vpBytes, WithPresKeepJWTCredentials())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode credential of presentation")
+}
@@ -0,0 +1,274 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// jwsProofType is a type of VerifiedProof of the presentation defined in JWS form.
+const jwsProofType = "JWS"
+
+// VerifiedProof describes a proof of the Verifiable Presentation which was checked during its decoding.
+type VerifiedProof struct {
+	// Type is a type of linked data proof (e.g. Ed25519Signature2018) or "JWS" if the presentation is in JWS form.
+	Type string
+
+	// Algorithm is a JWS algorithm (e.g. EdDSA); it's defined for the presentation in JWS form only.
+	Algorithm string
+
+	// VerificationMethod is an ID of the public key the proof is checked with (e.g. did:example:123#key-1).
+	VerificationMethod string
+
+	// PublicKey is the public key resolved for VerificationMethod.
+	PublicKey *verifier.PublicKey
+
+	// Challenge and Domain are the values defined in the linked data proof.
+	Challenge string
+	Domain    string
+
+	// ChallengeMatched and DomainMatched report whether Challenge and Domain match the expected ones
+	// (see WithPresExpectedChallenge and WithPresExpectedDomain). They are false if no value is expected.
+	ChallengeMatched bool
+	DomainMatched    bool
+}
+
+// VerificationResult holds details of the Verifiable Presentation verification.
+type VerificationResult struct {
+	// Proofs of the presentation which were checked. It's empty if proof check is disabled
+	// or the presentation has no proof.
+	Proofs []VerifiedProof
+
+	// Credentials are the results of the proof checks of the credentials enclosed into the presentation
+	// (nested presentations are not included). It's filled if WithPresBestEffortCredentialVerification is used.
+	Credentials []CredentialVerificationResult
+}
+
+// CredentialVerificationResult describes the proof check of the credential enclosed into the presentation.
+type CredentialVerificationResult struct {
+	// Index is the position of the credential in "verifiableCredential" of the presentation.
+	Index int
+
+	// ProofChecked reports whether the proof of the credential was checked. The proof of the credential with
+	// embedded linked data proof is checked only if WithPresVerifyAllEmbedded is used.
+	ProofChecked bool
+
+	// Err is the reason the credential was not verified (e.g. its issuer key can't be resolved).
+	Err error
+}
+
+// Verified reports whether the proof of the credential was checked successfully.
+func (r *CredentialVerificationResult) Verified() bool {
+	return r.ProofChecked && r.Err == nil
+}
+
+type resolvedKey struct {
+	issuerID string
+	keyID    string
+	pubKey   *verifier.PublicKey
+}
+
+// keyRecorder is a PublicKeyFetcher which remembers all resolved public keys.
+type keyRecorder struct {
+	fetcher PublicKeyFetcher
+	keys    []resolvedKey
+}
+
+func (r *keyRecorder) fetch(issuerID, keyID string) (*verifier.PublicKey, error) {
+	pubKey, err := r.fetcher(issuerID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.keys = append(r.keys, resolvedKey{issuerID: issuerID, keyID: keyID, pubKey: pubKey})
+
+	return pubKey, nil
+}
+
+func (r *keyRecorder) find(verificationMethod string) *resolvedKey {
+	for i := range r.keys {
+		if r.keys[i].verificationMethod() == verificationMethod {
+			return &r.keys[i]
+		}
+	}
+
+	return nil
+}
+
+func (k *resolvedKey) verificationMethod() string {
+	if strings.HasPrefix(k.keyID, "#") {
+		return k.issuerID + k.keyID
+	}
+
+	return k.keyID
+}
+
+// newPublicKeyFetcher returns public key fetcher to be used to check the presentation proof.
+// If verification result is requested, the fetcher remembers resolved keys.
+func (opts *presentationOpts) newPublicKeyFetcher() (PublicKeyFetcher, *keyRecorder) {
+	if opts.verificationResult == nil || opts.publicKeyFetcher == nil {
+		return opts.publicKeyFetcher, nil
+	}
+
+	recorder := &keyRecorder{fetcher: opts.publicKeyFetcher}
+
+	return recorder.fetch, recorder
+}
+
+// bestEffortCredentials reports whether the failed proof checks of the enclosed credentials are reported
+// in the verification result instead of failing the decoding (see WithPresBestEffortCredentialVerification).
+func (opts *presentationOpts) bestEffortCredentials() bool {
+	return opts.bestEffortCredentialVerification && opts.verificationResult != nil
+}
+
+// checkBestEffortCredentials checks that the results of the best effort verification of the enclosed
+// credentials are collected, i.e. WithPresBestEffortCredentialVerification is used with WithPresVerificationResult.
+func (opts *presentationOpts) checkBestEffortCredentials() error {
+	if opts.bestEffortCredentialVerification && opts.verificationResult == nil {
+		return errors.New("best effort credential verification requires verification result")
+	}
+
+	return nil
+}
+
+// addCredentialResult records the result of the proof check of the credential enclosed into the presentation.
+func (opts *presentationOpts) addCredentialResult(index int, proofChecked bool, err error) {
+	if !opts.bestEffortCredentials() {
+		return
+	}
+
+	opts.verificationResult.Credentials = append(opts.verificationResult.Credentials, CredentialVerificationResult{
+		Index:        index,
+		ProofChecked: proofChecked,
+		Err:          err,
+	})
+}
+
+// fillJWSVerificationResult fills verification result of the presentation in JWS form.
+func (opts *presentationOpts) fillJWSVerificationResult(vpJWS string, recorder *keyRecorder) error {
+	if recorder == nil || opts.disabledProofCheck {
+		return nil
+	}
+
+	headers, err := parseJWSHeaders(vpJWS)
+	if err != nil {
+		return err
+	}
+
+	alg, _ := headers.Algorithm()
+
+	proofs := make([]VerifiedProof, 0, len(recorder.keys))
+
+	for i := range recorder.keys {
+		proofs = append(proofs, VerifiedProof{
+			Type:               jwsProofType,
+			Algorithm:          alg,
+			VerificationMethod: recorder.keys[i].verificationMethod(),
+			PublicKey:          recorder.keys[i].pubKey,
+		})
+	}
+
+	opts.verificationResult.Proofs = proofs
+
+	return nil
+}
+
+// fillLDPVerificationResult fills verification result of the presentation with linked data proofs.
+func (opts *presentationOpts) fillLDPVerificationResult(vpBytes []byte, recorder *keyRecorder) error {
+	if recorder == nil || opts.disabledProofCheck {
+		return nil
+	}
+
+	var vpMap map[string]interface{}
+
+	if err := json.Unmarshal(vpBytes, &vpMap); err != nil {
+		return fmt.Errorf("unmarshal presentation: %w", err)
+	}
+
+	proofElement, ok := vpMap["proof"]
+	if !ok || proofElement == nil {
+		opts.verificationResult.Proofs = nil
+
+		return nil
+	}
+
+	proofs, err := getProofs(proofElement)
+	if err != nil {
+		return err
+	}
+
+	verifiedProofs := make([]VerifiedProof, 0, len(proofs))
+
+	for _, p := range proofs {
+		verifiedProof := VerifiedProof{
+			Type:               safeStringValue(p["type"]),
+			VerificationMethod: safeStringValue(p["verificationMethod"]),
+			Challenge:          safeStringValue(p["challenge"]),
+			Domain:             safeStringValue(p["domain"]),
+		}
+
+		if verifiedProof.VerificationMethod == "" {
+			// legacy name of verificationMethod
+			verifiedProof.VerificationMethod = safeStringValue(p["creator"])
+		}
+
+		if key := recorder.find(verifiedProof.VerificationMethod); key != nil {
+			verifiedProof.PublicKey = key.pubKey
+		}
+
+		// the challenge is a secret shared with the holder, so it's compared in constant time
+		verifiedProof.ChallengeMatched = opts.challenge != "" &&
+			subtle.ConstantTimeCompare([]byte(verifiedProof.Challenge), []byte(opts.challenge)) == 1
+		verifiedProof.DomainMatched = opts.domain != "" && verifiedProof.Domain == opts.domain
+
+		verifiedProofs = append(verifiedProofs, verifiedProof)
+	}
+
+	opts.verificationResult.Proofs = verifiedProofs
+
+	return nil
+}
+
+// addLDPVerificationResult appends the linked data proofs of the presentation in JWS form
+// to the verification result of its JWS.
+func (opts *presentationOpts) addLDPVerificationResult(vpBytes []byte, recorder *keyRecorder) error {
+	if recorder == nil || opts.disabledProofCheck {
+		return nil
+	}
+
+	jwsProofs := opts.verificationResult.Proofs
+
+	if err := opts.fillLDPVerificationResult(vpBytes, recorder); err != nil {
+		return err
+	}
+
+	opts.verificationResult.Proofs = append(jwsProofs, opts.verificationResult.Proofs...)
+
+	return nil
+}
+
+func parseJWSHeaders(jws string) (jose.Headers, error) {
+	headersBytes, err := base64.RawURLEncoding.DecodeString(strings.Split(jws, ".")[0])
+	if err != nil {
+		return nil, fmt.Errorf("decode JWS headers: %w", err)
+	}
+
+	var headers jose.Headers
+
+	if err = json.Unmarshal(headersBytes, &headers); err != nil {
+		return nil, fmt.Errorf("unmarshal JWS headers: %w", err)
+	}
+
+	return headers, nil
+}
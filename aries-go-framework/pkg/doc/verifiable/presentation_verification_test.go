@@ -0,0 +1,226 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestWithPresVerificationResult(t *testing.T) {
+	t.Run("presentation with linked data proof", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		ss := ed25519signature2018.New(suite.WithSigner(signer),
+			suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ss,
+			VerificationMethod:      "did:example:123456#key1",
+			Challenge:               "8b1f0a7e",
+			Domain:                  "example.com",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		result := &VerificationResult{}
+
+		_, err = newTestPresentation(t, vpBytes,
+			WithPresEmbeddedSignatureSuites(ss),
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithPresVerificationResult(result),
+			WithPresExpectedChallenge("8b1f0a7e"),
+			WithPresExpectedDomain("example.org"))
+		require.NoError(t, err)
+
+		require.Equal(t, []VerifiedProof{{
+			Type:               "Ed25519Signature2018",
+			VerificationMethod: "did:example:123456#key1",
+			PublicKey:          &verifier.PublicKey{Type: kms.ED25519, Value: signer.PublicKeyBytes()},
+			Challenge:          "8b1f0a7e",
+			Domain:             "example.com",
+			ChallengeMatched:   true,
+			DomainMatched:      false,
+		}}, result.Proofs)
+
+		// the challenges differing in the last byte or in length do not match
+		for _, challenge := range []string{"8b1f0a7f", "8b1f0a7", "8b1f0a7e0"} {
+			result = &VerificationResult{}
+
+			_, err = newTestPresentation(t, vpBytes,
+				WithPresEmbeddedSignatureSuites(ss),
+				WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+				WithPresVerificationResult(result),
+				WithPresExpectedChallenge(challenge))
+			require.NoError(t, err)
+			require.Len(t, result.Proofs, 1)
+			require.False(t, result.Proofs[0].ChallengeMatched)
+		}
+
+		// proof check is disabled
+		result = &VerificationResult{}
+
+		_, err = newTestPresentation(t, vpBytes,
+			WithPresDisabledProofCheck(),
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithPresVerificationResult(result))
+		require.NoError(t, err)
+		require.Empty(t, result.Proofs)
+	})
+
+	t.Run("presentation without proof", func(t *testing.T) {
+		result := &VerificationResult{}
+
+		_, err := newTestPresentation(t, []byte(validPresentation),
+			WithPresPublicKeyFetcher(SingleKey([]byte("pub key"), kms.ED25519)),
+			WithPresVerificationResult(result))
+		require.NoError(t, err)
+		require.Empty(t, result.Proofs)
+	})
+
+	t.Run("presentation in JWS form", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.RSARS256Type)
+		require.NoError(t, err)
+
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		claims, err := newJWTPresClaims(vp, nil, false)
+		require.NoError(t, err)
+
+		vpJWS, err := claims.MarshalJWS(RS256, signer, "#key-1")
+		require.NoError(t, err)
+
+		result := &VerificationResult{}
+
+		_, err = newTestPresentation(t, []byte(vpJWS),
+			WithPresPublicKeyFetcher(holderPublicKeyFetcher(signer.PublicKeyBytes())),
+			WithPresVerificationResult(result))
+		require.NoError(t, err)
+
+		require.Equal(t, []VerifiedProof{{
+			Type:               "JWS",
+			Algorithm:          "RS256",
+			VerificationMethod: "did:example:ebfeb1f712ebc6f1c276e12ec21#key-1",
+			PublicKey:          &verifier.PublicKey{Type: kms.RSARS256, Value: signer.PublicKeyBytes()},
+		}}, result.Proofs)
+	})
+}
+
+func TestWithPresBestEffortCredentialVerification(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	const unknownIssuer = "did:example:unknown"
+
+	newVCJWS := func(t *testing.T, issuerID string) string {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer.ID = issuerID
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, issuerID+"#key1")
+		require.NoError(t, err)
+
+		return vcJWS
+	}
+
+	vpBytes, err := json.Marshal(map[string]interface{}{
+		"@context": []string{"https://www.w3.org/2018/credentials/v1"},
+		"type":     "VerifiablePresentation",
+		"verifiableCredential": []string{
+			newVCJWS(t, "did:example:76e12ec712ebc6f1c221ebfeb1f"),
+			newVCJWS(t, unknownIssuer),
+		},
+	})
+	require.NoError(t, err)
+
+	fetcher := WithPresPublicKeyFetcher(func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		if issuerID == unknownIssuer {
+			return nil, errors.New("DID is not found")
+		}
+
+		return &verifier.PublicKey{Type: kms.ED25519, Value: signer.PublicKeyBytes()}, nil
+	})
+
+	t.Run("unverified credential is reported", func(t *testing.T) {
+		result := &VerificationResult{}
+
+		vp, err := newTestPresentation(t, vpBytes, fetcher, WithPresBestEffortCredentialVerification(),
+			WithPresVerificationResult(result))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 2)
+		require.IsType(t, []byte{}, vp.Credentials()[0])
+		require.IsType(t, "", vp.Credentials()[1])
+
+		require.Len(t, result.Credentials, 2)
+		require.Equal(t, CredentialVerificationResult{Index: 0, ProofChecked: true}, result.Credentials[0])
+		require.True(t, result.Credentials[0].Verified())
+		require.Equal(t, 1, result.Credentials[1].Index)
+		require.True(t, result.Credentials[1].ProofChecked)
+		require.False(t, result.Credentials[1].Verified())
+		require.Error(t, result.Credentials[1].Err)
+		require.Contains(t, result.Credentials[1].Err.Error(), "DID is not found")
+	})
+
+	t.Run("unchecked credential is not verified", func(t *testing.T) {
+		result := &VerificationResult{}
+
+		_, err := newTestPresentation(t, vpBytes, WithPresDisabledProofCheck(),
+			WithPresBestEffortCredentialVerification(), WithPresVerificationResult(result))
+		require.NoError(t, err)
+		require.Equal(t, []CredentialVerificationResult{{Index: 0}, {Index: 1}}, result.Credentials)
+		require.False(t, result.Credentials[0].Verified())
+	})
+
+	t.Run("decoding fails without the option", func(t *testing.T) {
+		_, err := newTestPresentation(t, vpBytes, fetcher, WithPresVerificationResult(&VerificationResult{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DID is not found")
+	})
+
+	t.Run("option requires verification result", func(t *testing.T) {
+		_, err := newTestPresentation(t, vpBytes, fetcher, WithPresBestEffortCredentialVerification())
+		require.EqualError(t, err, "best effort credential verification requires verification result")
+
+		_, errs := ParsePresentations([][]byte{vpBytes}, fetcher, WithPresBestEffortCredentialVerification(),
+			WithPresVerificationResult(&VerificationResult{}))
+		require.Len(t, errs, 1)
+		require.EqualError(t, errs[0], "best effort credential verification requires verification result")
+	})
+}
+
+func TestParseJWSHeaders(t *testing.T) {
+	_, err := parseJWSHeaders("!.payload.signature")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode JWS headers")
+
+	_, err = parseJWSHeaders("bm90IEpTT04.payload.signature")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unmarshal JWS headers")
+}
@@ -0,0 +1,142 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultPresentationChallengeTTL is how long the challenge issued by PresentationVerifier is valid by default.
+const defaultPresentationChallengeTTL = 5 * time.Minute
+
+// ErrChallengeExpired is returned by PresentationVerifier.Verify if the presentation has the challenge
+// issued by the verifier, but it has expired.
+var ErrChallengeExpired = errors.New("presentation challenge has expired")
+
+// PresentationVerifierOpt is the PresentationVerifier option.
+type PresentationVerifierOpt func(verifier *PresentationVerifier)
+
+// WithPresChallengeGenerator sets the function generating challenges issued by PresentationVerifier.
+// GeneratePresentationChallenge is used by default.
+func WithPresChallengeGenerator(generate func() (string, error)) PresentationVerifierOpt {
+	return func(verifier *PresentationVerifier) {
+		verifier.generate = generate
+	}
+}
+
+// WithPresChallengeTTL sets how long the challenge issued by PresentationVerifier is valid (5 minutes by default).
+func WithPresChallengeTTL(ttl time.Duration) PresentationVerifierOpt {
+	return func(verifier *PresentationVerifier) {
+		verifier.ttl = ttl
+	}
+}
+
+// PresentationVerifier keeps the challenges issued to the holders in the requests of Verifiable Presentations
+// and checks that the presentations returned by the holders have one of them. Each challenge can be used once,
+// so a presentation cannot be replayed. The challenges are valid until they are used or expired according to
+// the package clock (see SetClock). PresentationVerifier is safe for concurrent use.
+type PresentationVerifier struct {
+	generate func() (string, error)
+	ttl      time.Duration
+
+	mu         sync.Mutex
+	challenges map[string]time.Time
+}
+
+// NewPresentationVerifier creates a new instance of PresentationVerifier.
+func NewPresentationVerifier(opts ...PresentationVerifierOpt) *PresentationVerifier {
+	verifier := &PresentationVerifier{
+		generate:   GeneratePresentationChallenge,
+		ttl:        defaultPresentationChallengeTTL,
+		challenges: make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(verifier)
+	}
+
+	return verifier
+}
+
+// Challenge issues a new challenge to be put into the request of Verifiable Presentation.
+func (v *PresentationVerifier) Challenge() (string, error) {
+	challenge, err := v.generate()
+	if err != nil {
+		return "", err
+	}
+
+	if challenge == "" {
+		return "", errors.New("generated presentation challenge is empty")
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.removeExpired()
+
+	if _, ok := v.challenges[challenge]; ok {
+		return "", fmt.Errorf("presentation challenge %s is already issued", challenge)
+	}
+
+	v.challenges[challenge] = now().Add(v.ttl)
+
+	return challenge, nil
+}
+
+// Verify checks that the presentation has a linked data proof with the challenge issued by Challenge.
+// The challenge is consumed, so the same presentation is rejected next time. ErrChallengeMismatch is returned
+// if there is no such proof, and ErrChallengeExpired if the challenge has expired.
+// The proofs themselves are not checked, so the presentation must be parsed by ParsePresentation with
+// the proof check enabled.
+func (v *PresentationVerifier) Verify(vp *Presentation) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	expired := false
+
+	for _, p := range vp.Proofs {
+		challenge, ok := p["challenge"].(string)
+		if !ok {
+			continue
+		}
+
+		// The issued challenges are looked up rather than compared one by one. The lookup time does not reveal
+		// how close the challenge is to the issued one, as the map hash is randomly seeded.
+		expires, ok := v.challenges[challenge]
+		if !ok {
+			continue
+		}
+
+		delete(v.challenges, challenge)
+
+		if now().Before(expires) {
+			return nil
+		}
+
+		expired = true
+	}
+
+	v.removeExpired()
+
+	if expired {
+		return ErrChallengeExpired
+	}
+
+	return ErrChallengeMismatch
+}
+
+func (v *PresentationVerifier) removeExpired() {
+	t := now()
+
+	for challenge, expires := range v.challenges {
+		if !t.Before(expires) {
+			delete(v.challenges, challenge)
+		}
+	}
+}
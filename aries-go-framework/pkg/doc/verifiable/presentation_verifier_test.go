@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestPresentationVerifier(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	newVP := func(t *testing.T, challenge string) *Presentation {
+		t.Helper()
+
+		vp, err := NewPresentation()
+		require.NoError(t, err)
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:holder#key-1",
+			Challenge:               challenge,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		return vp
+	}
+
+	t.Run("challenge is used once", func(t *testing.T) {
+		verifier := NewPresentationVerifier()
+
+		challenge, err := verifier.Challenge()
+		require.NoError(t, err)
+
+		vp := newVP(t, challenge)
+
+		require.NoError(t, verifier.Verify(vp))
+		require.True(t, errors.Is(verifier.Verify(vp), ErrChallengeMismatch))
+	})
+
+	t.Run("challenge is not issued", func(t *testing.T) {
+		verifier := NewPresentationVerifier()
+
+		_, err := verifier.Challenge()
+		require.NoError(t, err)
+
+		challenge, err := GeneratePresentationChallenge()
+		require.NoError(t, err)
+
+		require.True(t, errors.Is(verifier.Verify(newVP(t, challenge)), ErrChallengeMismatch))
+
+		vp, err := NewPresentation()
+		require.NoError(t, err)
+		require.True(t, errors.Is(verifier.Verify(vp), ErrChallengeMismatch))
+	})
+
+	t.Run("challenge has expired", func(t *testing.T) {
+		issued := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		at := issued
+
+		SetClock(ClockFunc(func() time.Time { return at }))
+		defer SetClock(nil)
+
+		verifier := NewPresentationVerifier(WithPresChallengeTTL(time.Minute))
+
+		challenge, err := verifier.Challenge()
+		require.NoError(t, err)
+
+		at = issued.Add(59 * time.Second)
+		require.NoError(t, verifier.Verify(newVP(t, challenge)))
+
+		challenge, err = verifier.Challenge()
+		require.NoError(t, err)
+
+		at = at.Add(time.Minute)
+		require.True(t, errors.Is(verifier.Verify(newVP(t, challenge)), ErrChallengeExpired))
+		require.True(t, errors.Is(verifier.Verify(newVP(t, challenge)), ErrChallengeMismatch))
+	})
+
+	t.Run("custom challenge generator", func(t *testing.T) {
+		verifier := NewPresentationVerifier(WithPresChallengeGenerator(func() (string, error) {
+			return "challenge-1", nil
+		}))
+
+		challenge, err := verifier.Challenge()
+		require.NoError(t, err)
+		require.Equal(t, "challenge-1", challenge)
+
+		_, err = verifier.Challenge()
+		require.EqualError(t, err, "presentation challenge challenge-1 is already issued")
+
+		require.NoError(t, verifier.Verify(newVP(t, challenge)))
+
+		_, err = verifier.Challenge()
+		require.NoError(t, err)
+	})
+
+	t.Run("challenge generator fails", func(t *testing.T) {
+		verifier := NewPresentationVerifier(WithPresChallengeGenerator(func() (string, error) {
+			return "", errors.New("generator error")
+		}))
+
+		_, err := verifier.Challenge()
+		require.EqualError(t, err, "generator error")
+
+		verifier = NewPresentationVerifier(WithPresChallengeGenerator(func() (string, error) {
+			return "", nil
+		}))
+
+		_, err = verifier.Challenge()
+		require.EqualError(t, err, "generated presentation challenge is empty")
+	})
+}
@@ -0,0 +1,134 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+// proofPurposeRelationships maps proofPurpose of linked data proof to the DID document verification relationship
+// the verification method of the proof must be listed in.
+var proofPurposeRelationships = map[string]did.VerificationRelationship{ //nolint:gochecknoglobals
+	"assertionMethod":      did.AssertionMethod,
+	"authentication":       did.Authentication,
+	"capabilityInvocation": did.CapabilityInvocation,
+	"capabilityDelegation": did.CapabilityDelegation,
+	"keyAgreement":         did.KeyAgreement,
+}
+
+// checkProofsAuthorization checks that the verification method of every proof belongs to the controller
+// of the document (the issuer of the credential or the holder of the presentation) and is authorized
+// for the proof purpose, i.e. it is listed in the corresponding verification relationship of the controller's
+// DID document.
+func checkProofsAuthorization(proofs []map[string]interface{}, controller string, vdr vdrapi.Registry) error {
+	if controller == "" {
+		return errors.New("issuer or holder of the document is not defined")
+	}
+
+	for _, p := range proofs {
+		vmID := proofVerificationMethod(p)
+
+		purpose := safeStringValue(p["proofPurpose"])
+		if purpose == "" {
+			purpose = defaultProofPurpose
+		}
+
+		if err := checkMethodAuthorization(vmID, purpose, controller, vdr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkMethodAuthorization(vmID, purpose, controller string, vdr vdrapi.Registry) error {
+	relationship, ok := proofPurposeRelationships[purpose]
+	if !ok {
+		return fmt.Errorf("unsupported proof purpose %s", purpose)
+	}
+
+	// The method authorized in the DID document of someone else doesn't authorize the proof.
+	if err := checkMethodController(vmID, controller); err != nil {
+		return err
+	}
+
+	didID := strings.Split(vmID, "#")[0]
+
+	docResolution, err := vdr.Resolve(didID)
+	if err != nil {
+		return fmt.Errorf("resolve DID %s: %w", didID, err)
+	}
+
+	for _, verification := range docResolution.DIDDocument.VerificationMethods(relationship)[relationship] {
+		id := verification.VerificationMethod.ID
+		if strings.HasPrefix(id, "#") {
+			id = didID + id
+		}
+
+		if id == vmID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("verification method %s is not authorized for proof purpose %s", vmID, purpose)
+}
+
+// checkProofsController checks that the verification method of every proof belongs to the controller
+// of the document (the issuer of the credential or the holder of the presentation), so the proofs made
+// by someone else on behalf of the trusted issuer or holder are rejected (see WithTrustedIssuers).
+func checkProofsController(proofs []map[string]interface{}, controller string) error {
+	if controller == "" {
+		return errors.New("issuer or holder of the document is not defined")
+	}
+
+	for _, p := range proofs {
+		if err := checkMethodController(proofVerificationMethod(p), controller); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkMethodController(vmID, controller string) error {
+	if vmID == "" {
+		return errors.New("verification method of proof is not defined")
+	}
+
+	if didID := strings.Split(vmID, "#")[0]; didID != controller {
+		return fmt.Errorf("verification method %s does not belong to %s", vmID, controller)
+	}
+
+	return nil
+}
+
+func proofVerificationMethod(proof map[string]interface{}) string {
+	if vmID := safeStringValue(proof["verificationMethod"]); vmID != "" {
+		return vmID
+	}
+
+	return safeStringValue(proof["creator"])
+}
+
+// proofController returns the DID the proofs of the document must be made by, i.e. the issuer
+// of the credential or the holder of the presentation.
+func proofController(doc map[string]interface{}) string {
+	switch issuer := doc["issuer"].(type) {
+	case string:
+		return issuer
+	case map[string]interface{}:
+		return safeStringValue(issuer["id"])
+	}
+
+	holder, _ := doc["holder"].(string)
+
+	return holder
+}
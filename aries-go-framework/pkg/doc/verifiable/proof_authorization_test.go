@@ -0,0 +1,197 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+const authorizationDIDDoc = `{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "did:example:123456",
+  "verificationMethod": [
+    {
+      "id": "did:example:123456#key1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:123456",
+      "publicKeyBase58": "CDfabd1Vis8ok526GYNAPE7YGRRJUZpLDkZM35PDe4kf"
+    },
+    {
+      "id": "#key2",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "did:example:123456",
+      "publicKeyBase58": "CDfabd1Vis8ok526GYNAPE7YGRRJUZpLDkZM35PDe4kf"
+    }
+  ],
+  "assertionMethod": ["did:example:123456#key1"],
+  "authentication": ["#key2"]
+}`
+
+func newAuthorizationVDR(t *testing.T) *mockvdr.MockVDRegistry {
+	t.Helper()
+
+	didDoc, err := did.ParseDocument([]byte(authorizationDIDDoc))
+	require.NoError(t, err)
+
+	return &mockvdr.MockVDRegistry{ResolveValue: didDoc}
+}
+
+func TestCheckMethodAuthorization(t *testing.T) {
+	vdr := newAuthorizationVDR(t)
+
+	const controller = "did:example:123456"
+
+	require.NoError(t, checkMethodAuthorization("did:example:123456#key1", "assertionMethod", controller, vdr))
+	require.NoError(t, checkMethodAuthorization("did:example:123456#key2", "authentication", controller, vdr))
+
+	err := checkMethodAuthorization("did:example:123456#key2", "assertionMethod", controller, vdr)
+	require.EqualError(t, err,
+		"verification method did:example:123456#key2 is not authorized for proof purpose assertionMethod")
+
+	err = checkMethodAuthorization("did:example:123456#key1", "capabilityInvocation", controller, vdr)
+	require.EqualError(t, err,
+		"verification method did:example:123456#key1 is not authorized for proof purpose capabilityInvocation")
+
+	err = checkMethodAuthorization("did:example:123456#key1", "unknownPurpose", controller, vdr)
+	require.EqualError(t, err, "unsupported proof purpose unknownPurpose")
+
+	err = checkMethodAuthorization("", "assertionMethod", controller, vdr)
+	require.EqualError(t, err, "verification method of proof is not defined")
+
+	err = checkMethodAuthorization("did:example:123456#key1", "assertionMethod", controller,
+		&mockvdr.MockVDRegistry{ResolveErr: errors.New("not found")})
+	require.EqualError(t, err, "resolve DID did:example:123456: not found")
+
+	// the DID is not resolved for the method of other DID
+	err = checkMethodAuthorization("did:example:123456#key1", "assertionMethod", "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		&mockvdr.MockVDRegistry{ResolveErr: errors.New("not found")})
+	require.EqualError(t, err,
+		"verification method did:example:123456#key1 does not belong to did:example:76e12ec712ebc6f1c221ebfeb1f")
+
+	err = checkProofsAuthorization([]map[string]interface{}{{"verificationMethod": "did:example:123456#key1"}}, "", vdr)
+	require.EqualError(t, err, "issuer or holder of the document is not defined")
+}
+
+func TestWithVerifyMethodAuthorization(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vdr := newAuthorizationVDR(t)
+	fetcher := SingleKey(signer.PublicKeyBytes(), kms.ED25519)
+
+	signedCredential := func(t *testing.T, issuer, verificationMethod, purpose string) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer.ID = issuer
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ss,
+			VerificationMethod:      verificationMethod,
+			Purpose:                 purpose,
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	t.Run("authorized verification method", func(t *testing.T) {
+		_, err := parseTestCredential(t, signedCredential(t, "did:example:123456", "did:example:123456#key1", ""),
+			WithPublicKeyFetcher(fetcher), WithVerifyMethodAuthorization(vdr))
+		require.NoError(t, err)
+	})
+
+	t.Run("verification method is not authorized for the purpose", func(t *testing.T) {
+		vcBytes := signedCredential(t, "did:example:123456", "did:example:123456#key2", "assertionMethod")
+
+		// the proof itself is valid
+		_, err := parseTestCredential(t, vcBytes, WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithPublicKeyFetcher(fetcher), WithVerifyMethodAuthorization(vdr))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof: verification method did:example:123456#key2 "+
+			"is not authorized for proof purpose assertionMethod")
+	})
+
+	t.Run("verification method of other DID", func(t *testing.T) {
+		// did:example:123456#key1 is authorized for assertionMethod in the DID document of did:example:123456,
+		// but the credential is issued by other DID
+		vcBytes := signedCredential(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", "did:example:123456#key1", "")
+
+		_, err := parseTestCredential(t, vcBytes, WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithPublicKeyFetcher(fetcher), WithVerifyMethodAuthorization(vdr))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof: verification method did:example:123456#key1 "+
+			"does not belong to did:example:76e12ec712ebc6f1c221ebfeb1f")
+	})
+
+	t.Run("presentation proof authorized for authentication", func(t *testing.T) {
+		vc, err := parseTestCredential(t, signedCredential(t, "did:example:123456", "did:example:123456#key1", ""),
+			WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		vp.Holder = "did:example:123456"
+
+		err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ss,
+			VerificationMethod:      "did:example:123456#key2",
+			Purpose:                 "authentication",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vpBytes, err := vp.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(fetcher),
+			WithPresVerifyMethodAuthorization(vdr))
+		require.NoError(t, err)
+
+		// the enclosed credential is checked too
+		vc, err = parseTestCredential(t, signedCredential(t, "did:example:123456", "did:example:123456#key2", ""),
+			WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+
+		vp, err = NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		vpBytes, err = vp.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(fetcher),
+			WithPresVerifyMethodAuthorization(vdr), WithPresVerifyAllEmbedded(1))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification method did:example:123456#key2 "+
+			"is not authorized for proof purpose assertionMethod")
+	})
+}
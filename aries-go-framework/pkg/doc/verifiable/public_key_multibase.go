@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/multiformats/go-multibase"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+// secp256k1PubKeyMultiCodec is a code of compressed secp256k1 public key in multicodec table.
+const secp256k1PubKeyMultiCodec = 0xe7
+
+// PublicKeyFromMultibase decodes the public key encoded as multibase string of multicodec prefixed key bytes
+// (e.g. publicKeyMultibase of DID document verification method), e.g. "z6Mk...".
+// Ed25519, P-256 and secp256k1 (compressed form) keys are supported. ECDSA keys are returned in uncompressed
+// form together with JWK.
+func PublicKeyFromMultibase(mb string) (*verifier.PublicKey, error) {
+	_, mc, err := multibase.Decode(mb)
+	if err != nil {
+		return nil, fmt.Errorf("decode multibase public key: %w", err)
+	}
+
+	code, n := binary.Uvarint(mc)
+	if n <= 0 {
+		return nil, errors.New("decode multibase public key: invalid multicodec prefix")
+	}
+
+	keyBytes := mc[n:]
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		if len(keyBytes) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("decode multibase public key: invalid Ed25519 public key size %d", len(keyBytes))
+		}
+
+		return &verifier.PublicKey{Type: kms.ED25519, Value: keyBytes}, nil
+
+	case fingerprint.P256PubKeyMultiCodec:
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), keyBytes)
+		if x == nil {
+			return nil, errors.New("decode multibase public key: invalid P-256 public key")
+		}
+
+		return ecdsaPublicKey(kms.ECDSAP256IEEEP1363, &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+
+	case secp256k1PubKeyMultiCodec:
+		pubKey, err := btcec.ParsePubKey(keyBytes, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("decode multibase public key: invalid secp256k1 public key: %w", err)
+		}
+
+		return ecdsaPublicKey(kms.ECDSASecp256k1IEEEP1363, pubKey.ToECDSA())
+
+	default:
+		return nil, fmt.Errorf("decode multibase public key: unsupported multicodec 0x%x", code)
+	}
+}
+
+func ecdsaPublicKey(keyType string, pubKey *ecdsa.PublicKey) (*verifier.PublicKey, error) {
+	j, err := jwksupport.JWKFromKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode multibase public key: create JWK: %w", err)
+	}
+
+	return &verifier.PublicKey{
+		Type:  keyType,
+		Value: elliptic.Marshal(pubKey.Curve, pubKey.X, pubKey.Y),
+		JWK:   j,
+	}, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+func TestPublicKeyFromMultibase(t *testing.T) {
+	t.Run("Ed25519 key", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		pk, err := PublicKeyFromMultibase(fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, pubKey))
+		require.NoError(t, err)
+		require.Equal(t, kms.ED25519, pk.Type)
+		require.Equal(t, []byte(pubKey), pk.Value)
+
+		// the key can be used by the fetcher
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vcJWT := createEdDSAJWS(t, []byte(validCredential), signer, false)
+
+		mb := fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, signer.PublicKeyBytes())
+
+		vcFromJWT, err := parseTestCredential(t, vcJWT,
+			WithPublicKeyFetcher(func(_, _ string) (*verifier.PublicKey, error) {
+				return PublicKeyFromMultibase(mb)
+			}))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWT.ID)
+	})
+
+	t.Run("P-256 key", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		pk, err := PublicKeyFromMultibase(fingerprint.KeyFingerprint(fingerprint.P256PubKeyMultiCodec,
+			elliptic.MarshalCompressed(elliptic.P256(), privKey.X, privKey.Y)))
+		require.NoError(t, err)
+		require.Equal(t, kms.ECDSAP256IEEEP1363, pk.Type)
+		require.Equal(t, elliptic.Marshal(elliptic.P256(), privKey.X, privKey.Y), pk.Value)
+		require.NotNil(t, pk.JWK)
+		require.Equal(t, "P-256", pk.JWK.Crv)
+	})
+
+	t.Run("secp256k1 key", func(t *testing.T) {
+		privKey, err := btcec.NewPrivateKey(btcec.S256())
+		require.NoError(t, err)
+
+		pk, err := PublicKeyFromMultibase(fingerprint.KeyFingerprint(secp256k1PubKeyMultiCodec,
+			privKey.PubKey().SerializeCompressed()))
+		require.NoError(t, err)
+		require.Equal(t, kms.ECDSASecp256k1IEEEP1363, pk.Type)
+		require.Equal(t, privKey.PubKey().SerializeUncompressed(), pk.Value)
+		require.NotNil(t, pk.JWK)
+		require.Equal(t, "secp256k1", pk.JWK.Crv)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err := PublicKeyFromMultibase("not multibase")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode multibase public key")
+
+		_, err = PublicKeyFromMultibase(fingerprint.KeyFingerprint(fingerprint.BLS12381g2PubKeyMultiCodec,
+			make([]byte, 96)))
+		require.EqualError(t, err, "decode multibase public key: unsupported multicodec 0xeb")
+
+		_, err = PublicKeyFromMultibase(fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec,
+			make([]byte, 31)))
+		require.EqualError(t, err, "decode multibase public key: invalid Ed25519 public key size 31")
+
+		_, err = PublicKeyFromMultibase(fingerprint.KeyFingerprint(fingerprint.P256PubKeyMultiCodec,
+			make([]byte, 33)))
+		require.EqualError(t, err, "decode multibase public key: invalid P-256 public key")
+
+		_, err = PublicKeyFromMultibase(fingerprint.KeyFingerprint(secp256k1PubKeyMultiCodec, make([]byte, 33)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid secp256k1 public key")
+	})
+}
@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ReadError is returned by ParseCredentialReader and ParsePresentationReader when the data cannot be read
+// from the reader. Other errors returned by these functions are the decoding ones.
+type ReadError struct {
+	Err error
+}
+
+// Error returns a description of the read error.
+func (e *ReadError) Error() string {
+	return fmt.Sprintf("read error: %v", e.Err)
+}
+
+// Unwrap returns the underlying error of the reader.
+func (e *ReadError) Unwrap() error {
+	return e.Err
+}
+
+// ParseCredentialReader parses Verifiable Credential from the reader the same way as ParseCredential does.
+// If the data cannot be read, *ReadError is returned. If WithMaxDocumentSize is set, no more data
+// than the limit is read from the reader.
+func ParseCredentialReader(r io.Reader, opts ...CredentialOpt) (*Credential, error) {
+	vcData, err := readAll(r, getCredentialOpts(opts).maxDocumentSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseCredential(vcData, opts...)
+}
+
+// ParsePresentationReader parses Verifiable Presentation from the reader the same way as ParsePresentation does.
+// If the data cannot be read, *ReadError is returned. If WithPresMaxDocumentSize is set, no more data
+// than the limit is read from the reader.
+func ParsePresentationReader(r io.Reader, opts ...PresentationOpt) (*Presentation, error) {
+	vpData, err := readAll(r, getPresentationOpts(opts).maxDocumentSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePresentation(vpData, opts...)
+}
+
+// readAll reads the reader into a single buffer. Leading and trailing white space (e.g. a new line after
+// serialized JWT) is trimmed without copying. If maxSize is positive, the reader is not read beyond
+// the limit and an error is returned if there is more data.
+func readAll(r io.Reader, maxSize int) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if maxSize > 0 {
+		r = io.LimitReader(r, int64(maxSize)+1)
+	}
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, &ReadError{Err: err}
+	}
+
+	if maxSize > 0 && buf.Len() > maxSize {
+		return nil, fmt.Errorf("document size exceeds the limit of %d bytes", maxSize)
+	}
+
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
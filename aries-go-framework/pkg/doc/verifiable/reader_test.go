@@ -0,0 +1,134 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCredentialReader(t *testing.T) {
+	loader := createTestDocumentLoader(t)
+
+	t.Run("valid credential", func(t *testing.T) {
+		vc, err := ParseCredentialReader(strings.NewReader(validCredential), WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+
+		expected, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+		require.Equal(t, expected, vc)
+	})
+
+	t.Run("credential in JWT form followed by new line", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		vcFromJWT, err := ParseCredentialReader(strings.NewReader(vcJWT+"\n"),
+			WithJSONLDDocumentLoader(loader), WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWT.ID)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+
+		vc, err := ParseCredentialReader(iotest.ErrReader(readErr))
+		require.Nil(t, vc)
+
+		var e *ReadError
+		require.True(t, errors.As(err, &e))
+		require.True(t, errors.Is(err, readErr))
+		require.EqualError(t, err, "read error: connection reset")
+	})
+
+	t.Run("document is too large", func(t *testing.T) {
+		vc, err := ParseCredentialReader(strings.NewReader(validCredential),
+			WithJSONLDDocumentLoader(loader), WithMaxDocumentSize(len(validCredential)))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+
+		r := &countingReader{r: strings.NewReader(validCredential + strings.Repeat(" ", 1<<20))}
+
+		vc, err = ParseCredentialReader(r, WithJSONLDDocumentLoader(loader), WithMaxDocumentSize(len(validCredential)))
+		require.EqualError(t, err, fmt.Sprintf("document size exceeds the limit of %d bytes", len(validCredential)))
+		require.Nil(t, vc)
+		require.Equal(t, len(validCredential)+1, r.n)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		vc, err := ParseCredentialReader(strings.NewReader("{"), WithJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Nil(t, vc)
+
+		var e *ReadError
+		require.False(t, errors.As(err, &e))
+	})
+}
+
+func TestParsePresentationReader(t *testing.T) {
+	loader := createTestDocumentLoader(t)
+
+	t.Run("valid presentation", func(t *testing.T) {
+		vp, err := ParsePresentationReader(strings.NewReader(validPresentation),
+			WithPresJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+
+		expected, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+		require.Equal(t, expected, vp)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		vp, err := ParsePresentationReader(iotest.ErrReader(errors.New("connection reset")))
+		require.Nil(t, vp)
+
+		var e *ReadError
+		require.True(t, errors.As(err, &e))
+	})
+
+	t.Run("document is too large", func(t *testing.T) {
+		r := &countingReader{r: strings.NewReader(validPresentation)}
+
+		vp, err := ParsePresentationReader(r, WithPresJSONLDDocumentLoader(loader), WithPresMaxDocumentSize(100))
+		require.EqualError(t, err, "document size exceeds the limit of 100 bytes")
+		require.Nil(t, vp)
+		require.Equal(t, 101, r.n)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		vp, err := ParsePresentationReader(strings.NewReader("{"), WithPresJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Nil(t, vp)
+
+		var e *ReadError
+		require.False(t, errors.As(err, &e))
+	})
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+
+	return n, err
+}
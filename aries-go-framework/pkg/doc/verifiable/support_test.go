@@ -6,8 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"bytes"
 	_ "embed"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -192,9 +196,48 @@ func parseTestCredential(t *testing.T, vcData []byte, opts ...CredentialOpt) (*C
 		append([]CredentialOpt{WithJSONLDDocumentLoader(createTestDocumentLoader(t))}, opts...)...)
 }
 
+// withoutJWT returns a copy of the credential parsed from JWT without the recorded JWT, i.e. the credential
+// as if it was parsed from JSON.
+func withoutJWT(vc *Credential) *Credential {
+	vcCopy := *vc
+	vcCopy.jwt = ""
+
+	return &vcCopy
+}
+
 func newTestPresentation(t *testing.T, vpData []byte, opts ...PresentationOpt) (*Presentation, error) {
 	t.Helper()
 
 	return ParsePresentation(vpData,
 		append([]PresentationOpt{WithPresJSONLDDocumentLoader(createTestDocumentLoader(t))}, opts...)...)
 }
+
+// countingTransport counts HTTP requests made using the client. The documents are served from memory
+// if their URLs are known, other requests are forwarded to the default transport.
+type countingTransport struct {
+	documents map[string][]byte
+	calls     int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&c.calls, 1)
+
+	if doc, ok := c.documents[req.URL.String()]; ok {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/ld+json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(doc)),
+			Request:    req,
+		}, nil
+	}
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (c *countingTransport) client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+func (c *countingTransport) count() int32 {
+	return atomic.LoadInt32(&c.calls)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUntrustedIssuer is returned when the issuer of the credential is not one of the trusted issuers
+// (see WithTrustedIssuers).
+var ErrUntrustedIssuer = errors.New("credential issuer is not trusted")
+
+// ErrUntrustedHolder is returned when the holder of the presentation is not one of the trusted holders
+// (see WithPresTrustedHolders).
+var ErrUntrustedHolder = errors.New("presentation holder is not trusted")
+
+// WithTrustedIssuers option defines the allowlist of issuers (e.g. DIDs). The credential is rejected with
+// ErrUntrustedIssuer if its issuer id is not in the list, i.e. an empty list rejects all the credentials.
+// As the issuer field can be set by anyone, the linked data proofs of the credential must be made
+// by the verification methods of the issuer's DID as well. The option can be used several times to extend the list.
+func WithTrustedIssuers(dids ...string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		if opts.trustedIssuers == nil {
+			opts.trustedIssuers = make(map[string]bool)
+		}
+
+		for _, did := range dids {
+			opts.trustedIssuers[did] = true
+		}
+	}
+}
+
+// WithPresTrustedHolders option defines the allowlist of holders (e.g. DIDs). The presentation is rejected with
+// ErrUntrustedHolder if its holder is not in the list (or is not defined). The linked data proofs of the presentation
+// must be made by the verification methods of the holder's DID as well. The holders of the nested presentations
+// are not checked. The option can be used several times to extend the list.
+func WithPresTrustedHolders(dids ...string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		if opts.trustedHolders == nil {
+			opts.trustedHolders = make(map[string]bool)
+		}
+
+		for _, did := range dids {
+			opts.trustedHolders[did] = true
+		}
+	}
+}
+
+// checkTrustedIssuer checks the issuer against the trusted issuers if they are defined.
+func checkTrustedIssuer(issuerID string, trustedIssuers map[string]bool) error {
+	if trustedIssuers == nil || trustedIssuers[issuerID] {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrUntrustedIssuer, issuerID)
+}
+
+// checkTrustedHolder checks the holder against the trusted holders if they are defined.
+func checkTrustedHolder(holder string, trustedHolders map[string]bool) error {
+	if trustedHolders == nil || trustedHolders[holder] {
+		return nil
+	}
+
+	if holder == "" {
+		return fmt.Errorf("%w: holder is not defined", ErrUntrustedHolder)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUntrustedHolder, holder)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestWithTrustedIssuers(t *testing.T) {
+	const issuerID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+	t.Run("trusted issuer", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential),
+			WithTrustedIssuers("did:example:other"), WithTrustedIssuers(issuerID))
+		require.NoError(t, err)
+		require.Equal(t, issuerID, vc.Issuer.ID)
+	})
+
+	t.Run("untrusted issuer", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential), WithTrustedIssuers("did:example:other"))
+		require.ErrorIs(t, err, ErrUntrustedIssuer)
+		require.EqualError(t, err, "credential issuer is not trusted: "+issuerID)
+	})
+
+	t.Run("empty allowlist", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential), WithTrustedIssuers())
+		require.ErrorIs(t, err, ErrUntrustedIssuer)
+	})
+
+	t.Run("checked before proof verification", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, issuerID+"#key1")
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(vcJWS),
+			WithPublicKeyFetcher(func(string, string) (*verifier.PublicKey, error) {
+				require.FailNow(t, "public key must not be fetched")
+
+				return nil, nil
+			}),
+			WithTrustedIssuers("did:example:other"))
+		require.ErrorIs(t, err, ErrUntrustedIssuer)
+	})
+
+	t.Run("proof is made by other DID", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		signedCredential := func(t *testing.T, verificationMethod string) []byte {
+			t.Helper()
+
+			vc, err := parseTestCredential(t, []byte(validCredential))
+			require.NoError(t, err)
+
+			err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				SignatureRepresentation: SignatureJWS,
+				Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+				VerificationMethod:      verificationMethod,
+			}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+			require.NoError(t, err)
+
+			vcBytes, err := vc.MarshalJSON()
+			require.NoError(t, err)
+
+			return vcBytes
+		}
+
+		fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+		_, err = parseTestCredential(t, signedCredential(t, issuerID+"#key1"), fetcher, WithTrustedIssuers(issuerID))
+		require.NoError(t, err)
+
+		// the trusted issuer is put into the credential signed by someone else
+		vcBytes := signedCredential(t, "did:example:attacker#key1")
+
+		_, err = parseTestCredential(t, vcBytes, fetcher)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, fetcher, WithTrustedIssuers(issuerID))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification method did:example:attacker#key1 does not belong to "+issuerID)
+	})
+}
+
+func TestWithPresTrustedHolders(t *testing.T) {
+	const holder = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+	t.Run("trusted holder", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation), WithPresTrustedHolders(holder))
+		require.NoError(t, err)
+		require.Equal(t, holder, vp.Holder)
+	})
+
+	t.Run("untrusted holder", func(t *testing.T) {
+		_, err := newTestPresentation(t, []byte(validPresentation), WithPresTrustedHolders("did:example:other"))
+		require.ErrorIs(t, err, ErrUntrustedHolder)
+		require.EqualError(t, err, "presentation holder is not trusted: "+holder)
+	})
+
+	t.Run("holder is not defined", func(t *testing.T) {
+		var vpMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(validPresentation), &vpMap))
+		delete(vpMap, "holder")
+
+		vpBytes, err := json.Marshal(vpMap)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresTrustedHolders(holder))
+		require.ErrorIs(t, err, ErrUntrustedHolder)
+		require.EqualError(t, err, "presentation holder is not trusted: holder is not defined")
+	})
+
+	t.Run("proof is made by other DID", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		signedPresentation := func(t *testing.T, verificationMethod string) []byte {
+			t.Helper()
+
+			vp, err := NewPresentation()
+			require.NoError(t, err)
+
+			vp.Holder = holder
+
+			err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				SignatureRepresentation: SignatureJWS,
+				Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+				VerificationMethod:      verificationMethod,
+				Purpose:                 "authentication",
+			}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+			require.NoError(t, err)
+
+			vpBytes, err := vp.MarshalJSON()
+			require.NoError(t, err)
+
+			return vpBytes
+		}
+
+		fetcher := WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+		_, err = newTestPresentation(t, signedPresentation(t, holder+"#key1"), fetcher, WithPresTrustedHolders(holder))
+		require.NoError(t, err)
+
+		// the trusted holder is put into the presentation signed by someone else
+		vpBytes := signedPresentation(t, "did:example:attacker#key1")
+
+		_, err = newTestPresentation(t, vpBytes, fetcher)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, vpBytes, fetcher, WithPresTrustedHolders(holder))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verification method did:example:attacker#key1 does not belong to "+holder)
+	})
+}